@@ -0,0 +1,48 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"time"
+
+	"20dojo-online/pkg/constant"
+	"20dojo-online/pkg/db"
+	"20dojo-online/pkg/leaderboard"
+	"20dojo-online/pkg/server/model"
+	"20dojo-online/pkg/server/service"
+)
+
+var (
+	// 締める集計期間
+	period string
+)
+
+func init() {
+	flag.StringVar(&period, "period", constant.RankingPeriodDaily, "ranking period to close (daily or weekly)")
+	flag.Parse()
+}
+
+// 直前に終了した期間別ランキングを締め、上位のユーザを記録して報酬コインを付与する
+// 日次のリセット時刻の後に定期実行する. 締め処理済みの期間に対して実行しても何もしない
+func main() {
+	rankingService := service.NewRankingService(
+		model.NewUserRepository(db.Conn),
+		model.NewGamePlayRepository(db.Conn),
+		model.NewRankingSeasonRepository(db.Conn),
+		model.NewRankingSnapshotRepository(db.Conn),
+		model.NewFriendRepository(db.Conn),
+		leaderboard.NewRedisFactory(leaderboard.NewRedisPool(leaderboard.RedisAddrFromEnv())),
+	)
+	res, err := rankingService.CloseRankingPeriod(&service.CloseRankingPeriodRequest{
+		Period: period,
+		Now:    time.Now(),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if res.AlreadyClosed {
+		log.Printf("%s ranking [%s, %s) is already closed", period, res.StartAt, res.EndAt)
+		return
+	}
+	log.Printf("%s ranking [%s, %s) closed. %d users archived", period, res.StartAt, res.EndAt, len(res.Snapshots))
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"log"
+
+	"20dojo-online/pkg/db"
+	"20dojo-online/pkg/leaderboard"
+	"20dojo-online/pkg/server/model"
+	"20dojo-online/pkg/server/service"
+)
+
+// データベースの全ユーザのハイスコアと現在の期間のゲームプレイ記録からRedisのランキングを作り直す
+func main() {
+	rankingService := service.NewRankingService(
+		model.NewUserRepository(db.Conn),
+		model.NewGamePlayRepository(db.Conn),
+		model.NewRankingSeasonRepository(db.Conn),
+		model.NewRankingSnapshotRepository(db.Conn),
+		model.NewFriendRepository(db.Conn),
+		leaderboard.NewRedisFactory(leaderboard.NewRedisPool(leaderboard.RedisAddrFromEnv())),
+	)
+	if err := rankingService.RebuildLeaderboard(); err != nil {
+		log.Fatal(err)
+	}
+	log.Println("leaderboard rebuilt")
+}
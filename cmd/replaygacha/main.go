@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"log"
+
+	"20dojo-online/pkg/db"
+	"20dojo-online/pkg/random"
+	"20dojo-online/pkg/server/model"
+	"20dojo-online/pkg/server/service"
+)
+
+var (
+	// 再現するガチャ実行履歴のID
+	historyID int64
+)
+
+func init() {
+	flag.Int64Var(&historyID, "id", 0, "gacha draw history id to replay")
+	flag.Parse()
+}
+
+// ガチャ実行履歴に記録したシードと実行前の進捗状態から排出アイテムを再現し、記録された排出アイテムと一致するかを確認する
+func main() {
+	gachaDrawHistoryRepository := model.NewGachaDrawHistoryRepository(db.Conn)
+	gachaService := service.NewGachaService(
+		model.NewUserRepository(db.Conn),
+		model.NewGachaRepository(db.Conn),
+		model.NewGachaRepositoryRepository(db.Conn),
+		model.NewUserCollectionItemRepository(db.Conn),
+		model.NewCollectionItemRepository(db.Conn),
+		model.NewUserGachaPityRepository(db.Conn),
+		gachaDrawHistoryRepository,
+		model.NewGachaStepRepository(db.Conn),
+		model.NewUserGachaStepRepository(db.Conn),
+		model.NewUserGachaBoxItemRepository(db.Conn),
+		model.NewUserGachaTicketRepository(db.Conn),
+		model.NewUserGachaFreeDrawRepository(db.Conn),
+		random.NewCryptoSource(),
+	)
+
+	gachaDrawHistory, err := gachaDrawHistoryRepository.SelectGachaDrawHistoryByPrimaryKey(historyID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if gachaDrawHistory == nil {
+		log.Fatalf("gacha draw history not found. id=%d", historyID)
+	}
+	collectionItemID, err := gachaService.ReplayGachaDraw(gachaDrawHistory)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if collectionItemID != gachaDrawHistory.CollectionItemID {
+		log.Fatalf("gacha draw history %d is not reproduced. recorded=%s, replayed=%s", historyID, gachaDrawHistory.CollectionItemID, collectionItemID)
+	}
+	log.Printf("gacha draw history %d is reproduced. collectionItemID=%s", historyID, collectionItemID)
+}
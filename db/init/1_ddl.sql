@@ -28,8 +28,11 @@ CREATE TABLE IF NOT EXISTS `dojo_api`.`user` (
   `name` VARCHAR(64) NOT NULL COMMENT 'ユーザ名',
   `high_score` INT UNSIGNED NOT NULL COMMENT 'ハイスコア',
   `coin` INT UNSIGNED NOT NULL COMMENT '所持コイン',
+  `shard` INT UNSIGNED NOT NULL DEFAULT 0 COMMENT '所持シャード',
+  `high_score_updated_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT 'ハイスコア達成日時(同点時の順位付けに使用)',
   PRIMARY KEY (`id`),
-  INDEX `idx_auth_token` (`auth_token` ASC))
+  INDEX `idx_auth_token` (`auth_token` ASC),
+  INDEX `idx_high_score` (`high_score` DESC, `high_score_updated_at` ASC))
 ENGINE = InnoDB
 COMMENT = 'ユーザ';
 
@@ -52,6 +55,10 @@ COMMENT = 'コレクションアイテム';
 CREATE TABLE IF NOT EXISTS `dojo_api`.`user_collection_item` (
   `user_id` VARCHAR(128) NOT NULL COMMENT 'ユーザID',
   `collection_item_id` VARCHAR(128) NOT NULL COMMENT 'コレクションアイテムID',
+  `count` INT UNSIGNED NOT NULL DEFAULT 1 COMMENT '獲得数',
+  `level` INT UNSIGNED NOT NULL DEFAULT 1 COMMENT 'アイテムレベル',
+  `first_acquired_at` DATETIME NOT NULL COMMENT '初回獲得日時',
+  `last_acquired_at` DATETIME NOT NULL COMMENT '最終獲得日時',
   PRIMARY KEY (`user_id`, `collection_item_id`),
   INDEX `fk_user_collection_item_user_idx` (`user_id` ASC),
   INDEX `fk_user_collection_item_collection_item_idx` (`collection_item_id` ASC),
@@ -69,14 +76,37 @@ ENGINE = InnoDB
 COMMENT = 'ユーザ所持コレクションアイテム';
 
 
+-- -----------------------------------------------------
+-- Table `dojo_api`.`gacha`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `dojo_api`.`gacha` (
+  `id` VARCHAR(128) NOT NULL COMMENT 'ガチャID',
+  `name` VARCHAR(64) NOT NULL COMMENT 'ガチャ名',
+  `coin_consumption` INT UNSIGNED NOT NULL COMMENT '1回あたりの消費コイン',
+  `mode` VARCHAR(16) NOT NULL DEFAULT 'normal' COMMENT 'ガチャの種類(normal=通常, box=ボックス, step_up=ステップアップ)',
+  `start_at` DATETIME NOT NULL COMMENT '開催開始日時',
+  `end_at` DATETIME NOT NULL COMMENT '開催終了日時',
+  `updated_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6) COMMENT '更新日時(排出情報の更新も含む)',
+  PRIMARY KEY (`id`))
+ENGINE = InnoDB
+COMMENT = 'ガチャ';
+
+
 -- -----------------------------------------------------
 -- Table `dojo_api`.`gacha_probability`
 -- -----------------------------------------------------
 CREATE TABLE IF NOT EXISTS `dojo_api`.`gacha_probability` (
+  `gacha_id` VARCHAR(128) NOT NULL COMMENT 'ガチャID',
   `collection_item_id` VARCHAR(128) NOT NULL COMMENT 'コレクションアイテムID',
-  `ratio` INT UNSIGNED NOT NULL COMMENT '排出重み',
+  `ratio` INT UNSIGNED NOT NULL COMMENT '排出重み(ボックスガチャではボックス内の個数)',
+  INDEX `fk_gacha_probability_gacha_idx` (`gacha_id` ASC),
   INDEX `fk_gacha_probability_collection_item_idx` (`collection_item_id` ASC),
-  PRIMARY KEY (`collection_item_id`),
+  PRIMARY KEY (`gacha_id`, `collection_item_id`),
+  CONSTRAINT `fk_gacha_probability_gacha_id`
+    FOREIGN KEY (`gacha_id`)
+    REFERENCES `dojo_api`.`gacha` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION,
   CONSTRAINT `fk_gacha_probability_collection_item_id`
     FOREIGN KEY (`collection_item_id`)
     REFERENCES `dojo_api`.`collection_item` (`id`)
@@ -86,6 +116,444 @@ ENGINE = InnoDB
 COMMENT = 'ガチャ排出情報';
 
 
+-- -----------------------------------------------------
+-- Trigger: 排出情報・アイテムの変更時にガチャの更新日時を更新する
+-- (APIサーバは更新日時を見てメモリ上の排出対象のキャッシュを作り直す)
+-- -----------------------------------------------------
+CREATE TRIGGER `dojo_api`.`trg_gacha_probability_after_insert` AFTER INSERT ON `dojo_api`.`gacha_probability`
+  FOR EACH ROW UPDATE `dojo_api`.`gacha` SET `updated_at` = CURRENT_TIMESTAMP(6) WHERE `id` = NEW.`gacha_id`;
+
+CREATE TRIGGER `dojo_api`.`trg_gacha_probability_after_update` AFTER UPDATE ON `dojo_api`.`gacha_probability`
+  FOR EACH ROW UPDATE `dojo_api`.`gacha` SET `updated_at` = CURRENT_TIMESTAMP(6) WHERE `id` IN (OLD.`gacha_id`, NEW.`gacha_id`);
+
+CREATE TRIGGER `dojo_api`.`trg_gacha_probability_after_delete` AFTER DELETE ON `dojo_api`.`gacha_probability`
+  FOR EACH ROW UPDATE `dojo_api`.`gacha` SET `updated_at` = CURRENT_TIMESTAMP(6) WHERE `id` = OLD.`gacha_id`;
+
+CREATE TRIGGER `dojo_api`.`trg_collection_item_after_update` AFTER UPDATE ON `dojo_api`.`collection_item`
+  FOR EACH ROW UPDATE `dojo_api`.`gacha` SET `updated_at` = CURRENT_TIMESTAMP(6);
+
+
+-- -----------------------------------------------------
+-- Table `dojo_api`.`user_gacha_pity`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `dojo_api`.`user_gacha_pity` (
+  `user_id` VARCHAR(128) NOT NULL COMMENT 'ユーザID',
+  `gacha_id` VARCHAR(128) NOT NULL COMMENT 'ガチャID',
+  `count` INT UNSIGNED NOT NULL COMMENT '最高レアリティが出ていない連続回数',
+  PRIMARY KEY (`user_id`, `gacha_id`),
+  INDEX `fk_user_gacha_pity_gacha_idx` (`gacha_id` ASC),
+  CONSTRAINT `fk_user_gacha_pity_user`
+    FOREIGN KEY (`user_id`)
+    REFERENCES `dojo_api`.`user` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION,
+  CONSTRAINT `fk_user_gacha_pity_gacha`
+    FOREIGN KEY (`gacha_id`)
+    REFERENCES `dojo_api`.`gacha` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION)
+ENGINE = InnoDB
+COMMENT = 'ユーザ別ガチャ天井カウント';
+
+
+-- -----------------------------------------------------
+-- Table `dojo_api`.`gacha_draw_history`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `dojo_api`.`gacha_draw_history` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ガチャ履歴ID',
+  `user_id` VARCHAR(128) NOT NULL COMMENT 'ユーザID',
+  `gacha_id` VARCHAR(128) NOT NULL COMMENT 'ガチャID',
+  `collection_item_id` VARCHAR(128) NOT NULL COMMENT 'コレクションアイテムID',
+  `rarity` INT NOT NULL COMMENT '排出時のレアリティ',
+  `coin_consumption` INT UNSIGNED NOT NULL COMMENT '消費コイン',
+  `payment` VARCHAR(16) NOT NULL DEFAULT 'coin' COMMENT '支払い方法(coin/ticket/free)',
+  `seed` BIGINT NOT NULL COMMENT '排出に利用した乱数シード',
+  `draw_index` INT UNSIGNED NOT NULL DEFAULT 0 COMMENT '乱数シードから排出した順番(0始まり)',
+  `pity_count` INT UNSIGNED NOT NULL DEFAULT 0 COMMENT '実行前の天井カウント',
+  `step` INT UNSIGNED NOT NULL DEFAULT 0 COMMENT '実行したステップ(ステップアップガチャ以外は0)',
+  `box_drawn_counts` TEXT NOT NULL COMMENT '実行前のボックスの排出済み個数(JSON. ボックスガチャ以外は空文字)',
+  `created_at` DATETIME NOT NULL COMMENT '実行日時',
+  PRIMARY KEY (`id`),
+  INDEX `idx_user_id_id` (`user_id` ASC, `id` ASC),
+  INDEX `fk_gacha_draw_history_gacha_idx` (`gacha_id` ASC),
+  INDEX `fk_gacha_draw_history_collection_item_idx` (`collection_item_id` ASC),
+  CONSTRAINT `fk_gacha_draw_history_user`
+    FOREIGN KEY (`user_id`)
+    REFERENCES `dojo_api`.`user` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION,
+  CONSTRAINT `fk_gacha_draw_history_gacha`
+    FOREIGN KEY (`gacha_id`)
+    REFERENCES `dojo_api`.`gacha` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION,
+  CONSTRAINT `fk_gacha_draw_history_collection_item`
+    FOREIGN KEY (`collection_item_id`)
+    REFERENCES `dojo_api`.`collection_item` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION)
+ENGINE = InnoDB
+COMMENT = 'ガチャ実行履歴';
+
+
+-- -----------------------------------------------------
+-- Table `dojo_api`.`gacha_step`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `dojo_api`.`gacha_step` (
+  `gacha_id` VARCHAR(128) NOT NULL COMMENT 'ガチャID',
+  `step` INT UNSIGNED NOT NULL COMMENT 'ステップ(1始まり)',
+  `times` INT UNSIGNED NOT NULL COMMENT '実行回数',
+  `coin_consumption` INT UNSIGNED NOT NULL COMMENT 'ステップ全体の消費コイン',
+  `guaranteed_rarity` INT NOT NULL COMMENT '最低1つ保証するレアリティ(0なら保証なし)',
+  PRIMARY KEY (`gacha_id`, `step`),
+  CONSTRAINT `fk_gacha_step_gacha`
+    FOREIGN KEY (`gacha_id`)
+    REFERENCES `dojo_api`.`gacha` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION)
+ENGINE = InnoDB
+COMMENT = 'ステップアップガチャのステップ';
+
+
+-- -----------------------------------------------------
+-- Table `dojo_api`.`user_gacha_step`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `dojo_api`.`user_gacha_step` (
+  `user_id` VARCHAR(128) NOT NULL COMMENT 'ユーザID',
+  `gacha_id` VARCHAR(128) NOT NULL COMMENT 'ガチャID',
+  `step` INT UNSIGNED NOT NULL COMMENT '次に実行するステップ',
+  PRIMARY KEY (`user_id`, `gacha_id`),
+  INDEX `fk_user_gacha_step_gacha_idx` (`gacha_id` ASC),
+  CONSTRAINT `fk_user_gacha_step_user`
+    FOREIGN KEY (`user_id`)
+    REFERENCES `dojo_api`.`user` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION,
+  CONSTRAINT `fk_user_gacha_step_gacha`
+    FOREIGN KEY (`gacha_id`)
+    REFERENCES `dojo_api`.`gacha` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION)
+ENGINE = InnoDB
+COMMENT = 'ユーザ別ステップアップガチャ進捗';
+
+
+-- -----------------------------------------------------
+-- Table `dojo_api`.`user_gacha_box_item`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `dojo_api`.`user_gacha_box_item` (
+  `user_id` VARCHAR(128) NOT NULL COMMENT 'ユーザID',
+  `gacha_id` VARCHAR(128) NOT NULL COMMENT 'ガチャID',
+  `collection_item_id` VARCHAR(128) NOT NULL COMMENT 'コレクションアイテムID',
+  `drawn_count` INT UNSIGNED NOT NULL COMMENT 'ボックスから排出済みの個数',
+  PRIMARY KEY (`user_id`, `gacha_id`, `collection_item_id`),
+  INDEX `fk_user_gacha_box_item_gacha_idx` (`gacha_id` ASC),
+  INDEX `fk_user_gacha_box_item_collection_item_idx` (`collection_item_id` ASC),
+  CONSTRAINT `fk_user_gacha_box_item_user`
+    FOREIGN KEY (`user_id`)
+    REFERENCES `dojo_api`.`user` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION,
+  CONSTRAINT `fk_user_gacha_box_item_gacha`
+    FOREIGN KEY (`gacha_id`)
+    REFERENCES `dojo_api`.`gacha` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION,
+  CONSTRAINT `fk_user_gacha_box_item_collection_item`
+    FOREIGN KEY (`collection_item_id`)
+    REFERENCES `dojo_api`.`collection_item` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION)
+ENGINE = InnoDB
+COMMENT = 'ユーザ別ボックスガチャ排出済み個数';
+
+
+-- -----------------------------------------------------
+-- Table `dojo_api`.`user_gacha_ticket`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `dojo_api`.`user_gacha_ticket` (
+  `user_id` VARCHAR(128) NOT NULL COMMENT 'ユーザID',
+  `count` INT UNSIGNED NOT NULL COMMENT 'ガチャチケットの所持数',
+  PRIMARY KEY (`user_id`),
+  CONSTRAINT `fk_user_gacha_ticket_user`
+    FOREIGN KEY (`user_id`)
+    REFERENCES `dojo_api`.`user` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION)
+ENGINE = InnoDB
+COMMENT = 'ユーザ別ガチャチケット所持数';
+
+
+-- -----------------------------------------------------
+-- Table `dojo_api`.`user_gacha_free_draw`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `dojo_api`.`user_gacha_free_draw` (
+  `user_id` VARCHAR(128) NOT NULL COMMENT 'ユーザID',
+  `last_draw_at` DATETIME NOT NULL COMMENT '無料ガチャの最終実行日時',
+  PRIMARY KEY (`user_id`),
+  CONSTRAINT `fk_user_gacha_free_draw_user`
+    FOREIGN KEY (`user_id`)
+    REFERENCES `dojo_api`.`user` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION)
+ENGINE = InnoDB
+COMMENT = 'ユーザ別無料ガチャ実行日時';
+
+
+-- -----------------------------------------------------
+-- Table `dojo_api`.`game_session`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `dojo_api`.`game_session` (
+  `id` VARCHAR(128) NOT NULL COMMENT 'ゲームセッションID',
+  `user_id` VARCHAR(128) NOT NULL COMMENT 'ユーザID',
+  `started_at` DATETIME NOT NULL COMMENT '開始日時',
+  `finished_at` DATETIME NULL COMMENT '終了日時(未終了の場合はNULL)',
+  PRIMARY KEY (`id`),
+  INDEX `fk_game_session_user_idx` (`user_id` ASC),
+  CONSTRAINT `fk_game_session_user`
+    FOREIGN KEY (`user_id`)
+    REFERENCES `dojo_api`.`user` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION)
+ENGINE = InnoDB
+COMMENT = 'ゲームセッション';
+
+
+-- -----------------------------------------------------
+-- Table `dojo_api`.`game_play`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `dojo_api`.`game_play` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ゲームプレイID',
+  `user_id` VARCHAR(128) NOT NULL COMMENT 'ユーザID',
+  `score` INT UNSIGNED NOT NULL COMMENT 'スコア',
+  `reward_coin` INT UNSIGNED NOT NULL COMMENT '獲得コイン',
+  `duration` INT UNSIGNED NOT NULL COMMENT 'プレイ時間(秒)',
+  `created_at` DATETIME NOT NULL COMMENT '終了日時',
+  PRIMARY KEY (`id`),
+  INDEX `idx_user_id_id` (`user_id` ASC, `id` ASC),
+  INDEX `idx_user_id_created_at` (`user_id` ASC, `created_at` ASC),
+  INDEX `idx_created_at` (`created_at` ASC),
+  CONSTRAINT `fk_game_play_user`
+    FOREIGN KEY (`user_id`)
+    REFERENCES `dojo_api`.`user` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION)
+ENGINE = InnoDB
+COMMENT = 'ゲームプレイ記録';
+
+
+-- -----------------------------------------------------
+-- Table `dojo_api`.`reward_event`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `dojo_api`.`reward_event` (
+  `id` VARCHAR(128) NOT NULL COMMENT '報酬イベントID',
+  `name` VARCHAR(64) NOT NULL COMMENT '報酬イベント名',
+  `multiplier` DOUBLE NOT NULL COMMENT '報酬コインの倍率',
+  `start_at` DATETIME NOT NULL COMMENT '開催開始日時',
+  `end_at` DATETIME NOT NULL COMMENT '開催終了日時',
+  PRIMARY KEY (`id`),
+  INDEX `idx_start_at_end_at` (`start_at` ASC, `end_at` ASC))
+ENGINE = InnoDB
+COMMENT = '期間限定の報酬倍率イベント';
+
+
+-- -----------------------------------------------------
+-- Table `dojo_api`.`ranking_season`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `dojo_api`.`ranking_season` (
+  `period` VARCHAR(16) NOT NULL COMMENT '集計期間の種類(daily, weekly)',
+  `start_at` DATETIME NOT NULL COMMENT '集計開始日時',
+  `end_at` DATETIME NOT NULL COMMENT '集計終了日時',
+  `closed_at` DATETIME NOT NULL COMMENT '締め処理の実行日時',
+  PRIMARY KEY (`period`, `start_at`))
+ENGINE = InnoDB
+COMMENT = '締め処理済みの期間別ランキング';
+
+
+-- -----------------------------------------------------
+-- Table `dojo_api`.`ranking_snapshot`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `dojo_api`.`ranking_snapshot` (
+  `period` VARCHAR(16) NOT NULL COMMENT '集計期間の種類(daily, weekly)',
+  `start_at` DATETIME NOT NULL COMMENT '集計開始日時',
+  `user_id` VARCHAR(128) NOT NULL COMMENT 'ユーザID',
+  `rank` INT UNSIGNED NOT NULL COMMENT '順位',
+  `score` INT UNSIGNED NOT NULL COMMENT '期間内の最高スコア',
+  `reward_coin` INT UNSIGNED NOT NULL COMMENT '報酬コイン',
+  PRIMARY KEY (`period`, `start_at`, `user_id`),
+  INDEX `idx_user_id` (`user_id` ASC),
+  CONSTRAINT `fk_ranking_snapshot_ranking_season`
+    FOREIGN KEY (`period`, `start_at`)
+    REFERENCES `dojo_api`.`ranking_season` (`period`, `start_at`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION,
+  CONSTRAINT `fk_ranking_snapshot_user`
+    FOREIGN KEY (`user_id`)
+    REFERENCES `dojo_api`.`user` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION)
+ENGINE = InnoDB
+COMMENT = '期間別ランキングの締め時点の上位ユーザ';
+
+
+-- -----------------------------------------------------
+-- Table `dojo_api`.`friend_request`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `dojo_api`.`friend_request` (
+  `user_id` VARCHAR(128) NOT NULL COMMENT '申請したユーザID',
+  `target_user_id` VARCHAR(128) NOT NULL COMMENT '申請されたユーザID',
+  `created_at` DATETIME NOT NULL COMMENT '申請日時',
+  PRIMARY KEY (`user_id`, `target_user_id`),
+  INDEX `idx_target_user_id` (`target_user_id` ASC),
+  CONSTRAINT `fk_friend_request_user`
+    FOREIGN KEY (`user_id`)
+    REFERENCES `dojo_api`.`user` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION,
+  CONSTRAINT `fk_friend_request_target_user`
+    FOREIGN KEY (`target_user_id`)
+    REFERENCES `dojo_api`.`user` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION)
+ENGINE = InnoDB
+COMMENT = '承認待ちのフレンド申請';
+
+
+-- -----------------------------------------------------
+-- Table `dojo_api`.`friend`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `dojo_api`.`friend` (
+  `user_id` VARCHAR(128) NOT NULL COMMENT 'ユーザID',
+  `friend_user_id` VARCHAR(128) NOT NULL COMMENT 'フレンドのユーザID',
+  `created_at` DATETIME NOT NULL COMMENT 'フレンドになった日時',
+  PRIMARY KEY (`user_id`, `friend_user_id`),
+  CONSTRAINT `fk_friend_user`
+    FOREIGN KEY (`user_id`)
+    REFERENCES `dojo_api`.`user` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION,
+  CONSTRAINT `fk_friend_friend_user`
+    FOREIGN KEY (`friend_user_id`)
+    REFERENCES `dojo_api`.`user` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION)
+ENGINE = InnoDB
+COMMENT = 'フレンド関係. 1組のフレンドにつき双方向の2レコードを保持する';
+
+
+-- -----------------------------------------------------
+-- Table `dojo_api`.`collection_set`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `dojo_api`.`collection_set` (
+  `id` VARCHAR(128) NOT NULL COMMENT 'コレクションセットID',
+  `name` VARCHAR(64) NOT NULL COMMENT 'コレクションセット名',
+  `rarity` INT NOT NULL COMMENT '対象のレアリティ(0ならcollection_set_itemで指定したアイテム)',
+  `reward_coin` INT UNSIGNED NOT NULL COMMENT 'コンプリート報酬のコイン',
+  `reward_ticket` INT UNSIGNED NOT NULL COMMENT 'コンプリート報酬のガチャチケット',
+  PRIMARY KEY (`id`))
+ENGINE = InnoDB
+COMMENT = 'コレクションセット';
+
+
+-- -----------------------------------------------------
+-- Table `dojo_api`.`collection_set_item`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `dojo_api`.`collection_set_item` (
+  `collection_set_id` VARCHAR(128) NOT NULL COMMENT 'コレクションセットID',
+  `collection_item_id` VARCHAR(128) NOT NULL COMMENT 'コレクションアイテムID',
+  PRIMARY KEY (`collection_set_id`, `collection_item_id`),
+  CONSTRAINT `fk_collection_set_item_collection_set`
+    FOREIGN KEY (`collection_set_id`)
+    REFERENCES `dojo_api`.`collection_set` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION,
+  CONSTRAINT `fk_collection_set_item_collection_item`
+    FOREIGN KEY (`collection_item_id`)
+    REFERENCES `dojo_api`.`collection_item` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION)
+ENGINE = InnoDB
+COMMENT = 'コレクションセットの対象アイテム(レアリティ指定のないセットのみ)';
+
+
+-- -----------------------------------------------------
+-- Table `dojo_api`.`user_collection_set_reward`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `dojo_api`.`user_collection_set_reward` (
+  `user_id` VARCHAR(128) NOT NULL COMMENT 'ユーザID',
+  `collection_set_id` VARCHAR(128) NOT NULL COMMENT 'コレクションセットID',
+  `reward_coin` INT UNSIGNED NOT NULL COMMENT '受け取ったコイン',
+  `reward_ticket` INT UNSIGNED NOT NULL COMMENT '受け取ったガチャチケット',
+  `claimed_at` DATETIME NOT NULL COMMENT '受け取り日時',
+  PRIMARY KEY (`user_id`, `collection_set_id`),
+  CONSTRAINT `fk_user_collection_set_reward_user`
+    FOREIGN KEY (`user_id`)
+    REFERENCES `dojo_api`.`user` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION,
+  CONSTRAINT `fk_user_collection_set_reward_collection_set`
+    FOREIGN KEY (`collection_set_id`)
+    REFERENCES `dojo_api`.`collection_set` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION)
+ENGINE = InnoDB
+COMMENT = 'コレクションセットのコンプリート報酬の受け取り記録';
+
+
+-- -----------------------------------------------------
+-- Table `dojo_api`.`trade`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `dojo_api`.`trade` (
+  `id` VARCHAR(128) NOT NULL COMMENT 'トレードID',
+  `proposer_user_id` VARCHAR(128) NOT NULL COMMENT '提案したユーザID',
+  `target_user_id` VARCHAR(128) NOT NULL COMMENT '提案されたユーザID',
+  `offer_coin` INT UNSIGNED NOT NULL DEFAULT 0 COMMENT '提案したユーザが渡すコイン',
+  `request_coin` INT UNSIGNED NOT NULL DEFAULT 0 COMMENT '提案されたユーザが渡すコイン',
+  `status` VARCHAR(16) NOT NULL COMMENT '状態(pending, accepted, declined, canceled)',
+  `expires_at` DATETIME NOT NULL COMMENT '有効期限',
+  `created_at` DATETIME NOT NULL COMMENT '提案日時',
+  `updated_at` DATETIME NOT NULL COMMENT '更新日時',
+  PRIMARY KEY (`id`),
+  INDEX `idx_proposer_user_id_status` (`proposer_user_id` ASC, `status` ASC),
+  INDEX `idx_target_user_id_status` (`target_user_id` ASC, `status` ASC),
+  CONSTRAINT `fk_trade_proposer_user`
+    FOREIGN KEY (`proposer_user_id`)
+    REFERENCES `dojo_api`.`user` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION,
+  CONSTRAINT `fk_trade_target_user`
+    FOREIGN KEY (`target_user_id`)
+    REFERENCES `dojo_api`.`user` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION)
+ENGINE = InnoDB
+COMMENT = 'ユーザ間のアイテムトレード';
+
+
+-- -----------------------------------------------------
+-- Table `dojo_api`.`trade_item`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `dojo_api`.`trade_item` (
+  `trade_id` VARCHAR(128) NOT NULL COMMENT 'トレードID',
+  `user_id` VARCHAR(128) NOT NULL COMMENT 'アイテムを渡すユーザID',
+  `collection_item_id` VARCHAR(128) NOT NULL COMMENT 'コレクションアイテムID',
+  `count` INT UNSIGNED NOT NULL COMMENT '個数',
+  PRIMARY KEY (`trade_id`, `user_id`, `collection_item_id`),
+  INDEX `fk_trade_item_collection_item_idx` (`collection_item_id` ASC),
+  CONSTRAINT `fk_trade_item_trade`
+    FOREIGN KEY (`trade_id`)
+    REFERENCES `dojo_api`.`trade` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION,
+  CONSTRAINT `fk_trade_item_collection_item`
+    FOREIGN KEY (`collection_item_id`)
+    REFERENCES `dojo_api`.`collection_item` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION)
+ENGINE = InnoDB
+COMMENT = 'トレードで受け渡すアイテム';
+
+
 SET SQL_MODE=@OLD_SQL_MODE;
 SET FOREIGN_KEY_CHECKS=@OLD_FOREIGN_KEY_CHECKS;
 SET UNIQUE_CHECKS=@OLD_UNIQUE_CHECKS;
@@ -123,123 +123,362 @@ INSERT INTO `collection_item` (`id`,`name`,`rarity`) VALUES ("3038","超スゴ
 INSERT INTO `collection_item` (`id`,`name`,`rarity`) VALUES ("3039","超スゴリラ39",3);
 INSERT INTO `collection_item` (`id`,`name`,`rarity`) VALUES ("3040","超スゴリラ40",3);
 
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1001",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1002",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1003",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1004",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1005",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1006",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1007",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1008",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1009",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1010",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1011",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1012",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1013",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1014",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1015",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1016",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1017",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1018",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1019",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1020",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1021",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1022",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1023",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1024",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1025",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1026",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1027",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1028",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1029",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1030",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1031",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1032",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1033",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1034",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1035",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1036",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1037",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1038",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1039",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("1040",6);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2001",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2002",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2003",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2004",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2005",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2006",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2007",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2008",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2009",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2010",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2011",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2012",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2013",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2014",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2015",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2016",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2017",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2018",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2019",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2020",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2021",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2022",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2023",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2024",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2025",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2026",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2027",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2028",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2029",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2030",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2031",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2032",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2033",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2034",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2035",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2036",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2037",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2038",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2039",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("2040",3);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3001",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3002",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3003",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3004",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3005",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3006",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3007",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3008",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3009",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3010",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3011",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3012",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3013",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3014",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3015",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3016",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3017",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3018",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3019",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3020",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3021",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3022",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3023",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3024",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3025",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3026",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3027",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3028",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3029",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3030",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3031",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3032",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3033",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3034",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3035",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3036",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3037",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3038",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3039",1);
-INSERT INTO `gacha_probability` (`collection_item_id`,`ratio`) VALUES ("3040",1);
+INSERT INTO `gacha` (`id`,`name`,`coin_consumption`,`mode`,`start_at`,`end_at`) VALUES ("1","スタンダードガチャ",100,"normal","2020-01-01 00:00:00","2099-12-31 23:59:59");
+INSERT INTO `gacha` (`id`,`name`,`coin_consumption`,`mode`,`start_at`,`end_at`) VALUES ("2","超スゴリラ限定ガチャ",300,"normal","2020-01-01 00:00:00","2099-12-31 23:59:59");
+INSERT INTO `gacha` (`id`,`name`,`coin_consumption`,`mode`,`start_at`,`end_at`) VALUES ("3","スゴリラボックスガチャ",200,"box","2020-01-01 00:00:00","2099-12-31 23:59:59");
+INSERT INTO `gacha` (`id`,`name`,`coin_consumption`,`mode`,`start_at`,`end_at`) VALUES ("4","スゴリラステップアップガチャ",100,"step_up","2020-01-01 00:00:00","2099-12-31 23:59:59");
+
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1001",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1002",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1003",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1004",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1005",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1006",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1007",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1008",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1009",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1010",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1011",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1012",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1013",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1014",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1015",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1016",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1017",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1018",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1019",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1020",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1021",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1022",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1023",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1024",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1025",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1026",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1027",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1028",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1029",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1030",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1031",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1032",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1033",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1034",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1035",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1036",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1037",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1038",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1039",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","1040",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2001",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2002",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2003",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2004",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2005",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2006",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2007",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2008",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2009",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2010",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2011",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2012",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2013",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2014",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2015",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2016",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2017",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2018",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2019",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2020",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2021",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2022",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2023",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2024",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2025",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2026",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2027",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2028",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2029",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2030",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2031",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2032",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2033",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2034",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2035",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2036",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2037",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2038",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2039",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","2040",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3001",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3002",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3003",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3004",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3005",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3006",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3007",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3008",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3009",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3010",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3011",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3012",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3013",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3014",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3015",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3016",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3017",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3018",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3019",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3020",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3021",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3022",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3023",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3024",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3025",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3026",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3027",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3028",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3029",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3030",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3031",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3032",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3033",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3034",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3035",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3036",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3037",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3038",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3039",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("1","3040",1);
+
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2001",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2002",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2003",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2004",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2005",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2006",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2007",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2008",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2009",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2010",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2011",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2012",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2013",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2014",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2015",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2016",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2017",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2018",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2019",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2020",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2021",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2022",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2023",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2024",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2025",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2026",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2027",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2028",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2029",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2030",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2031",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2032",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2033",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2034",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2035",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2036",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2037",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2038",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2039",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","2040",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3001",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3002",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3003",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3004",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3005",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3006",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3007",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3008",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3009",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3010",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3011",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3012",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3013",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3014",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3015",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3016",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3017",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3018",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3019",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3020",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3021",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3022",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3023",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3024",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3025",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3026",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3027",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3028",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3029",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3030",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3031",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3032",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3033",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3034",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3035",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3036",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3037",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3038",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3039",4);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("2","3040",4);
+
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("3","1001",5);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("3","1002",5);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("3","1003",5);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("3","1004",5);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("3","1005",5);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("3","1006",5);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("3","1007",5);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("3","1008",5);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("3","1009",5);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("3","1010",5);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("3","2001",2);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("3","2002",2);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("3","2003",2);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("3","2004",2);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("3","2005",2);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("3","3001",1);
+
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1001",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1002",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1003",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1004",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1005",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1006",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1007",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1008",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1009",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1010",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1011",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1012",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1013",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1014",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1015",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1016",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1017",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1018",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1019",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1020",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1021",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1022",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1023",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1024",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1025",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1026",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1027",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1028",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1029",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1030",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1031",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1032",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1033",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1034",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1035",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1036",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1037",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1038",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1039",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","1040",6);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2001",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2002",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2003",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2004",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2005",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2006",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2007",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2008",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2009",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2010",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2011",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2012",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2013",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2014",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2015",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2016",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2017",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2018",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2019",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2020",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2021",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2022",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2023",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2024",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2025",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2026",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2027",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2028",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2029",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2030",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2031",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2032",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2033",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2034",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2035",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2036",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2037",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2038",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2039",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","2040",3);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3001",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3002",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3003",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3004",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3005",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3006",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3007",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3008",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3009",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3010",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3011",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3012",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3013",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3014",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3015",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3016",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3017",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3018",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3019",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3020",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3021",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3022",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3023",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3024",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3025",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3026",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3027",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3028",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3029",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3030",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3031",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3032",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3033",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3034",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3035",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3036",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3037",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3038",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3039",1);
+INSERT INTO `gacha_probability` (`gacha_id`,`collection_item_id`,`ratio`) VALUES ("4","3040",1);
+
+INSERT INTO `gacha_step` (`gacha_id`,`step`,`times`,`coin_consumption`,`guaranteed_rarity`) VALUES ("4",1,10,500,2);
+INSERT INTO `gacha_step` (`gacha_id`,`step`,`times`,`coin_consumption`,`guaranteed_rarity`) VALUES ("4",2,10,800,2);
+INSERT INTO `gacha_step` (`gacha_id`,`step`,`times`,`coin_consumption`,`guaranteed_rarity`) VALUES ("4",3,10,1000,3);
+
+INSERT INTO `reward_event` (`id`,`name`,`multiplier`,`start_at`,`end_at`) VALUES ("1","リリース記念コイン2倍",2,"2020-08-24 00:00:00","2020-08-31 23:59:59");
+
+INSERT INTO `collection_set` (`id`,`name`,`rarity`,`reward_coin`,`reward_ticket`) VALUES ("1","スゴリラコンプリート",1,1000,1);
+INSERT INTO `collection_set` (`id`,`name`,`rarity`,`reward_coin`,`reward_ticket`) VALUES ("2","レアスゴリラコンプリート",2,3000,3);
+INSERT INTO `collection_set` (`id`,`name`,`rarity`,`reward_coin`,`reward_ticket`) VALUES ("3","超スゴリラコンプリート",3,10000,10);
+INSERT INTO `collection_set` (`id`,`name`,`rarity`,`reward_coin`,`reward_ticket`) VALUES ("4","スゴリラ三兄弟",0,500,1);
+
+INSERT INTO `collection_set_item` (`collection_set_id`,`collection_item_id`) VALUES ("4","1001");
+INSERT INTO `collection_set_item` (`collection_set_id`,`collection_item_id`) VALUES ("4","2001");
+INSERT INTO `collection_set_item` (`collection_set_id`,`collection_item_id`) VALUES ("4","3001");
@@ -3,8 +3,10 @@ module 20dojo-online
 go 1.14
 
 require (
+	github.com/alicebob/miniredis/v2 v2.14.1
 	github.com/go-sql-driver/mysql v1.4.1
 	github.com/golang/mock v1.5.0
+	github.com/gomodule/redigo v1.8.2
 	github.com/google/uuid v1.1.1
 	google.golang.org/appengine v1.6.1 // indirect
 )
@@ -1,10 +1,130 @@
 package constant
 
+import "time"
+
 const (
 	// ガチャ1回あたりのコイン消費量
 	GachaCoinConsumption int = 100
+	// ガチャIDの指定がない場合に実行する常設ガチャのID
+	DefaultGachaID string = "1"
+	// ガチャの種類: 通常(重み付きで無限に引ける)
+	GachaModeNormal string = "normal"
+	// ガチャの種類: ボックス(ユーザごとの有限の中身から引く)
+	GachaModeBox string = "box"
+	// ガチャの種類: ステップアップ(ステップごとに回数・消費コイン・保証が変わる)
+	GachaModeStepUp string = "step_up"
+	// ガチャの支払い方法: コイン
+	GachaPaymentCoin string = "coin"
+	// ガチャの支払い方法: ガチャチケット(1回につき1枚消費)
+	GachaPaymentTicket string = "ticket"
+	// ガチャの支払い方法: 1日1回の無料ガチャ(通常ガチャの1回実行のみ)
+	GachaPaymentFree string = "free"
+	// 1リクエストあたりのガチャ実行回数の上限
+	GachaMaxDrawTimes int = 100
+	// ユーザ名・ID文字列の最大文字数
+	MaxNameLength int = 64
+	// 無料ガチャの実行回数・報酬コインの上限など日次の集計がリセットされる時刻(時)
+	DailyResetHour int = 4
+	// 天井の対象となるレアリティ
+	GachaPityRarity int = 3
+	// 天井の対象レアリティが出ないまま何回引くと次回確定になるか
+	GachaPityThreshold int = 100
+	// 保証の単位となる連続実行回数(10連)
+	GachaGuaranteedTimes int = 10
+	// 10連で最低1つ保証するレアリティ
+	GachaGuaranteedRarity int = 2
+	// ゲームセッションの有効期限(開始からこの時間を過ぎると終了できない)
+	GameSessionExpiration time.Duration = 30 * time.Minute
+	// 1秒あたりに獲得できるスコアの上限
+	GameMaxScorePerSecond int = 100
 	// スコアに対する獲得コインの割合
 	RewardCoinRate float64 = 0.1
-	// 1リクエストあたりのランキング取得件数
+	// ハイスコア更新時のボーナスコイン
+	RewardNewHighScoreBonus int = 100
+	// 1日あたりに獲得できる報酬コインの上限. 上限を超える分の報酬は切り詰め、到達後のゲーム終了はエラーとする
+	RewardCoinDailyCap int = 10000
+	// 1時間あたりのゲームプレイ回数の上限
+	GameMaxPlaysPerHour int = 60
+	// 1リクエストあたりのランキング取得件数(指定がない場合)
 	RankingListLimit int = 10
+	// 1リクエストあたりのランキング取得件数の上限
+	RankingListMaxLimit int = 100
+	// 1リクエストあたりのコレクションアイテム取得件数(指定がない場合)
+	CollectionListLimit int = 50
+	// 1リクエストあたりのコレクションアイテム取得件数の上限
+	CollectionListMaxLimit int = 100
+	// コレクションアイテム一覧の並び順: ID順
+	CollectionSortID string = "id"
+	// コレクションアイテム一覧の並び順: レアリティの高い順(同じレアリティはID順)
+	CollectionSortRarity string = "rarity"
+	// コレクションアイテム一覧の並び順: 初回獲得日時の新しい順(未所持のアイテムは最後. 同じ日時はID順)
+	CollectionSortAcquired string = "acquired"
+	// コレクションアイテム一覧の所持状態の絞り込み: 所持しているアイテムのみ
+	CollectionOwnershipOwned string = "owned"
+	// コレクションアイテム一覧の所持状態の絞り込み: 所持していないアイテムのみ
+	CollectionOwnershipUnowned string = "unowned"
+	// 順位の付け方: 同点は同順位とし、次の順位は人数分飛ばす(1, 1, 3)
+	RankingModeCompetition string = "competition"
+	// 順位の付け方: 同点は同順位とし、次の順位は飛ばさない(1, 1, 2)
+	RankingModeDense string = "dense"
+	// 自分の順位の取得時に合わせて返す前後のユーザ数
+	RankingNeighborCount int = 5
+	// ハイスコアのランキングを保持するRedisのキー
+	LeaderboardKey string = "leaderboard:high_score"
+	// ランキングの集計期間: 全期間(ハイスコア)
+	RankingPeriodAllTime string = "all_time"
+	// ランキングの集計期間: 日別(日次のリセット時刻から1日)
+	RankingPeriodDaily string = "daily"
+	// ランキングの集計期間: 週別(月曜日の日次のリセット時刻から1週間)
+	RankingPeriodWeekly string = "weekly"
+	// 期間別ランキングのRedisのデータを最後の更新から保持する期間. 締め処理はデータベースから集計するため期間終了後は不要
+	RankingPeriodRetention time.Duration = 8 * 24 * time.Hour
+	// 期間別ランキングの締めで記録する上位のユーザ数
+	RankingSnapshotLimit int = 100
+	// 1ユーザあたりのフレンド数の上限
+	FriendLimit int = 100
+	// トレードの状態: 承認待ち(有効期限を過ぎたものは期限切れとして扱う)
+	TradeStatusPending string = "pending"
+	// トレードの状態: 承認済み(アイテム・コインの受け渡し済み)
+	TradeStatusAccepted string = "accepted"
+	// トレードの状態: 提案されたユーザが拒否
+	TradeStatusDeclined string = "declined"
+	// トレードの状態: 提案したユーザが取り消し
+	TradeStatusCanceled string = "canceled"
+	// トレードの提案から承認できなくなるまでの期間
+	TradeExpiration time.Duration = 72 * time.Hour
+	// 1つのトレードで片方のユーザが渡すアイテムの種類数の上限
+	TradeMaxItems int = 10
+	// 1ユーザあたりの承認待ちのトレード(提案したもの)の上限
+	TradePendingLimit int = 20
+	// 1リクエストあたりのガチャ実行履歴取得件数
+	GachaHistoryListLimit int = 20
+	// 1リクエストあたりのゲームプレイ履歴取得件数
+	GameHistoryListLimit int = 20
+	// ゲームプレイ履歴の集計対象とする日数
+	GameHistoryStatsDays int = 7
+)
+
+var (
+	// スコアの閾値ごとのボーナスコイン. 到達した最も高い閾値のボーナスのみ付与する
+	RewardScoreThresholdBonus = map[int]int{
+		1000:  50,
+		5000:  300,
+		10000: 1000,
+	}
+	// 許可するガチャ実行回数(空の場合はGachaMaxDrawTimes以下の任意の回数)
+	GachaAllowedDrawTimes = []int{1, 10}
+	// 重複アイテムをシャードへ変換する際のレアリティごとの変換量
+	DuplicateShardConversion = map[int]int{
+		1: 1,
+		2: 5,
+		3: 20,
+	}
+	// アイテムレベルごとに必要な獲得数. i番目の要素がレベルi+1に必要な獲得数
+	CollectionItemLevelCounts = []int{1, 2, 4, 7, 11}
+	// 期間別ランキングの締めで上位のユーザに付与する報酬コイン. i番目の要素がi+1位の報酬
+	RankingPeriodRewardCoins = map[string][]int{
+		RankingPeriodDaily:  {1000, 500, 300},
+		RankingPeriodWeekly: {5000, 3000, 1000, 500, 500},
+	}
 )
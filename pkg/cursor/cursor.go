@@ -0,0 +1,32 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalidCursor 形式不正のカーソル
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Encode ページングのカーソル(前のページの最後の要素のキー)を不透明な文字列に変換する
+// クライアントは中身を解釈せず、次のページの取得時にそのまま指定する
+func Encode(key interface{}) (string, error) {
+	data, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// Decode Encodeで作成した文字列からカーソルのキーを復元する. 形式不正の場合はErrInvalidCursorを返す
+func Decode(token string, key interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	if err = json.Unmarshal(data, key); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
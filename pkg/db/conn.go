@@ -31,9 +31,10 @@ func init() {
 
 	// 接続情報は以下のように指定する.
 	// user:password@tcp(host:port)/database
+	// DATETIME型をtime.Timeとして扱うためにparseTimeを指定する
 	var err error
 	Conn, err = sql.Open(driverName,
-		fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", user, password, host, port, database))
+		fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true", user, password, host, port, database))
 	if err != nil {
 		log.Fatal(err)
 	}
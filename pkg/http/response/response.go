@@ -42,7 +42,9 @@ func (hr *HttpResponse) Failed(writer http.ResponseWriter, err error) {
 	if errors.As(err, &appErr) {
 		switch appErr.Code {
 		case http.StatusBadRequest:
-			badRequest(writer, "Bad Request")
+			badRequest(writer, "Bad Request", appErr.FieldErrors)
+		case http.StatusTooManyRequests:
+			tooManyRequests(writer, "Too Many Requests")
 		case http.StatusInternalServerError:
 			internalServerError(writer, "Internal Server Error")
 		}
@@ -51,9 +53,21 @@ func (hr *HttpResponse) Failed(writer http.ResponseWriter, err error) {
 	}
 }
 
-// BadRequest HTTPコード:400 BadRequestを処理する
-func badRequest(writer http.ResponseWriter, message string) {
-	httpError(writer, http.StatusBadRequest, message)
+// BadRequest HTTPコード:400 BadRequestを処理する. 不正なフィールドがあればレスポンスに含める
+func badRequest(writer http.ResponseWriter, message string, fieldErrors []myerror.FieldError) {
+	var errs []*fieldErrorResponse
+	for _, fieldError := range fieldErrors {
+		errs = append(errs, &fieldErrorResponse{
+			Field:   fieldError.Field,
+			Message: fieldError.Message,
+		})
+	}
+	httpError(writer, http.StatusBadRequest, message, errs...)
+}
+
+// tooManyRequests HTTPコード:429 TooManyRequestsを処理する
+func tooManyRequests(writer http.ResponseWriter, message string) {
+	httpError(writer, http.StatusTooManyRequests, message)
 }
 
 // InternalServerError HTTPコード:500 InternalServerErrorを処理する
@@ -62,10 +76,11 @@ func internalServerError(writer http.ResponseWriter, message string) {
 }
 
 // httpError エラー用のレスポンス出力を行う
-func httpError(writer http.ResponseWriter, code int, message string) {
+func httpError(writer http.ResponseWriter, code int, message string, errs ...*fieldErrorResponse) {
 	data, _ := json.Marshal(errorResponse{
 		Code:    code,
 		Message: message,
+		Errors:  errs,
 	})
 	writer.WriteHeader(code)
 	if data != nil {
@@ -74,7 +89,13 @@ func httpError(writer http.ResponseWriter, code int, message string) {
 }
 
 type errorResponse struct {
-	Code    int    `json:"code"`
+	Code    int                   `json:"code"`
+	Message string                `json:"message"`
+	Errors  []*fieldErrorResponse `json:"errors,omitempty"`
+}
+
+type fieldErrorResponse struct {
+	Field   string `json:"field"`
 	Message string `json:"message"`
 }
 
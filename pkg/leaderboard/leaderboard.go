@@ -0,0 +1,57 @@
+package leaderboard
+
+import (
+	"sort"
+	"time"
+)
+
+// Entry ランキングに登録するユーザのハイスコア
+type Entry struct {
+	UserID     string
+	Score      int
+	AchievedAt time.Time // ハイスコアを達成した日時. 同点の場合は先に達成したユーザを上位とする
+}
+
+// Store ハイスコアのランキングを保持する
+// 並び順はスコアの降順、同点の場合は達成日時の昇順、それも同じ場合はユーザIDの昇順とする
+type Store interface {
+	// Set ユーザのハイスコアを登録する. 登録済みの場合は置き換える
+	Set(entry *Entry) error
+	// SetIfHigher 登録済みのスコアより高い場合(未登録の場合を含む)のみユーザのスコアを登録する
+	SetIfHigher(entry *Entry) error
+	// Range 並び順でstart番目(1始まり)から指定件数を取得する
+	Range(start int, limit int) ([]*Entry, error)
+	// Position ユーザの並び順での位置(1始まり)を取得する. 未登録の場合は0を返す
+	Position(userID string) (int, error)
+	// CountUntil 並び順で指定したハイスコア以前(同じものを含む)のユーザ数を取得する
+	// 指定したハイスコアが登録されていなくてもよく、カーソルによるページングの開始位置に利用する
+	CountUntil(entry *Entry) (int, error)
+	// CountGreater 指定したスコアより高いスコアのユーザ数を取得する
+	CountGreater(score int) (int, error)
+	// CountDistinctGreater 指定したスコアより高いスコアの種類数を取得する
+	CountDistinctGreater(score int) (int, error)
+	// Rebuild 登録済みのハイスコアを全て置き換える
+	Rebuild(entries []*Entry) error
+}
+
+// Factory キーごとのStoreを取得する. 期間別のランキングなどキーを切り替えて利用する
+type Factory interface {
+	// Store キーに対応するStoreを取得する. ttlが0より大きい場合は最後の更新からttl経過したデータを破棄する
+	Store(key string, ttl time.Duration) Store
+}
+
+// SortEntries 並び順に並べ替える
+func SortEntries(entries []*Entry) {
+	sort.Slice(entries, func(i, j int) bool { return less(entries[i], entries[j]) })
+}
+
+// less 並び順でaがbより前であるか
+func less(a, b *Entry) bool {
+	if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	if !a.AchievedAt.Equal(b.AchievedAt) {
+		return a.AchievedAt.Before(b.AchievedAt)
+	}
+	return a.UserID < b.UserID
+}
@@ -0,0 +1,395 @@
+package leaderboard
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+var baseTime = time.Date(2020, 8, 1, 12, 0, 0, 0, time.UTC)
+
+// testEntries テスト用のハイスコア. 並び順はUserId3, UserId2, UserId4, UserId1, UserId5
+// UserId2とUserId4は同点・同時刻のためユーザIDの昇順、UserId1は同点で後に達成したため後ろに並ぶ
+var testEntries = []*Entry{
+	{UserID: "UserId1", Score: 100, AchievedAt: baseTime.Add(2 * time.Second)},
+	{UserID: "UserId2", Score: 100, AchievedAt: baseTime.Add(time.Second)},
+	{UserID: "UserId3", Score: 200, AchievedAt: baseTime.Add(3 * time.Second)},
+	{UserID: "UserId4", Score: 100, AchievedAt: baseTime.Add(time.Second)},
+	{UserID: "UserId5", Score: 50, AchievedAt: baseTime},
+}
+
+// testStores MemoryStoreとRedisStoreで同じテストを実行する. RedisStoreはminiredisを利用する
+func testStores(t *testing.T, test func(t *testing.T, store Store)) {
+	stores := []struct {
+		name     string
+		newStore func(t *testing.T) Store
+	}{
+		{
+			name: "MemoryStore",
+			newStore: func(t *testing.T) Store {
+				return NewMemoryStore()
+			},
+		},
+		{
+			name: "RedisStore",
+			newStore: func(t *testing.T) Store {
+				mr, err := miniredis.Run()
+				if err != nil {
+					t.Fatal(err)
+				}
+				t.Cleanup(mr.Close)
+				pool := NewRedisPool(mr.Addr())
+				t.Cleanup(func() { pool.Close() })
+				return NewRedisFactory(pool).Store("ranking:test", time.Hour)
+			},
+		},
+	}
+	for _, s := range stores {
+		t.Run(s.name, func(t *testing.T) {
+			test(t, s.newStore(t))
+		})
+	}
+}
+
+// setEntries ハイスコアを順に登録する
+func setEntries(t *testing.T, store Store, entries []*Entry) {
+	for _, entry := range entries {
+		if err := store.Set(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// entryStrings 比較のためにハイスコアを"ユーザID:スコア:達成日時(秒)"の形式に変換する
+func entryStrings(entries []*Entry) []string {
+	values := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		values = append(values, fmt.Sprintf("%s:%d:%d", entry.UserID, entry.Score, entry.AchievedAt.Unix()))
+	}
+	return values
+}
+
+// allEntryStrings 登録済みの全てのハイスコアを並び順で取得する
+func allEntryStrings(t *testing.T, store Store) []string {
+	entries, err := store.Range(1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return entryStrings(entries)
+}
+
+func TestStore_Range(t *testing.T) {
+	tests := []struct {
+		name  string
+		start int
+		limit int
+		want  []string
+	}{
+		{
+			name:  "正常:スコアの降順、同点は達成日時の昇順、同時刻はユーザIDの昇順",
+			start: 1,
+			limit: 10,
+			want: entryStrings([]*Entry{
+				testEntries[2], testEntries[1], testEntries[3], testEntries[0], testEntries[4],
+			}),
+		},
+		{
+			name:  "正常:指定位置から指定件数",
+			start: 2,
+			limit: 2,
+			want:  entryStrings([]*Entry{testEntries[1], testEntries[3]}),
+		},
+		{
+			name:  "正常:登録数を超える位置",
+			start: 6,
+			limit: 10,
+			want:  []string{},
+		},
+		{
+			name:  "正常:開始位置が0",
+			start: 0,
+			limit: 10,
+			want:  []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testStores(t, func(t *testing.T, store Store) {
+				setEntries(t, store, testEntries)
+				got, err := store.Range(tt.start, tt.limit)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if gotStrings := entryStrings(got); !reflect.DeepEqual(gotStrings, tt.want) {
+					t.Errorf("Range() got = %v, want %v", gotStrings, tt.want)
+				}
+			})
+		})
+	}
+}
+
+func TestStore_SetIfHigher(t *testing.T) {
+	tests := []struct {
+		name             string
+		entry            *Entry
+		want             []string
+		wantDistinctOver int // 0より高いスコアの種類数
+	}{
+		{
+			name:             "正常:登録済みより高いスコアは置き換える",
+			entry:            &Entry{UserID: "UserId1", Score: 300, AchievedAt: baseTime.Add(10 * time.Second)},
+			want:             []string{"UserId1:300:1596283210", "UserId3:200:1596283203"},
+			wantDistinctOver: 2,
+		},
+		{
+			name:             "正常:登録済みと同じスコアは置き換えない",
+			entry:            &Entry{UserID: "UserId1", Score: 100, AchievedAt: baseTime},
+			want:             []string{"UserId3:200:1596283203", "UserId1:100:1596283202"},
+			wantDistinctOver: 2,
+		},
+		{
+			name:             "正常:登録済みより低いスコアは置き換えない",
+			entry:            &Entry{UserID: "UserId1", Score: 50, AchievedAt: baseTime.Add(10 * time.Second)},
+			want:             []string{"UserId3:200:1596283203", "UserId1:100:1596283202"},
+			wantDistinctOver: 2,
+		},
+		{
+			name:             "正常:未登録のユーザは登録する",
+			entry:            &Entry{UserID: "UserId2", Score: 10, AchievedAt: baseTime},
+			want:             []string{"UserId3:200:1596283203", "UserId1:100:1596283202", "UserId2:10:1596283200"},
+			wantDistinctOver: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testStores(t, func(t *testing.T, store Store) {
+				setEntries(t, store, []*Entry{testEntries[0], testEntries[2]})
+				if err := store.SetIfHigher(tt.entry); err != nil {
+					t.Fatal(err)
+				}
+				if got := allEntryStrings(t, store); !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("SetIfHigher() entries = %v, want %v", got, tt.want)
+				}
+				// 置き換えた場合は以前のスコアが種類数に残らない
+				got, err := store.CountDistinctGreater(0)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != tt.wantDistinctOver {
+					t.Errorf("CountDistinctGreater() got = %d, want %d", got, tt.wantDistinctOver)
+				}
+			})
+		})
+	}
+}
+
+func TestStore_Set(t *testing.T) {
+	testStores(t, func(t *testing.T, store Store) {
+		setEntries(t, store, testEntries)
+		// 登録済みの場合は低いスコアでも置き換える
+		if err := store.Set(&Entry{UserID: "UserId3", Score: 10, AchievedAt: baseTime}); err != nil {
+			t.Fatal(err)
+		}
+		want := []string{
+			"UserId2:100:1596283201", "UserId4:100:1596283201", "UserId1:100:1596283202", "UserId5:50:1596283200", "UserId3:10:1596283200",
+		}
+		if got := allEntryStrings(t, store); !reflect.DeepEqual(got, want) {
+			t.Errorf("Set() entries = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestStore_Position(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID string
+		want   int
+	}{
+		{
+			name:   "正常:最上位",
+			userID: "UserId3",
+			want:   1,
+		},
+		{
+			name:   "正常:同点・同時刻はユーザIDの昇順",
+			userID: "UserId4",
+			want:   3,
+		},
+		{
+			name:   "正常:同点は先に達成したユーザが上位",
+			userID: "UserId1",
+			want:   4,
+		},
+		{
+			name:   "正常:未登録のユーザ",
+			userID: "UserId9",
+			want:   0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testStores(t, func(t *testing.T, store Store) {
+				setEntries(t, store, testEntries)
+				got, err := store.Position(tt.userID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != tt.want {
+					t.Errorf("Position() got = %d, want %d", got, tt.want)
+				}
+			})
+		})
+	}
+}
+
+func TestStore_CountUntil(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry *Entry
+		want  int
+	}{
+		{
+			name:  "正常:登録済みのハイスコアは自身を含む",
+			entry: testEntries[3],
+			want:  3,
+		},
+		{
+			name:  "正常:未登録のハイスコアは並び順で前にある数",
+			entry: &Entry{UserID: "UserId9", Score: 100, AchievedAt: baseTime.Add(time.Second)},
+			want:  3,
+		},
+		{
+			name:  "正常:同点で先に達成した未登録のハイスコア",
+			entry: &Entry{UserID: "UserId9", Score: 100, AchievedAt: baseTime},
+			want:  1,
+		},
+		{
+			name:  "正常:最下位より低いハイスコア",
+			entry: &Entry{UserID: "UserId9", Score: 0, AchievedAt: baseTime},
+			want:  5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testStores(t, func(t *testing.T, store Store) {
+				setEntries(t, store, testEntries)
+				got, err := store.CountUntil(tt.entry)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != tt.want {
+					t.Errorf("CountUntil() got = %d, want %d", got, tt.want)
+				}
+				// 未登録のハイスコアを指定しても登録されない
+				if position, err := store.Position("UserId9"); err != nil || position != 0 {
+					t.Errorf("Position() got = %d, %v, want 0", position, err)
+				}
+			})
+		})
+	}
+}
+
+func TestStore_CountGreater(t *testing.T) {
+	tests := []struct {
+		name             string
+		score            int
+		wantCount        int
+		wantDistinctOver int
+	}{
+		{
+			name:             "正常:同点のユーザは全て数え、種類は1つとして数える",
+			score:            50,
+			wantCount:        4,
+			wantDistinctOver: 2,
+		},
+		{
+			name:             "正常:同じスコアは含まない",
+			score:            100,
+			wantCount:        1,
+			wantDistinctOver: 1,
+		},
+		{
+			name:             "正常:最高スコア以上",
+			score:            200,
+			wantCount:        0,
+			wantDistinctOver: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testStores(t, func(t *testing.T, store Store) {
+				setEntries(t, store, testEntries)
+				gotCount, err := store.CountGreater(tt.score)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if gotCount != tt.wantCount {
+					t.Errorf("CountGreater() got = %d, want %d", gotCount, tt.wantCount)
+				}
+				gotDistinct, err := store.CountDistinctGreater(tt.score)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if gotDistinct != tt.wantDistinctOver {
+					t.Errorf("CountDistinctGreater() got = %d, want %d", gotDistinct, tt.wantDistinctOver)
+				}
+			})
+		})
+	}
+}
+
+func TestStore_Rebuild(t *testing.T) {
+	tests := []struct {
+		name             string
+		entries          []*Entry
+		want             []string
+		wantDistinctOver int
+	}{
+		{
+			name:             "正常:登録済みのハイスコアを全て置き換える",
+			entries:          []*Entry{testEntries[4], testEntries[1], testEntries[3]},
+			want:             entryStrings([]*Entry{testEntries[1], testEntries[3], testEntries[4]}),
+			wantDistinctOver: 2,
+		},
+		{
+			name:             "正常:空で置き換える",
+			entries:          nil,
+			want:             []string{},
+			wantDistinctOver: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testStores(t, func(t *testing.T, store Store) {
+				setEntries(t, store, testEntries)
+				if err := store.Rebuild(tt.entries); err != nil {
+					t.Fatal(err)
+				}
+				if got := allEntryStrings(t, store); !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("Rebuild() entries = %v, want %v", got, tt.want)
+				}
+				// 置き換え前のみに登録されていたユーザは残らない
+				if position, err := store.Position("UserId3"); err != nil || position != 0 {
+					t.Errorf("Position() got = %d, %v, want 0", position, err)
+				}
+				got, err := store.CountDistinctGreater(0)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != tt.wantDistinctOver {
+					t.Errorf("CountDistinctGreater() got = %d, want %d", got, tt.wantDistinctOver)
+				}
+				// 置き換え後も登録・更新できる
+				if err = store.SetIfHigher(&Entry{UserID: "UserId3", Score: 300, AchievedAt: baseTime}); err != nil {
+					t.Fatal(err)
+				}
+				if position, err := store.Position("UserId3"); err != nil || position != 1 {
+					t.Errorf("Position() got = %d, %v, want 1", position, err)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package leaderboard
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryStore メモリ上でハイスコアを保持するStore. Redisを使わないテストや開発環境で利用する
+type MemoryStore struct {
+	mu      sync.RWMutex
+	entries []*Entry // 並び順でソート済み
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+// Set ユーザのハイスコアを登録する. 登録済みの場合は置き換える
+func (s *MemoryStore) Set(entry *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set(entry)
+	return nil
+}
+
+// SetIfHigher 登録済みのスコアより高い場合(未登録の場合を含む)のみユーザのスコアを登録する
+func (s *MemoryStore) SetIfHigher(entry *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if index := s.indexOf(entry.UserID); index >= 0 && s.entries[index].Score >= entry.Score {
+		return nil
+	}
+	s.set(entry)
+	return nil
+}
+
+// Range 並び順でstart番目(1始まり)から指定件数を取得する
+func (s *MemoryStore) Range(start int, limit int) ([]*Entry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	from := start - 1
+	if from < 0 || from >= len(s.entries) || limit <= 0 {
+		return nil, nil
+	}
+	to := from + limit
+	if to > len(s.entries) {
+		to = len(s.entries)
+	}
+	entries := make([]*Entry, 0, to-from)
+	for _, entry := range s.entries[from:to] {
+		copied := *entry
+		entries = append(entries, &copied)
+	}
+	return entries, nil
+}
+
+// Position ユーザの並び順での位置(1始まり)を取得する. 未登録の場合は0を返す
+func (s *MemoryStore) Position(userID string) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.indexOf(userID) + 1, nil
+}
+
+// CountUntil 並び順で指定したハイスコア以前(同じものを含む)のユーザ数を取得する
+func (s *MemoryStore) CountUntil(entry *Entry) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return sort.Search(len(s.entries), func(i int) bool { return less(entry, s.entries[i]) }), nil
+}
+
+// CountGreater 指定したスコアより高いスコアのユーザ数を取得する
+func (s *MemoryStore) CountGreater(score int) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return sort.Search(len(s.entries), func(i int) bool { return s.entries[i].Score <= score }), nil
+}
+
+// CountDistinctGreater 指定したスコアより高いスコアの種類数を取得する
+func (s *MemoryStore) CountDistinctGreater(score int) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	count := 0
+	for i, entry := range s.entries {
+		if entry.Score <= score {
+			break
+		}
+		if i == 0 || entry.Score != s.entries[i-1].Score {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Rebuild 登録済みのハイスコアを全て置き換える
+func (s *MemoryStore) Rebuild(entries []*Entry) error {
+	rebuilt := make([]*Entry, 0, len(entries))
+	for _, entry := range entries {
+		copied := *entry
+		rebuilt = append(rebuilt, &copied)
+	}
+	SortEntries(rebuilt)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = rebuilt
+	return nil
+}
+
+// set 登録済みのユーザを削除してから並び順の位置に挿入する
+func (s *MemoryStore) set(entry *Entry) {
+	if index := s.indexOf(entry.UserID); index >= 0 {
+		s.entries = append(s.entries[:index], s.entries[index+1:]...)
+	}
+	copied := *entry
+	index := sort.Search(len(s.entries), func(i int) bool { return less(&copied, s.entries[i]) })
+	s.entries = append(s.entries, nil)
+	copy(s.entries[index+1:], s.entries[index:])
+	s.entries[index] = &copied
+}
+
+// indexOf ユーザの位置(0始まり)を返す. 未登録の場合は-1を返す
+func (s *MemoryStore) indexOf(userID string) int {
+	for i, entry := range s.entries {
+		if entry.UserID == userID {
+			return i
+		}
+	}
+	return -1
+}
+
+// MemoryFactory キーごとのMemoryStoreを保持するFactory. ttlは考慮しない
+type MemoryFactory struct {
+	mu     sync.Mutex
+	stores map[string]*MemoryStore
+}
+
+var _ Factory = (*MemoryFactory)(nil)
+
+func NewMemoryFactory() *MemoryFactory {
+	return &MemoryFactory{
+		stores: make(map[string]*MemoryStore),
+	}
+}
+
+// Store キーに対応するMemoryStoreを取得する. 存在しない場合は作成する
+func (f *MemoryFactory) Store(key string, ttl time.Duration) Store {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	store, ok := f.stores[key]
+	if !ok {
+		store = NewMemoryStore()
+		f.stores[key] = store
+	}
+	return store
+}
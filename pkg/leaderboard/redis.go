@@ -0,0 +1,322 @@
+package leaderboard
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// Redisの接続先が未設定の場合のデフォルト(docker-composeのredis)
+const defaultRedisAddr = "127.0.0.1:6379"
+
+// Rebuild時に1コマンドで登録する件数
+const rebuildBatchSize = 1000
+
+// RedisAddrFromEnv 環境変数REDIS_ADDRからRedisの接続先を取得する
+func RedisAddrFromEnv() string {
+	if addr := os.Getenv("REDIS_ADDR"); addr != "" {
+		return addr
+	}
+	return defaultRedisAddr
+}
+
+// NewRedisPool Redisのコネクションプールを作成する. 接続はコマンド実行時に行う
+func NewRedisPool(addr string) *redis.Pool {
+	return &redis.Pool{
+		MaxIdle:     10,
+		IdleTimeout: 240 * time.Second,
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", addr)
+		},
+	}
+}
+
+// RedisStore Redisのソート済みセットでハイスコアを保持するStore
+// ランキングのキーはスコアを-ハイスコア、メンバーを"達成日時(マイクロ秒, 0埋め):ユーザID"として登録する.
+// 同点はメンバーの辞書順に並ぶため、ハイスコアの降順・達成日時の昇順・ユーザIDの昇順になる.
+// 他に以下のキーを利用する
+// - <key>:member ユーザIDからランキングのメンバーへのハッシュ
+// - <key>:score 登録されているハイスコアの種類. dense順位の計算に利用する
+// - <key>:score_count ハイスコアごとのユーザ数のハッシュ
+type RedisStore struct {
+	Pool *redis.Pool
+	Key  string
+	TTL  time.Duration // 0より大きい場合は更新のたびに全てのキーの有効期限を設定する
+}
+
+var _ Store = (*RedisStore)(nil)
+
+func NewRedisStore(pool *redis.Pool, key string) *RedisStore {
+	return &RedisStore{
+		Pool: pool,
+		Key:  key,
+	}
+}
+
+// setScript 既存のメンバーを削除してからハイスコアを登録する
+// KEYS: ランキング, メンバー, スコアの種類, スコアごとのユーザ数
+// ARGV: ユーザID, ハイスコア, メンバー, 登録済みのスコアより高い場合のみ登録するか(1/0), 有効期限(ミリ秒)
+var setScript = redis.NewScript(4, `
+local old = redis.call('HGET', KEYS[2], ARGV[1])
+if old then
+  local oldScore = redis.call('ZSCORE', KEYS[1], old)
+  if oldScore and ARGV[4] == '1' and 0 - tonumber(oldScore) >= tonumber(ARGV[2]) then
+    return 0
+  end
+  redis.call('ZREM', KEYS[1], old)
+  if oldScore then
+    local s = tostring(0 - tonumber(oldScore))
+    if redis.call('HINCRBY', KEYS[4], s, -1) <= 0 then
+      redis.call('HDEL', KEYS[4], s)
+      redis.call('ZREM', KEYS[3], s)
+    end
+  end
+end
+redis.call('ZADD', KEYS[1], 0 - tonumber(ARGV[2]), ARGV[3])
+redis.call('HSET', KEYS[2], ARGV[1], ARGV[3])
+if redis.call('HINCRBY', KEYS[4], ARGV[2], 1) == 1 then
+  redis.call('ZADD', KEYS[3], 0 - tonumber(ARGV[2]), ARGV[2])
+end
+if tonumber(ARGV[5]) > 0 then
+  for i = 1, 4 do
+    redis.call('PEXPIRE', KEYS[i], ARGV[5])
+  end
+end
+return 1
+`)
+
+// Set ユーザのハイスコアを登録する. 登録済みの場合は置き換える
+func (s *RedisStore) Set(entry *Entry) error {
+	return s.set(entry, false)
+}
+
+// SetIfHigher 登録済みのスコアより高い場合(未登録の場合を含む)のみユーザのスコアを登録する
+func (s *RedisStore) SetIfHigher(entry *Entry) error {
+	return s.set(entry, true)
+}
+
+func (s *RedisStore) set(entry *Entry, onlyIfHigher bool) error {
+	conn := s.Pool.Get()
+	defer conn.Close()
+	onlyIfHigherFlag := 0
+	if onlyIfHigher {
+		onlyIfHigherFlag = 1
+	}
+	_, err := setScript.Do(conn, s.Key, s.memberKey(), s.scoreKey(), s.scoreCountKey(),
+		entry.UserID, entry.Score, encodeMember(entry), onlyIfHigherFlag, int64(s.TTL/time.Millisecond))
+	return err
+}
+
+// Range 並び順でstart番目(1始まり)から指定件数を取得する
+func (s *RedisStore) Range(start int, limit int) ([]*Entry, error) {
+	if start <= 0 || limit <= 0 {
+		return nil, nil
+	}
+	conn := s.Pool.Get()
+	defer conn.Close()
+	values, err := redis.Strings(conn.Do("ZRANGE", s.Key, start-1, start-1+limit-1, "WITHSCORES"))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*Entry, 0, len(values)/2)
+	for i := 0; i+1 < len(values); i += 2 {
+		entry, err := decodeMember(values[i], values[i+1])
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Position ユーザの並び順での位置(1始まり)を取得する. 未登録の場合は0を返す
+func (s *RedisStore) Position(userID string) (int, error) {
+	conn := s.Pool.Get()
+	defer conn.Close()
+	member, err := redis.String(conn.Do("HGET", s.memberKey(), userID))
+	if err == redis.ErrNil {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	rank, err := redis.Int(conn.Do("ZRANK", s.Key, member))
+	if err == redis.ErrNil {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return rank + 1, nil
+}
+
+// countUntilScript 指定したメンバーの並び順での位置を求める. 未登録の場合は一時的に登録して位置を求めてから削除する
+// KEYS: ランキング
+// ARGV: スコア, メンバー
+var countUntilScript = redis.NewScript(1, `
+local added = redis.call('ZADD', KEYS[1], 'NX', ARGV[1], ARGV[2])
+local rank = redis.call('ZRANK', KEYS[1], ARGV[2])
+if added == 1 then
+  redis.call('ZREM', KEYS[1], ARGV[2])
+  return rank
+end
+return rank + 1
+`)
+
+// CountUntil 並び順で指定したハイスコア以前(同じものを含む)のユーザ数を取得する
+func (s *RedisStore) CountUntil(entry *Entry) (int, error) {
+	conn := s.Pool.Get()
+	defer conn.Close()
+	return redis.Int(countUntilScript.Do(conn, s.Key, -entry.Score, encodeMember(entry)))
+}
+
+// CountGreater 指定したスコアより高いスコアのユーザ数を取得する
+func (s *RedisStore) CountGreater(score int) (int, error) {
+	conn := s.Pool.Get()
+	defer conn.Close()
+	return redis.Int(conn.Do("ZCOUNT", s.Key, "-inf", fmt.Sprintf("(%d", -score)))
+}
+
+// CountDistinctGreater 指定したスコアより高いスコアの種類数を取得する
+func (s *RedisStore) CountDistinctGreater(score int) (int, error) {
+	conn := s.Pool.Get()
+	defer conn.Close()
+	return redis.Int(conn.Do("ZCOUNT", s.scoreKey(), "-inf", fmt.Sprintf("(%d", -score)))
+}
+
+// Rebuild 登録済みのハイスコアを全て置き換える
+// 一時キーに登録してからRENAMEするため、再構築中も古いランキングを参照できる
+func (s *RedisStore) Rebuild(entries []*Entry) error {
+	conn := s.Pool.Get()
+	defer conn.Close()
+
+	keys := []string{s.Key, s.memberKey(), s.scoreKey(), s.scoreCountKey()}
+	tmpKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		tmpKeys = append(tmpKeys, key+":rebuild")
+	}
+	if _, err := conn.Do("DEL", redis.Args{}.AddFlat(tmpKeys)...); err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		_, err := conn.Do("DEL", redis.Args{}.AddFlat(keys)...)
+		return err
+	}
+
+	scoreCounts := make(map[int]int)
+	for i := 0; i < len(entries); i += rebuildBatchSize {
+		end := i + rebuildBatchSize
+		if end > len(entries) {
+			end = len(entries)
+		}
+		rankingArgs := redis.Args{}.Add(tmpKeys[0])
+		memberArgs := redis.Args{}.Add(tmpKeys[1])
+		for _, entry := range entries[i:end] {
+			member := encodeMember(entry)
+			rankingArgs = rankingArgs.Add(-entry.Score, member)
+			memberArgs = memberArgs.Add(entry.UserID, member)
+			scoreCounts[entry.Score]++
+		}
+		if _, err := conn.Do("ZADD", rankingArgs...); err != nil {
+			return err
+		}
+		if _, err := conn.Do("HSET", memberArgs...); err != nil {
+			return err
+		}
+	}
+
+	scoreArgs := redis.Args{}.Add(tmpKeys[2])
+	scoreCountArgs := redis.Args{}.Add(tmpKeys[3])
+	for score, count := range scoreCounts {
+		scoreArgs = scoreArgs.Add(-score, score)
+		scoreCountArgs = scoreCountArgs.Add(score, count)
+	}
+	if _, err := conn.Do("ZADD", scoreArgs...); err != nil {
+		return err
+	}
+	if _, err := conn.Do("HSET", scoreCountArgs...); err != nil {
+		return err
+	}
+
+	// 全てのキーをまとめて置き換える
+	if err := conn.Send("MULTI"); err != nil {
+		return err
+	}
+	for i, key := range keys {
+		if err := conn.Send("RENAME", tmpKeys[i], key); err != nil {
+			return err
+		}
+		if s.TTL > 0 {
+			if err := conn.Send("PEXPIRE", key, int64(s.TTL/time.Millisecond)); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := conn.Do("EXEC")
+	return err
+}
+
+// RedisFactory キーごとのRedisStoreを作成するFactory
+type RedisFactory struct {
+	Pool *redis.Pool
+}
+
+var _ Factory = (*RedisFactory)(nil)
+
+func NewRedisFactory(pool *redis.Pool) *RedisFactory {
+	return &RedisFactory{
+		Pool: pool,
+	}
+}
+
+// Store キーに対応するRedisStoreを作成する
+func (f *RedisFactory) Store(key string, ttl time.Duration) Store {
+	return &RedisStore{
+		Pool: f.Pool,
+		Key:  key,
+		TTL:  ttl,
+	}
+}
+
+func (s *RedisStore) memberKey() string {
+	return s.Key + ":member"
+}
+
+func (s *RedisStore) scoreKey() string {
+	return s.Key + ":score"
+}
+
+func (s *RedisStore) scoreCountKey() string {
+	return s.Key + ":score_count"
+}
+
+// encodeMember ランキングのメンバーを作成する. 辞書順が達成日時の昇順・ユーザIDの昇順になるよう達成日時を0埋めする
+func encodeMember(entry *Entry) string {
+	return fmt.Sprintf("%020d:%s", entry.AchievedAt.UnixNano()/int64(time.Microsecond), entry.UserID)
+}
+
+// decodeMember ランキングのメンバーとスコアからEntryを復元する
+func decodeMember(member string, score string) (*Entry, error) {
+	parts := strings.SplitN(member, ":", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid leaderboard member. member=%s", member)
+	}
+	micros, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	negativeScore, err := strconv.Atoi(score)
+	if err != nil {
+		return nil, err
+	}
+	return &Entry{
+		UserID:     parts[1],
+		Score:      -negativeScore,
+		AchievedAt: time.Unix(0, micros*int64(time.Microsecond)),
+	}, nil
+}
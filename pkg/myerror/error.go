@@ -4,6 +4,13 @@ type ApplicationError struct {
 	Message       string
 	OriginalError error
 	Code          int
+	FieldErrors   []FieldError // リクエストのどのフィールドが不正かを示す(バリデーションエラーの場合のみ)
+}
+
+// FieldError リクエストのフィールド単位のエラー
+type FieldError struct {
+	Field   string
+	Message string
 }
 
 func (e ApplicationError) Error() string {
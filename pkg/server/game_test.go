@@ -0,0 +1,135 @@
+package server
+
+import (
+	"20dojo-online/pkg/constant"
+	"20dojo-online/pkg/db"
+	"20dojo-online/pkg/server/handler"
+	"20dojo-online/pkg/server/model"
+	"20dojo-online/pkg/server/service"
+	"20dojo-online/pkg/session"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGameFinishAndGachaDrawConcurrencyIntegration(t *testing.T) {
+	signer := session.NewSigner([]byte("test-secret"))
+	testGameService := service.NewGameService(testUserRepository, model.NewGameSessionRepository(db.Conn), model.NewGamePlayRepository(db.Conn),
+		model.NewRewardEventRepository(db.Conn), testLeaderboards, &service.StandardRewardCalculator{CoinRate: constant.RewardCoinRate}, signer)
+	testGameHandler := handler.NewGameHandler(httpResponse, testGameService)
+
+	// モックサーバー
+	mux := http.NewServeMux()
+	mux.HandleFunc("/test/game/finish", post(testAuthMiddleware.Authenticate(testGameHandler.HandleGameFinish)))
+	mux.HandleFunc("/test/gacha/draw", post(testAuthMiddleware.Authenticate(gachaHandler.HandleGachaDraw)))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	const (
+		initialCoin = 10000
+		finishCount = 20 // ゲーム終了の回数(ボーナスなしで1回あたり100コイン獲得)
+		drawCount   = 10 // ガチャ実行の回数(1回あたり100コイン消費)
+	)
+
+	// シードの作成
+	if _, err := testUserRepository.Conn.Exec(`INSERT INTO user(id, auth_token, name, high_score, coin) VALUES ("id1", "token1", "name1", 0, ?)`, initialCoin); err != nil {
+		t.Fatalf("db.TestConn.Exec failed %s", err)
+	}
+	startedAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+	sessionIDs := make([]string, 0, finishCount)
+	for i := 0; i < finishCount; i++ {
+		id := fmt.Sprintf("session%d", i)
+		if _, err := testUserRepository.Conn.Exec(`INSERT INTO game_session(id, user_id, started_at) VALUES (?, "id1", ?)`, id, startedAt); err != nil {
+			t.Fatalf("db.TestConn.Exec failed %s", err)
+		}
+		sessionIDs = append(sessionIDs, signer.Sign(id, "id1", startedAt))
+	}
+	defer func() {
+		// シードの削除
+		for _, query := range []string{
+			`DELETE FROM game_play WHERE user_id = "id1"`,
+			`DELETE FROM game_session WHERE user_id = "id1"`,
+			`DELETE FROM gacha_draw_history WHERE user_id = "id1"`,
+			`DELETE FROM user_collection_item WHERE user_id = "id1"`,
+			`DELETE FROM user_gacha_pity WHERE user_id = "id1"`,
+			`DELETE FROM user WHERE id = "id1"`,
+		} {
+			if _, err := testUserRepository.Conn.Exec(query); err != nil {
+				t.Errorf("db.TestConn.Exec failed %s", err)
+			}
+		}
+	}()
+
+	var (
+		wg              sync.WaitGroup
+		mu              sync.Mutex
+		finishSucceeded int
+		drawSucceeded   int
+	)
+	send := func(pattern string, body string) int {
+		req, err := http.NewRequest("POST", server.URL+pattern, strings.NewReader(body))
+		if err != nil {
+			t.Errorf("http.NewRequest failed %s", err)
+			return 0
+		}
+		req.Header.Set("x-token", "token1")
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Errorf("http.DefaultClient.Do failed %s", err)
+			return 0
+		}
+		defer res.Body.Close()
+		return res.StatusCode
+	}
+
+	// 同じセッションで2回ずつゲーム終了を送信し、並行してガチャを実行する
+	for _, sessionID := range sessionIDs {
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func(sessionID string) {
+				defer wg.Done()
+				if send("/test/game/finish", fmt.Sprintf(`{"sessionID": "%s", "score": 1000}`, sessionID)) == http.StatusOK {
+					mu.Lock()
+					finishSucceeded++
+					mu.Unlock()
+				}
+			}(sessionID)
+		}
+	}
+	for i := 0; i < drawCount; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if send("/test/gacha/draw", `{"gachaID": "1", "times": 1}`) == http.StatusOK {
+				mu.Lock()
+				drawSucceeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 1つのセッションで報酬を受け取れるのは1回のみ
+	if finishSucceeded != finishCount {
+		t.Errorf("finish succeeded = %d, want %d", finishSucceeded, finishCount)
+	}
+	if drawSucceeded != drawCount {
+		t.Errorf("draw succeeded = %d, want %d", drawSucceeded, drawCount)
+	}
+
+	// 更新が失われていなければ獲得・消費したコインが全て反映される
+	user, err := testUserRepository.SelectUserByPrimaryKey("id1")
+	if err != nil {
+		t.Fatalf("SelectUserByPrimaryKey failed %s", err)
+	}
+	if want := initialCoin + finishCount*100 - drawCount*100; user.Coin != want {
+		t.Errorf("coin = %d, want %d", user.Coin, want)
+	}
+	if user.HighScore != 1000 {
+		t.Errorf("high score = %d, want 1000", user.HighScore)
+	}
+}
@@ -1,23 +1,72 @@
 package handler
 
 import (
+	"20dojo-online/pkg/constant"
 	"20dojo-online/pkg/dcontext"
 	"20dojo-online/pkg/http/response"
 	"20dojo-online/pkg/myerror"
 	"20dojo-online/pkg/server/service"
+	"20dojo-online/pkg/validation"
+	"errors"
 	"log"
 	"net/http"
+	"strconv"
 )
 
 type collectionListResponse struct {
-	Collections []*collection `json:"collections"`
+	Collections []*collection    `json:"collections"`
+	NextCursor  string           `json:"nextCursor,omitempty"`
+	TotalCount  int              `json:"totalCount"`
+	Sets        []*collectionSet `json:"sets"`
 }
 
 type collection struct {
-	CollectionID string `json:"collectionID"`
-	Name         string `json:"name"`
-	Rarity       int    `json:"rarity"`
-	HasItem      bool   `json:"hasItem"`
+	CollectionID    string `json:"collectionID"`
+	Name            string `json:"name"`
+	Rarity          int    `json:"rarity"`
+	HasItem         bool   `json:"hasItem"`
+	Count           int    `json:"count"`
+	Level           int    `json:"level"`
+	FirstAcquiredAt int64  `json:"firstAcquiredAt,omitempty"`
+	LastAcquiredAt  int64  `json:"lastAcquiredAt,omitempty"`
+}
+
+// collectionSet コレクションセットの達成状況
+type collectionSet struct {
+	CollectionSetID string  `json:"collectionSetID"`
+	Name            string  `json:"name"`
+	OwnedCount      int     `json:"ownedCount"`
+	TotalCount      int     `json:"totalCount"`
+	CompletionRate  float64 `json:"completionRate"`
+	Completed       bool    `json:"completed"`
+	Claimed         bool    `json:"claimed"`
+	RewardCoin      int     `json:"rewardCoin"`
+	RewardTicket    int     `json:"rewardTicket"`
+}
+
+type collectionSetClaimRequest struct {
+	CollectionSetID string `json:"collectionSetID"`
+}
+
+// Validate コレクションセットIDは必須
+func (r *collectionSetClaimRequest) Validate(v *validation.Validator) {
+	v.Required("collectionSetID", r.CollectionSetID)
+	v.MaxLength("collectionSetID", r.CollectionSetID, constant.MaxNameLength)
+}
+
+type collectionSetClaimResponse struct {
+	RewardCoin   int `json:"rewardCoin"`
+	RewardTicket int `json:"rewardTicket"`
+	Coin         int `json:"coin"`
+	Ticket       int `json:"ticket"`
+}
+
+// collectionListQuery コレクションアイテム一覧の絞り込み条件と並び順
+type collectionListQuery struct {
+	rarity    int
+	ownership string
+	keyword   string
+	sort      string
 }
 
 type CollectionHandler struct {
@@ -34,6 +83,21 @@ func NewCollectionHandler(httpResponse response.HttpResponseInterface, collectio
 
 // HandleCollectionList ユーザのコレクションアイテム一覧情報取得
 func (h *CollectionHandler) HandleUserCollectionList(writer http.ResponseWriter, request *http.Request) {
+	// クエリストリングから取得件数の受け取り
+	limit, err := pageLimitFromQuery(request, constant.CollectionListLimit, constant.CollectionListMaxLimit)
+	if err != nil {
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	// クエリストリングから絞り込み条件と並び順の受け取り
+	query, err := collectionListQueryFromRequest(request)
+	if err != nil {
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
 
 	// コンテキストからユーザidを取得
 	ctx := request.Context()
@@ -49,12 +113,23 @@ func (h *CollectionHandler) HandleUserCollectionList(writer http.ResponseWriter,
 	}
 
 	// ユーザのコレクションアイテム一覧情報取得のロジック
-	res, err := h.CollectionService.GetUserCollectionList(&service.GetUserCollectionListRequest{UserID: userID})
+	res, err := h.CollectionService.GetUserCollectionList(&service.GetUserCollectionListRequest{
+		UserID:    userID,
+		Limit:     limit,
+		Cursor:    request.URL.Query().Get("cursor"),
+		Rarity:    query.rarity,
+		Ownership: query.ownership,
+		Keyword:   query.keyword,
+		Sort:      query.sort,
+	})
 	if err != nil {
-		err := myerror.ApplicationError{
-			Message:       "failed to get user collection item",
-			OriginalError: err,
-			Code:          http.StatusInternalServerError,
+		var appErr myerror.ApplicationError
+		if !errors.As(err, &appErr) {
+			err = myerror.ApplicationError{
+				Message:       "failed to get user collection item",
+				OriginalError: err,
+				Code:          http.StatusInternalServerError,
+			}
 		}
 		log.Println(err)
 		h.HttpResponse.Failed(writer, err)
@@ -69,9 +144,111 @@ func (h *CollectionHandler) HandleUserCollectionList(writer http.ResponseWriter,
 			Name:         collectionItem.Name,
 			Rarity:       collectionItem.Rarity,
 			HasItem:      collectionItem.HasItem,
+			Count:        collectionItem.Count,
+			Level:        collectionItem.Level,
+		}
+		if collectionItem.HasItem {
+			collection.FirstAcquiredAt = collectionItem.FirstAcquiredAt.Unix()
+			collection.LastAcquiredAt = collectionItem.LastAcquiredAt.Unix()
 		}
 		collections = append(collections, collection)
 	}
 
-	h.HttpResponse.Success(writer, &collectionListResponse{Collections: collections})
+	sets := make([]*collectionSet, 0, len(res.Sets))
+	for _, set := range res.Sets {
+		sets = append(sets, &collectionSet{
+			CollectionSetID: set.CollectionSetID,
+			Name:            set.Name,
+			OwnedCount:      set.OwnedCount,
+			TotalCount:      set.TotalCount,
+			CompletionRate:  set.CompletionRate,
+			Completed:       set.Completed,
+			Claimed:         set.Claimed,
+			RewardCoin:      set.RewardCoin,
+			RewardTicket:    set.RewardTicket,
+		})
+	}
+
+	h.HttpResponse.Success(writer, &collectionListResponse{
+		Collections: collections,
+		NextCursor:  res.NextCursor,
+		TotalCount:  res.TotalCount,
+		Sets:        sets,
+	})
+}
+
+// HandleCollectionSetClaim コンプリートしたコレクションセットの報酬受け取り
+func (h *CollectionHandler) HandleCollectionSetClaim(writer http.ResponseWriter, request *http.Request) {
+	var requestBody collectionSetClaimRequest
+	if err := validation.DecodeJSON(request.Body, &requestBody); err != nil {
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	// コンテキストからユーザidを取得
+	ctx := request.Context()
+	userID := dcontext.GetUserIDFromContext(ctx)
+	if userID == "" {
+		userIDEmptyErr := myerror.ApplicationError{
+			Message: "userID from context is empty",
+			Code:    http.StatusInternalServerError,
+		}
+		log.Println(userIDEmptyErr)
+		h.HttpResponse.Failed(writer, userIDEmptyErr)
+		return
+	}
+
+	// 報酬受け取りのロジック
+	res, err := h.CollectionService.ClaimCollectionSetReward(&service.ClaimCollectionSetRewardRequest{
+		UserID:          userID,
+		CollectionSetID: requestBody.CollectionSetID,
+	})
+	if err != nil {
+		var appErr myerror.ApplicationError
+		if !errors.As(err, &appErr) {
+			err = myerror.ApplicationError{
+				Message:       "failed to claim collection set reward",
+				OriginalError: err,
+				Code:          http.StatusInternalServerError,
+			}
+		}
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	h.HttpResponse.Success(writer, &collectionSetClaimResponse{
+		RewardCoin:   res.RewardCoin,
+		RewardTicket: res.RewardTicket,
+		Coin:         res.Coin,
+		Ticket:       res.Ticket,
+	})
+}
+
+// collectionListQueryFromRequest クエリストリングからコレクションアイテム一覧の絞り込み条件と並び順を取得する
+func collectionListQueryFromRequest(request *http.Request) (*collectionListQuery, error) {
+	values := request.URL.Query()
+	query := &collectionListQuery{
+		ownership: values.Get("ownership"),
+		keyword:   values.Get("q"),
+		sort:      values.Get("sort"),
+	}
+	if query.sort == "" {
+		query.sort = constant.CollectionSortID
+	}
+
+	v := validation.New()
+	if param := values.Get("rarity"); param != "" {
+		rarity, err := strconv.Atoi(param)
+		v.Check(err == nil, "rarity", "must be int")
+		v.Min("rarity", rarity, 1)
+		query.rarity = rarity
+	}
+	if query.ownership != "" {
+		v.OneOf("ownership", query.ownership, constant.CollectionOwnershipOwned, constant.CollectionOwnershipUnowned)
+	}
+	v.MaxLength("q", query.keyword, constant.MaxNameLength)
+	v.OneOf("sort", query.sort, constant.CollectionSortID, constant.CollectionSortRarity, constant.CollectionSortAcquired)
+	return query, v.Err()
 }
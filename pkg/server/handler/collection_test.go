@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"20dojo-online/pkg/constant"
+	"20dojo-online/pkg/dcontext"
+	"20dojo-online/pkg/http/response"
+	"20dojo-online/pkg/server/service"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestCollectionHandler_HandleUserCollectionList(t *testing.T) {
+	acquiredAt := time.Unix(1596250800, 0)
+
+	type args struct {
+		query string
+	}
+	type want struct {
+		statusCode int
+		body       string
+	}
+	tests := []struct {
+		name   string
+		args   args
+		before func(mock *mock, args args)
+		want   want
+	}{
+		{
+			name: "正常:指定がない場合はID順で全件",
+			args: args{
+				query: "",
+			},
+			before: func(mock *mock, args args) {
+				mock.collectionService.EXPECT().GetUserCollectionList(&service.GetUserCollectionListRequest{
+					UserID: "UserId1",
+					Limit:  constant.CollectionListLimit,
+					Sort:   constant.CollectionSortID,
+				}).Return(&service.GetUserCollectionListResponse{
+					CollectionItems: []*service.CollectionItem{
+						{CollectionID: "1001", Name: "ゴリラ01", Rarity: 1, HasItem: true, Count: 2, Level: 2, FirstAcquiredAt: acquiredAt, LastAcquiredAt: acquiredAt},
+						{CollectionID: "1002", Name: "ゴリラ02", Rarity: 1},
+					},
+					TotalCount: 2,
+				}, nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body: `{
+						  "collections": [
+							{"collectionID": "1001", "name": "ゴリラ01", "rarity": 1, "hasItem": true, "count": 2, "level": 2, "firstAcquiredAt": 1596250800, "lastAcquiredAt": 1596250800},
+							{"collectionID": "1002", "name": "ゴリラ02", "rarity": 1, "hasItem": false, "count": 0, "level": 0}
+						  ],
+						  "totalCount": 2,
+						  "sets": []
+						}`,
+			},
+		},
+		{
+			name: "正常:絞り込みと並び順の指定",
+			args: args{
+				query: "?rarity=3&ownership=unowned&q=%E3%82%B4%E3%83%AA%E3%83%A9&sort=rarity&limit=1&cursor=next",
+			},
+			before: func(mock *mock, args args) {
+				mock.collectionService.EXPECT().GetUserCollectionList(&service.GetUserCollectionListRequest{
+					UserID:    "UserId1",
+					Limit:     1,
+					Cursor:    "next",
+					Rarity:    3,
+					Ownership: constant.CollectionOwnershipUnowned,
+					Keyword:   "ゴリラ",
+					Sort:      constant.CollectionSortRarity,
+				}).Return(&service.GetUserCollectionListResponse{
+					CollectionItems: []*service.CollectionItem{
+						{CollectionID: "3001", Name: "超スゴリラ01", Rarity: 3},
+					},
+					NextCursor: "next2",
+					TotalCount: 40,
+				}, nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body: `{
+						  "collections": [
+							{"collectionID": "3001", "name": "超スゴリラ01", "rarity": 3, "hasItem": false, "count": 0, "level": 0}
+						  ],
+						  "nextCursor": "next2",
+						  "totalCount": 40,
+						  "sets": []
+						}`,
+			},
+		},
+		{
+			name: "異常:絞り込み条件エラー",
+			args: args{
+				query: "?rarity=rare&ownership=all&sort=name",
+			},
+			before: func(mock *mock, args args) {},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body: `{
+							"code": 400,
+							"message": "Bad Request",
+							"errors": [
+								{"field": "rarity", "message": "must be int"},
+								{"field": "ownership", "message": "must be one of [owned, unowned]"},
+								{"field": "sort", "message": "must be one of [id, rarity, acquired]"}
+							]
+						}`,
+			},
+		},
+		{
+			name: "異常:取得エラー",
+			args: args{
+				query: "",
+			},
+			before: func(mock *mock, args args) {
+				mock.collectionService.EXPECT().GetUserCollectionList(gomock.Any()).Return(nil, errors.New("GetUserCollectionList"))
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+				body: `{
+							"code": 500,
+							"message": "Internal Server Error"
+						}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMock(ctrl)
+			tt.before(mock, tt.args)
+			writer := httptest.NewRecorder()
+			request := httptest.NewRequest("GET", "http://localhost:8080/collection/list"+tt.args.query, nil)
+			request = request.WithContext(dcontext.SetUserID(request.Context(), "UserId1"))
+
+			h := NewCollectionHandler(response.NewHttpResponse(), mock.collectionService)
+			h.HandleUserCollectionList(writer, request)
+
+			res := writer.Result()
+			body, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				t.Errorf("ioutil.ReadAll failed %s", err)
+			}
+
+			if res.StatusCode != tt.want.statusCode {
+				t.Errorf("status code = %d, want %d", res.StatusCode, tt.want.statusCode)
+			}
+
+			boolean, err := deepEqualString(string(body), tt.want.body)
+			if err != nil {
+				t.Errorf("response.DeepEqualString() failed %s", err)
+			}
+			if !boolean {
+				t.Errorf("response body = \n%s\n, want \n%s\n", string(body), tt.want.body)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"20dojo-online/pkg/constant"
+	"20dojo-online/pkg/dcontext"
+	"20dojo-online/pkg/http/response"
+	"20dojo-online/pkg/myerror"
+	"20dojo-online/pkg/server/service"
+	"20dojo-online/pkg/validation"
+	"errors"
+	"log"
+	"net/http"
+)
+
+type friendUserRequest struct {
+	UserId string `json:"userId"`
+}
+
+// Validate 対象のユーザIDは必須
+func (r *friendUserRequest) Validate(v *validation.Validator) {
+	v.Required("userId", r.UserId)
+	v.MaxLength("userId", r.UserId, constant.MaxNameLength)
+}
+
+type friendRequestResponse struct {
+	Accepted bool `json:"accepted"`
+}
+
+type friendListResponse struct {
+	Friends  []*friend `json:"friends"`
+	Requests []*friend `json:"requests"`
+}
+
+// friend フレンド情報
+type friend struct {
+	UserId    string `json:"userId"`
+	UserName  string `json:"userName"`
+	HighScore int    `json:"highScore"`
+	CreatedAt int64  `json:"createdAt"`
+}
+
+type FriendHandler struct {
+	HttpResponse  response.HttpResponseInterface
+	FriendService service.FriendServiceInterface
+}
+
+func NewFriendHandler(httpResponse response.HttpResponseInterface, friendService service.FriendServiceInterface) *FriendHandler {
+	return &FriendHandler{
+		HttpResponse:  httpResponse,
+		FriendService: friendService,
+	}
+}
+
+// HandleFriendList フレンド一覧と承認待ちの申請の取得
+func (h *FriendHandler) HandleFriendList(writer http.ResponseWriter, request *http.Request) {
+	// コンテキストからユーザidを取得
+	ctx := request.Context()
+	userID := dcontext.GetUserIDFromContext(ctx)
+	if userID == "" {
+		userIDEmptyErr := myerror.ApplicationError{
+			Message: "userID from context is empty",
+			Code:    http.StatusInternalServerError,
+		}
+		log.Println(userIDEmptyErr)
+		h.HttpResponse.Failed(writer, userIDEmptyErr)
+		return
+	}
+
+	// フレンド一覧取得のロジック
+	res, err := h.FriendService.GetFriendList(&service.GetFriendListRequest{UserID: userID})
+	if err != nil {
+		err = myerror.ApplicationError{
+			Message:       "failed to get friend list",
+			OriginalError: err,
+			Code:          http.StatusInternalServerError,
+		}
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	// レスポンスの整形
+	h.HttpResponse.Success(writer, &friendListResponse{
+		Friends:  newFriends(res.Friends),
+		Requests: newFriends(res.Requests),
+	})
+}
+
+// HandleFriendRequest フレンド申請
+func (h *FriendHandler) HandleFriendRequest(writer http.ResponseWriter, request *http.Request) {
+	var requestBody friendUserRequest
+	if err := validation.DecodeJSON(request.Body, &requestBody); err != nil {
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	// コンテキストからユーザidを取得
+	ctx := request.Context()
+	userID := dcontext.GetUserIDFromContext(ctx)
+	if userID == "" {
+		userIDEmptyErr := myerror.ApplicationError{
+			Message: "userID from context is empty",
+			Code:    http.StatusInternalServerError,
+		}
+		log.Println(userIDEmptyErr)
+		h.HttpResponse.Failed(writer, userIDEmptyErr)
+		return
+	}
+
+	// フレンド申請のロジック
+	res, err := h.FriendService.RequestFriend(&service.RequestFriendRequest{
+		UserID:       userID,
+		TargetUserID: requestBody.UserId,
+	})
+	if err != nil {
+		var appErr myerror.ApplicationError
+		if !errors.As(err, &appErr) {
+			err = myerror.ApplicationError{
+				Message:       "failed to request friend",
+				OriginalError: err,
+				Code:          http.StatusInternalServerError,
+			}
+		}
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	h.HttpResponse.Success(writer, &friendRequestResponse{Accepted: res.Accepted})
+}
+
+// HandleFriendAccept フレンド申請の承認
+func (h *FriendHandler) HandleFriendAccept(writer http.ResponseWriter, request *http.Request) {
+	var requestBody friendUserRequest
+	if err := validation.DecodeJSON(request.Body, &requestBody); err != nil {
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	// コンテキストからユーザidを取得
+	ctx := request.Context()
+	userID := dcontext.GetUserIDFromContext(ctx)
+	if userID == "" {
+		userIDEmptyErr := myerror.ApplicationError{
+			Message: "userID from context is empty",
+			Code:    http.StatusInternalServerError,
+		}
+		log.Println(userIDEmptyErr)
+		h.HttpResponse.Failed(writer, userIDEmptyErr)
+		return
+	}
+
+	// フレンド申請承認のロジック
+	if err := h.FriendService.AcceptFriend(&service.AcceptFriendRequest{
+		UserID:          userID,
+		RequesterUserID: requestBody.UserId,
+	}); err != nil {
+		var appErr myerror.ApplicationError
+		if !errors.As(err, &appErr) {
+			err = myerror.ApplicationError{
+				Message:       "failed to accept friend",
+				OriginalError: err,
+				Code:          http.StatusInternalServerError,
+			}
+		}
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	h.HttpResponse.Success(writer, nil)
+}
+
+// HandleFriendRemove フレンド解除. 承認待ちの申請の取り消し・拒否にも利用する
+func (h *FriendHandler) HandleFriendRemove(writer http.ResponseWriter, request *http.Request) {
+	var requestBody friendUserRequest
+	if err := validation.DecodeJSON(request.Body, &requestBody); err != nil {
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	// コンテキストからユーザidを取得
+	ctx := request.Context()
+	userID := dcontext.GetUserIDFromContext(ctx)
+	if userID == "" {
+		userIDEmptyErr := myerror.ApplicationError{
+			Message: "userID from context is empty",
+			Code:    http.StatusInternalServerError,
+		}
+		log.Println(userIDEmptyErr)
+		h.HttpResponse.Failed(writer, userIDEmptyErr)
+		return
+	}
+
+	// フレンド解除のロジック
+	if err := h.FriendService.RemoveFriend(&service.RemoveFriendRequest{
+		UserID:       userID,
+		FriendUserID: requestBody.UserId,
+	}); err != nil {
+		var appErr myerror.ApplicationError
+		if !errors.As(err, &appErr) {
+			err = myerror.ApplicationError{
+				Message:       "failed to remove friend",
+				OriginalError: err,
+				Code:          http.StatusInternalServerError,
+			}
+		}
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	h.HttpResponse.Success(writer, nil)
+}
+
+// newFriends フレンド情報をレスポンスの形式に変換する
+func newFriends(friendInfoList []*service.FriendInfo) []*friend {
+	friends := make([]*friend, 0, len(friendInfoList))
+	for _, friendInfo := range friendInfoList {
+		friends = append(friends, &friend{
+			UserId:    friendInfo.UserID,
+			UserName:  friendInfo.UserName,
+			HighScore: friendInfo.HighScore,
+			CreatedAt: friendInfo.CreatedAt.Unix(),
+		})
+	}
+	return friends
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"20dojo-online/pkg/dcontext"
+	"20dojo-online/pkg/http/response"
+	"20dojo-online/pkg/myerror"
+	"20dojo-online/pkg/server/service"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestFriendHandler_HandleFriendRequest(t *testing.T) {
+	type args struct {
+		body string
+	}
+	type want struct {
+		statusCode int
+		body       string
+	}
+	tests := []struct {
+		name   string
+		args   args
+		before func(mock *mock, args args)
+		want   want
+	}{
+		{
+			name: "正常:申請",
+			args: args{
+				body: `{"userId": "UserId2"}`,
+			},
+			before: func(mock *mock, args args) {
+				mock.friendService.EXPECT().RequestFriend(&service.RequestFriendRequest{
+					UserID:       "UserId1",
+					TargetUserID: "UserId2",
+				}).Return(&service.RequestFriendResponse{}, nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       `{"accepted": false}`,
+			},
+		},
+		{
+			name: "正常:相手の申請を承認",
+			args: args{
+				body: `{"userId": "UserId2"}`,
+			},
+			before: func(mock *mock, args args) {
+				mock.friendService.EXPECT().RequestFriend(&service.RequestFriendRequest{
+					UserID:       "UserId1",
+					TargetUserID: "UserId2",
+				}).Return(&service.RequestFriendResponse{Accepted: true}, nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       `{"accepted": true}`,
+			},
+		},
+		{
+			name: "異常:ユーザID未指定",
+			args: args{
+				body: `{}`,
+			},
+			before: func(mock *mock, args args) {},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body: `{
+							"code": 400,
+							"message": "Bad Request",
+							"errors": [{"field": "userId", "message": "is required"}]
+						}`,
+			},
+		},
+		{
+			name: "異常:既にフレンド",
+			args: args{
+				body: `{"userId": "UserId2"}`,
+			},
+			before: func(mock *mock, args args) {
+				mock.friendService.EXPECT().RequestFriend(gomock.Any()).Return(nil, myerror.ApplicationError{
+					Message: "already friends",
+					Code:    http.StatusBadRequest,
+				})
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body: `{
+							"code": 400,
+							"message": "Bad Request"
+						}`,
+			},
+		},
+		{
+			name: "異常:申請エラー",
+			args: args{
+				body: `{"userId": "UserId2"}`,
+			},
+			before: func(mock *mock, args args) {
+				mock.friendService.EXPECT().RequestFriend(gomock.Any()).Return(nil, errors.New("RequestFriend"))
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+				body: `{
+							"code": 500,
+							"message": "Internal Server Error"
+						}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMock(ctrl)
+			tt.before(mock, tt.args)
+			writer := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "http://localhost:8080/friend/request", strings.NewReader(tt.args.body))
+			request = request.WithContext(dcontext.SetUserID(request.Context(), "UserId1"))
+
+			h := NewFriendHandler(response.NewHttpResponse(), mock.friendService)
+			h.HandleFriendRequest(writer, request)
+
+			res := writer.Result()
+			body, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				t.Errorf("ioutil.ReadAll failed %s", err)
+			}
+
+			if res.StatusCode != tt.want.statusCode {
+				t.Errorf("status code = %d, want %d", res.StatusCode, tt.want.statusCode)
+			}
+
+			boolean, err := deepEqualString(string(body), tt.want.body)
+			if err != nil {
+				t.Errorf("response.DeepEqualString() failed %s", err)
+			}
+			if !boolean {
+				t.Errorf("response body = \n%s\n, want \n%s\n", string(body), tt.want.body)
+			}
+		})
+	}
+}
@@ -1,19 +1,46 @@
 package handler
 
 import (
+	"20dojo-online/pkg/constant"
 	"20dojo-online/pkg/dcontext"
 	"20dojo-online/pkg/http/response"
 	"20dojo-online/pkg/myerror"
 	"20dojo-online/pkg/server/service"
-	"encoding/json"
+	"20dojo-online/pkg/validation"
 	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 )
 
 type gachaDrawRequest struct {
-	Times int `json:"times"`
+	GachaID string `json:"gachaID"`
+	Times   int    `json:"times"`
+	Payment string `json:"payment"`
+}
+
+// Validate 実行回数は許可された回数のみ. ガチャIDと支払い方法は省略可能
+func (r *gachaDrawRequest) Validate(v *validation.Validator) {
+	v.MaxLength("gachaID", r.GachaID, constant.MaxNameLength)
+	v.Min("times", r.Times, 1)
+	v.Max("times", r.Times, constant.GachaMaxDrawTimes)
+	if len(constant.GachaAllowedDrawTimes) >= 1 {
+		v.OneOfInt("times", r.Times, constant.GachaAllowedDrawTimes...)
+	}
+	if r.Payment != "" {
+		v.OneOf("payment", r.Payment, constant.GachaPaymentCoin, constant.GachaPaymentTicket, constant.GachaPaymentFree)
+	}
+}
+
+type gachaBoxResetRequest struct {
+	GachaID string `json:"gachaID"`
+}
+
+// Validate ガチャIDは必須
+func (r *gachaBoxResetRequest) Validate(v *validation.Validator) {
+	v.Required("gachaID", r.GachaID)
+	v.MaxLength("gachaID", r.GachaID, constant.MaxNameLength)
 }
 
 type gachaDrawResponse struct {
@@ -25,6 +52,53 @@ type result struct {
 	Name         string `json:"name"`
 	Rarity       int    `json:"rarity"`
 	IsNew        bool   `json:"isNew"`
+	Shard        int    `json:"shard"`
+	Count        int    `json:"count"`
+	Level        int    `json:"level"`
+}
+
+type gachaListResponse struct {
+	Gachas []*gachaInfo `json:"gachas"`
+}
+
+type gachaInfo struct {
+	GachaID         string `json:"gachaID"`
+	Name            string `json:"name"`
+	CoinConsumption int    `json:"coinConsumption"`
+	Mode            string `json:"mode"`
+	StartAt         int64  `json:"startAt"`
+	EndAt           int64  `json:"endAt"`
+}
+
+type gachaHistoryResponse struct {
+	Histories []*gachaHistory `json:"histories"`
+}
+
+type gachaHistory struct {
+	GachaID         string `json:"gachaID"`
+	CollectionID    string `json:"collectionID"`
+	Name            string `json:"name"`
+	Rarity          int    `json:"rarity"`
+	CoinConsumption int    `json:"coinConsumption"`
+	Payment         string `json:"payment"`
+	DrawnAt         int64  `json:"drawnAt"`
+}
+
+type gachaRatesResponse struct {
+	Items    []*itemRate   `json:"items"`
+	Rarities []*rarityRate `json:"rarities"`
+}
+
+type itemRate struct {
+	CollectionID string  `json:"collectionID"`
+	Name         string  `json:"name"`
+	Rarity       int     `json:"rarity"`
+	Rate         float64 `json:"rate"`
+}
+
+type rarityRate struct {
+	Rarity int     `json:"rarity"`
+	Rate   float64 `json:"rate"`
 }
 
 type GachaHandler struct {
@@ -43,26 +117,19 @@ func (h *GachaHandler) HandleGachaDraw(writer http.ResponseWriter, request *http
 
 	// リクエストbodyからガチャ実行回数を取得
 	var requestBody gachaDrawRequest
-	if err := json.NewDecoder(request.Body).Decode(&requestBody); err != nil {
-		err = myerror.ApplicationError{
-			Message:       "failed to decode request body",
-			OriginalError: err,
-			Code:          http.StatusBadRequest,
-		}
+	if err := validation.DecodeJSON(request.Body, &requestBody); err != nil {
 		log.Println(err)
 		h.HttpResponse.Failed(writer, err)
 		return
 	}
 
-	// timesが0以下のときエラーを返す
-	if requestBody.Times <= 0 {
-		timesLessErr := myerror.ApplicationError{
-			Message: fmt.Sprintf("gacha draw times is 0 or less. times=%d", requestBody.Times),
-			Code:    http.StatusBadRequest,
-		}
-		log.Println(timesLessErr)
-		h.HttpResponse.Failed(writer, timesLessErr)
-		return
+	// ガチャIDの指定がない場合は常設ガチャを実行する
+	if requestBody.GachaID == "" {
+		requestBody.GachaID = constant.DefaultGachaID
+	}
+	// 支払い方法の指定がない場合はコインで支払う
+	if requestBody.Payment == "" {
+		requestBody.Payment = constant.GachaPaymentCoin
 	}
 
 	// Contextから認証済みのユーザIDを取得
@@ -80,8 +147,10 @@ func (h *GachaHandler) HandleGachaDraw(writer http.ResponseWriter, request *http
 
 	// ガチャ実行ロジック
 	res, err := h.GachaService.DrawGacha(&service.DrawGachaRequest{
-		Times:  requestBody.Times,
-		UserID: userID,
+		GachaID: requestBody.GachaID,
+		Times:   requestBody.Times,
+		Payment: requestBody.Payment,
+		UserID:  userID,
 	})
 	if err != nil {
 		var appErr myerror.ApplicationError
@@ -109,6 +178,9 @@ func (h *GachaHandler) HandleGachaDraw(writer http.ResponseWriter, request *http
 			Name:         gachaResult.Name,
 			Rarity:       gachaResult.Rarity,
 			IsNew:        gachaResult.IsNew,
+			Shard:        gachaResult.Shard,
+			Count:        gachaResult.Count,
+			Level:        gachaResult.Level,
 		}
 		results = append(results, result)
 	}
@@ -116,3 +188,197 @@ func (h *GachaHandler) HandleGachaDraw(writer http.ResponseWriter, request *http
 	h.HttpResponse.Success(writer, gachaDrawResponse{Results: results})
 
 }
+
+// HandleGachaList 開催中のガチャ一覧取得
+func (h *GachaHandler) HandleGachaList(writer http.ResponseWriter, request *http.Request) {
+	// 開催中のガチャ一覧取得のロジック
+	res, err := h.GachaService.GetGachaList()
+	if err != nil {
+		err = myerror.ApplicationError{
+			Message:       "failed to get gacha list",
+			OriginalError: err,
+			Code:          http.StatusInternalServerError,
+		}
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	// レスポンスの整形
+	gachas := make([]*gachaInfo, 0, len(res.Gachas))
+	for _, gacha := range res.Gachas {
+		gachas = append(gachas, &gachaInfo{
+			GachaID:         gacha.GachaID,
+			Name:            gacha.Name,
+			CoinConsumption: gacha.CoinConsumption,
+			Mode:            gacha.Mode,
+			StartAt:         gacha.StartAt.Unix(),
+			EndAt:           gacha.EndAt.Unix(),
+		})
+	}
+
+	h.HttpResponse.Success(writer, &gachaListResponse{Gachas: gachas})
+}
+
+// HandleGachaHistory ガチャ実行履歴取得
+func (h *GachaHandler) HandleGachaHistory(writer http.ResponseWriter, request *http.Request) {
+	// クエリストリングから開始位置の受け取り
+	param := request.URL.Query().Get("start")
+	start, err := strconv.Atoi(param)
+	if err != nil {
+		err = myerror.ApplicationError{
+			Message:       "failed to get query parameter",
+			OriginalError: err,
+			Code:          http.StatusBadRequest,
+		}
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	// startが0以下のときエラーを返す
+	if start <= 0 {
+		err := myerror.ApplicationError{
+			Message: fmt.Sprintf("start is 0 or less. start=%d", start),
+			Code:    http.StatusBadRequest,
+		}
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	// Contextから認証済みのユーザIDを取得
+	ctx := request.Context()
+	userID := dcontext.GetUserIDFromContext(ctx)
+	if userID == "" {
+		userIDEmptyErr := myerror.ApplicationError{
+			Message: "userID from context is empty",
+			Code:    http.StatusInternalServerError,
+		}
+		log.Println(userIDEmptyErr)
+		h.HttpResponse.Failed(writer, userIDEmptyErr)
+		return
+	}
+
+	// ガチャ実行履歴取得のロジック
+	res, err := h.GachaService.GetGachaHistory(&service.GetGachaHistoryRequest{
+		UserID: userID,
+		Limit:  constant.GachaHistoryListLimit,
+		Offset: start,
+	})
+	if err != nil {
+		err = myerror.ApplicationError{
+			Message:       "failed to get gacha history",
+			OriginalError: err,
+			Code:          http.StatusInternalServerError,
+		}
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	// レスポンスの整形
+	histories := make([]*gachaHistory, 0, len(res.GachaHistories))
+	for _, history := range res.GachaHistories {
+		histories = append(histories, &gachaHistory{
+			GachaID:         history.GachaID,
+			CollectionID:    history.CollectionID,
+			Name:            history.Name,
+			Rarity:          history.Rarity,
+			CoinConsumption: history.CoinConsumption,
+			Payment:         history.Payment,
+			DrawnAt:         history.DrawnAt.Unix(),
+		})
+	}
+
+	h.HttpResponse.Success(writer, &gachaHistoryResponse{Histories: histories})
+}
+
+// HandleGachaRates ガチャ排出確率取得
+func (h *GachaHandler) HandleGachaRates(writer http.ResponseWriter, request *http.Request) {
+	// クエリストリングからガチャIDを取得. 指定がない場合は常設ガチャ
+	gachaID := request.URL.Query().Get("gachaID")
+	if gachaID == "" {
+		gachaID = constant.DefaultGachaID
+	}
+
+	// ガチャ排出確率取得のロジック
+	res, err := h.GachaService.GetGachaRates(&service.GetGachaRatesRequest{GachaID: gachaID})
+	if err != nil {
+		var appErr myerror.ApplicationError
+		if !errors.As(err, &appErr) {
+			err = myerror.ApplicationError{
+				Message:       "failed to get gacha rates",
+				OriginalError: err,
+				Code:          http.StatusInternalServerError,
+			}
+		}
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	// レスポンスの整形
+	items := make([]*itemRate, 0, len(res.ItemRates))
+	for _, rate := range res.ItemRates {
+		items = append(items, &itemRate{
+			CollectionID: rate.CollectionID,
+			Name:         rate.Name,
+			Rarity:       rate.Rarity,
+			Rate:         rate.Rate,
+		})
+	}
+	rarities := make([]*rarityRate, 0, len(res.RarityRates))
+	for _, rate := range res.RarityRates {
+		rarities = append(rarities, &rarityRate{
+			Rarity: rate.Rarity,
+			Rate:   rate.Rate,
+		})
+	}
+
+	h.HttpResponse.Success(writer, &gachaRatesResponse{Items: items, Rarities: rarities})
+}
+
+// HandleGachaBoxReset ボックスガチャのリセット
+func (h *GachaHandler) HandleGachaBoxReset(writer http.ResponseWriter, request *http.Request) {
+	// リクエストbodyからガチャIDを取得
+	var requestBody gachaBoxResetRequest
+	if err := validation.DecodeJSON(request.Body, &requestBody); err != nil {
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	// Contextから認証済みのユーザIDを取得
+	ctx := request.Context()
+	userID := dcontext.GetUserIDFromContext(ctx)
+	if userID == "" {
+		userIDEmptyErr := myerror.ApplicationError{
+			Message: "userID from context is empty",
+			Code:    http.StatusInternalServerError,
+		}
+		log.Println(userIDEmptyErr)
+		h.HttpResponse.Failed(writer, userIDEmptyErr)
+		return
+	}
+
+	// ボックスガチャのリセットのロジック
+	if err := h.GachaService.ResetGachaBox(&service.ResetGachaBoxRequest{
+		GachaID: requestBody.GachaID,
+		UserID:  userID,
+	}); err != nil {
+		var appErr myerror.ApplicationError
+		if !errors.As(err, &appErr) {
+			err = myerror.ApplicationError{
+				Message:       "failed to reset gacha box",
+				OriginalError: err,
+				Code:          http.StatusInternalServerError,
+			}
+		}
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	h.HttpResponse.Success(writer, nil)
+}
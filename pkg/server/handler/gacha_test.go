@@ -0,0 +1,385 @@
+package handler
+
+import (
+	"20dojo-online/pkg/constant"
+	"20dojo-online/pkg/dcontext"
+	"20dojo-online/pkg/http/response"
+	"20dojo-online/pkg/myerror"
+	"20dojo-online/pkg/server/service"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestGachaHandler_HandleGachaDraw(t *testing.T) {
+	type args struct {
+		body string
+	}
+	type want struct {
+		statusCode int
+		body       string
+	}
+	tests := []struct {
+		name   string
+		args   args
+		before func(mock *mock, args args)
+		want   want
+	}{
+		{
+			name: "正常:ガチャID指定",
+			args: args{
+				body: `{"gachaID": "2", "times": 1}`,
+			},
+			before: func(mock *mock, args args) {
+				mock.gachaService.EXPECT().DrawGacha(&service.DrawGachaRequest{
+					GachaID: "2",
+					Times:   1,
+					Payment: constant.GachaPaymentCoin,
+					UserID:  "UserId1",
+				}).Return(&service.DrawGachaResponse{
+					GachaResults: []*service.GachaResult{
+						{
+							CollectionID: "3001",
+							Name:         "超スゴリラ01",
+							Rarity:       3,
+							IsNew:        true,
+							Count:        1,
+							Level:        1,
+						},
+					},
+				}, nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body: `{
+						  "results": [
+							{
+							  "collectionID": "3001",
+							  "name": "超スゴリラ01",
+							  "rarity": 3,
+							  "isNew": true,
+							  "shard": 0,
+							  "count": 1,
+							  "level": 1
+							}
+						  ]
+						}`,
+			},
+		},
+		{
+			name: "正常:チケットで支払い",
+			args: args{
+				body: `{"gachaID": "2", "times": 1, "payment": "ticket"}`,
+			},
+			before: func(mock *mock, args args) {
+				mock.gachaService.EXPECT().DrawGacha(&service.DrawGachaRequest{
+					GachaID: "2",
+					Times:   1,
+					Payment: constant.GachaPaymentTicket,
+					UserID:  "UserId1",
+				}).Return(&service.DrawGachaResponse{
+					GachaResults: []*service.GachaResult{
+						{
+							CollectionID: "1001",
+							Name:         "スゴリラ01",
+							Rarity:       1,
+							IsNew:        false,
+							Shard:        1,
+							Count:        2,
+							Level:        2,
+						},
+					},
+				}, nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body: `{
+						  "results": [
+							{
+							  "collectionID": "1001",
+							  "name": "スゴリラ01",
+							  "rarity": 1,
+							  "isNew": false,
+							  "shard": 1,
+							  "count": 2,
+							  "level": 2
+							}
+						  ]
+						}`,
+			},
+		},
+		{
+			name: "正常:ガチャID未指定なら常設ガチャ",
+			args: args{
+				body: `{"times": 1}`,
+			},
+			before: func(mock *mock, args args) {
+				mock.gachaService.EXPECT().DrawGacha(&service.DrawGachaRequest{
+					GachaID: constant.DefaultGachaID,
+					Times:   1,
+					Payment: constant.GachaPaymentCoin,
+					UserID:  "UserId1",
+				}).Return(&service.DrawGachaResponse{
+					GachaResults: []*service.GachaResult{
+						{
+							CollectionID: "1001",
+							Name:         "スゴリラ01",
+							Rarity:       1,
+							IsNew:        false,
+							Shard:        1,
+							Count:        2,
+							Level:        2,
+						},
+					},
+				}, nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body: `{
+						  "results": [
+							{
+							  "collectionID": "1001",
+							  "name": "スゴリラ01",
+							  "rarity": 1,
+							  "isNew": false,
+							  "shard": 1,
+							  "count": 2,
+							  "level": 2
+							}
+						  ]
+						}`,
+			},
+		},
+		{
+			name: "異常:実行回数エラー",
+			args: args{
+				body: `{"gachaID": "1", "times": 0}`,
+			},
+			before: func(mock *mock, args args) {},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body: `{
+							"code": 400,
+							"message": "Bad Request",
+							"errors": [
+								{"field": "times", "message": "must be 1 or more"}
+							]
+						}`,
+			},
+		},
+		{
+			name: "異常:実行回数が上限を超える",
+			args: args{
+				body: `{"gachaID": "1", "times": 10000000}`,
+			},
+			before: func(mock *mock, args args) {},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body: `{
+							"code": 400,
+							"message": "Bad Request",
+							"errors": [
+								{"field": "times", "message": "must be 100 or less"}
+							]
+						}`,
+			},
+		},
+		{
+			name: "異常:許可されていない実行回数と支払い方法",
+			args: args{
+				body: `{"gachaID": "1", "times": 5, "payment": "gem"}`,
+			},
+			before: func(mock *mock, args args) {},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body: `{
+							"code": 400,
+							"message": "Bad Request",
+							"errors": [
+								{"field": "times", "message": "must be one of [1, 10]"},
+								{"field": "payment", "message": "must be one of [coin, ticket, free]"}
+							]
+						}`,
+			},
+		},
+		{
+			name: "異常:実行回数の型エラー",
+			args: args{
+				body: `{"gachaID": "1", "times": "ten"}`,
+			},
+			before: func(mock *mock, args args) {},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body: `{
+							"code": 400,
+							"message": "Bad Request",
+							"errors": [
+								{"field": "times", "message": "must be int"}
+							]
+						}`,
+			},
+		},
+		{
+			name: "異常:開催期間外のガチャ",
+			args: args{
+				body: `{"gachaID": "2", "times": 1}`,
+			},
+			before: func(mock *mock, args args) {
+				mock.gachaService.EXPECT().DrawGacha(&service.DrawGachaRequest{
+					GachaID: "2",
+					Times:   1,
+					Payment: constant.GachaPaymentCoin,
+					UserID:  "UserId1",
+				}).Return(nil, myerror.ApplicationError{
+					Message: "gacha is not in session. gachaID=2",
+					Code:    http.StatusBadRequest,
+				})
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body: `{
+							"code": 400,
+							"message": "Bad Request"
+						}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMock(ctrl)
+			tt.before(mock, tt.args)
+			writer := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "http://localhost:8080/gacha/draw", strings.NewReader(tt.args.body))
+			request = request.WithContext(dcontext.SetUserID(request.Context(), "UserId1"))
+
+			h := NewGachaHandler(response.NewHttpResponse(), mock.gachaService)
+			h.HandleGachaDraw(writer, request)
+
+			res := writer.Result()
+			body, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				t.Errorf("ioutil.ReadAll failed %s", err)
+			}
+
+			if res.StatusCode != tt.want.statusCode {
+				t.Errorf("status code = %d, want %d", res.StatusCode, tt.want.statusCode)
+			}
+
+			boolean, err := deepEqualString(string(body), tt.want.body)
+			if err != nil {
+				t.Errorf("response.DeepEqualString() failed %s", err)
+			}
+			if !boolean {
+				t.Errorf("response body = \n%s\n, want \n%s\n", string(body), tt.want.body)
+			}
+		})
+	}
+}
+
+func TestGachaHandler_HandleGachaHistory(t *testing.T) {
+	type args struct {
+		request *http.Request
+	}
+	type want struct {
+		statusCode int
+		body       string
+	}
+	tests := []struct {
+		name   string
+		args   args
+		before func(mock *mock, args args)
+		want   want
+	}{
+		{
+			name: "正常:ガチャ実行履歴取得",
+			args: args{
+				request: httptest.NewRequest("GET", "http://localhost:8080/gacha/history?start=1", nil),
+			},
+			before: func(mock *mock, args args) {
+				mock.gachaService.EXPECT().GetGachaHistory(&service.GetGachaHistoryRequest{
+					UserID: "UserId1",
+					Limit:  constant.GachaHistoryListLimit,
+					Offset: 1,
+				}).Return(&service.GetGachaHistoryResponse{
+					GachaHistories: []*service.GachaHistory{
+						{
+							GachaID:         "1",
+							CollectionID:    "1001",
+							Name:            "スゴリラ01",
+							Rarity:          1,
+							CoinConsumption: 100,
+							Payment:         constant.GachaPaymentCoin,
+							DrawnAt:         time.Unix(1598227200, 0),
+						},
+					},
+				}, nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body: `{
+						  "histories": [
+							{
+							  "gachaID": "1",
+							  "collectionID": "1001",
+							  "name": "スゴリラ01",
+							  "rarity": 1,
+							  "coinConsumption": 100,
+							  "payment": "coin",
+							  "drawnAt": 1598227200
+							}
+						  ]
+						}`,
+			},
+		},
+		{
+			name: "異常:開始位置エラー",
+			args: args{
+				request: httptest.NewRequest("GET", "http://localhost:8080/gacha/history?start=0", nil),
+			},
+			before: func(mock *mock, args args) {},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body: `{
+							"code": 400,
+							"message": "Bad Request"
+						}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMock(ctrl)
+			tt.before(mock, tt.args)
+			writer := httptest.NewRecorder()
+			request := tt.args.request.WithContext(dcontext.SetUserID(tt.args.request.Context(), "UserId1"))
+
+			h := NewGachaHandler(response.NewHttpResponse(), mock.gachaService)
+			h.HandleGachaHistory(writer, request)
+
+			res := writer.Result()
+			body, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				t.Errorf("ioutil.ReadAll failed %s", err)
+			}
+
+			if res.StatusCode != tt.want.statusCode {
+				t.Errorf("status code = %d, want %d", res.StatusCode, tt.want.statusCode)
+			}
+
+			boolean, err := deepEqualString(string(body), tt.want.body)
+			if err != nil {
+				t.Errorf("response.DeepEqualString() failed %s", err)
+			}
+			if !boolean {
+				t.Errorf("response body = \n%s\n, want \n%s\n", string(body), tt.want.body)
+			}
+		})
+	}
+}
@@ -1,22 +1,68 @@
 package handler
 
 import (
+	"20dojo-online/pkg/constant"
 	"20dojo-online/pkg/dcontext"
 	"20dojo-online/pkg/http/response"
 	"20dojo-online/pkg/myerror"
 	"20dojo-online/pkg/server/service"
-	"encoding/json"
+	"20dojo-online/pkg/validation"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 )
 
+type gameStartResponse struct {
+	SessionID string `json:"sessionID"`
+	StartedAt int64  `json:"startedAt"`
+}
+
 type gameFinishRequest struct {
-	Score int `json:"score"`
+	SessionID string `json:"sessionID"`
+	Score     int    `json:"score"`
+}
+
+// Validate セッションIDは必須. スコアは0以上
+func (r *gameFinishRequest) Validate(v *validation.Validator) {
+	v.Required("sessionID", r.SessionID)
+	v.Min("score", r.Score, 0)
 }
 
 type gameFinishResponse struct {
-	Coin int `json:"coin"`
+	Coin      int              `json:"coin"`
+	Breakdown *rewardBreakdown `json:"breakdown"`
+}
+
+type rewardBreakdown struct {
+	BaseCoin   int            `json:"baseCoin"`
+	Bonuses    []*rewardBonus `json:"bonuses"`
+	Multiplier float64        `json:"multiplier"`
+	CappedCoin int            `json:"cappedCoin"`
+}
+
+type rewardBonus struct {
+	Name string `json:"name"`
+	Coin int    `json:"coin"`
+}
+
+type gameHistoryResponse struct {
+	Plays []*gamePlay    `json:"plays"`
+	Stats *gamePlayStats `json:"stats"`
+}
+
+type gamePlay struct {
+	Score      int   `json:"score"`
+	RewardCoin int   `json:"rewardCoin"`
+	Duration   int   `json:"duration"`
+	FinishedAt int64 `json:"finishedAt"`
+}
+
+type gamePlayStats struct {
+	PlayCount    int     `json:"playCount"`
+	AverageScore float64 `json:"averageScore"`
+	BestScore    int     `json:"bestScore"`
 }
 
 type GameHandler struct {
@@ -31,14 +77,113 @@ func NewGameHandler(httpResponse response.HttpResponseInterface, gameService ser
 	}
 }
 
+// HandleGameStart インゲーム開始
+func (h *GameHandler) HandleGameStart(writer http.ResponseWriter, request *http.Request) {
+
+	// ミドルウェアでコンテキストに格納したユーザidの取得
+	ctx := request.Context()
+	userID := dcontext.GetUserIDFromContext(ctx)
+	if userID == "" {
+		userIDEmptyErr := myerror.ApplicationError{
+			Message: "userID from context is empty",
+			Code:    http.StatusInternalServerError,
+		}
+		log.Println(userIDEmptyErr)
+		h.HttpResponse.Failed(writer, userIDEmptyErr)
+		return
+	}
+
+	// ゲーム開始時のロジック
+	res, err := h.GameService.StartGame(&service.StartGameRequest{UserID: userID})
+	if err != nil {
+		err = myerror.ApplicationError{
+			Message:       "failed to start game correctly",
+			OriginalError: err,
+			Code:          http.StatusInternalServerError,
+		}
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	// 署名済みのセッションIDをレスポンスとして返す
+	h.HttpResponse.Success(writer, &gameStartResponse{
+		SessionID: res.SessionID,
+		StartedAt: res.StartedAt.Unix(),
+	})
+}
+
 // HandleGameFinish インゲーム終了
 func (h *GameHandler) HandleGameFinish(writer http.ResponseWriter, request *http.Request) {
 
 	// リクエストbodyからスコアを取得
 	var requestBody gameFinishRequest
-	if err := json.NewDecoder(request.Body).Decode(&requestBody); err != nil {
+	if err := validation.DecodeJSON(request.Body, &requestBody); err != nil {
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	// ミドルウェアでコンテキストに格納したユーザidの取得
+	ctx := request.Context()
+	userID := dcontext.GetUserIDFromContext(ctx)
+	if userID == "" {
+		userIDEmptyErr := myerror.ApplicationError{
+			Message: "userID from context is empty",
+			Code:    http.StatusInternalServerError,
+		}
+		log.Println(userIDEmptyErr)
+		h.HttpResponse.Failed(writer, userIDEmptyErr)
+		return
+	}
+
+	// ゲーム終了時のロジック
+	res, err := h.GameService.FinishGame(&service.FinishGameRequest{
+		UserId:    userID,
+		SessionID: requestBody.SessionID,
+		Score:     requestBody.Score,
+	})
+	if err != nil {
+		var appErr myerror.ApplicationError
+		if !errors.As(err, &appErr) {
+			err = myerror.ApplicationError{
+				Message:       "failed to finish game correctly",
+				OriginalError: err,
+				Code:          http.StatusInternalServerError,
+			}
+		}
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	// 獲得コインと演出用の内訳をレスポンスとして返す
+	bonuses := make([]*rewardBonus, 0, len(res.Breakdown.Bonuses))
+	for _, bonus := range res.Breakdown.Bonuses {
+		bonuses = append(bonuses, &rewardBonus{
+			Name: bonus.Name,
+			Coin: bonus.Coin,
+		})
+	}
+	h.HttpResponse.Success(writer, &gameFinishResponse{
+		Coin: res.Coin,
+		Breakdown: &rewardBreakdown{
+			BaseCoin:   res.Breakdown.BaseCoin,
+			Bonuses:    bonuses,
+			Multiplier: res.Breakdown.Multiplier,
+			CappedCoin: res.Breakdown.CappedCoin,
+		},
+	})
+}
+
+// HandleGameHistory ゲームプレイ履歴取得
+func (h *GameHandler) HandleGameHistory(writer http.ResponseWriter, request *http.Request) {
+	// クエリストリングから開始位置の受け取り
+	param := request.URL.Query().Get("start")
+	start, err := strconv.Atoi(param)
+	if err != nil {
 		err = myerror.ApplicationError{
-			Message:       "failed to decode request body",
+			Message:       "failed to get query parameter",
 			OriginalError: err,
 			Code:          http.StatusBadRequest,
 		}
@@ -46,10 +191,11 @@ func (h *GameHandler) HandleGameFinish(writer http.ResponseWriter, request *http
 		h.HttpResponse.Failed(writer, err)
 		return
 	}
-	// scoreが負の数のときエラーを返す
-	if requestBody.Score < 0 {
+
+	// startが0以下のときエラーを返す
+	if start <= 0 {
 		err := myerror.ApplicationError{
-			Message: fmt.Sprintf("score is minus. score=%d", requestBody.Score),
+			Message: fmt.Sprintf("start is 0 or less. start=%d", start),
 			Code:    http.StatusBadRequest,
 		}
 		log.Println(err)
@@ -70,23 +216,40 @@ func (h *GameHandler) HandleGameFinish(writer http.ResponseWriter, request *http
 		return
 	}
 
-	// ゲーム終了時のロジック
-	res, err := h.GameService.FinishGame(&service.FinishGameRequest{
-		UserId: userID,
-		Score:  requestBody.Score,
+	// ゲームプレイ履歴取得のロジック
+	res, err := h.GameService.GetGameHistory(&service.GetGameHistoryRequest{
+		UserID: userID,
+		Limit:  constant.GameHistoryListLimit,
+		Offset: start,
 	})
 	if err != nil {
 		err = myerror.ApplicationError{
-			Message: "failed to finish game correctly",
-			Code:    http.StatusInternalServerError,
+			Message:       "failed to get game history",
+			OriginalError: err,
+			Code:          http.StatusInternalServerError,
 		}
 		log.Println(err)
 		h.HttpResponse.Failed(writer, err)
 		return
 	}
 
-	// 獲得コインをレスポンスとして返す
-	h.HttpResponse.Success(writer, &gameFinishResponse{
-		Coin: res.Coin,
+	// レスポンスの整形
+	plays := make([]*gamePlay, 0, len(res.GamePlays))
+	for _, play := range res.GamePlays {
+		plays = append(plays, &gamePlay{
+			Score:      play.Score,
+			RewardCoin: play.RewardCoin,
+			Duration:   play.Duration,
+			FinishedAt: play.FinishedAt.Unix(),
+		})
+	}
+
+	h.HttpResponse.Success(writer, &gameHistoryResponse{
+		Plays: plays,
+		Stats: &gamePlayStats{
+			PlayCount:    res.Stats.PlayCount,
+			AverageScore: res.Stats.AverageScore,
+			BestScore:    res.Stats.BestScore,
+		},
 	})
 }
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"20dojo-online/pkg/dcontext"
+	"20dojo-online/pkg/http/response"
+	"20dojo-online/pkg/myerror"
+	"20dojo-online/pkg/server/service"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestGameHandler_HandleGameFinish(t *testing.T) {
+	type args struct {
+		body string
+	}
+	type want struct {
+		statusCode int
+		body       string
+	}
+	tests := []struct {
+		name   string
+		args   args
+		before func(mock *mock, args args)
+		want   want
+	}{
+		{
+			name: "正常:獲得コインと内訳",
+			args: args{
+				body: `{"sessionID": "session1", "score": 1000}`,
+			},
+			before: func(mock *mock, args args) {
+				mock.gameService.EXPECT().FinishGame(&service.FinishGameRequest{
+					UserId:    "UserId1",
+					SessionID: "session1",
+					Score:     1000,
+				}).Return(&service.FinishGameResponse{
+					Coin: 300,
+					Breakdown: &service.RewardBreakdown{
+						BaseCoin: 100,
+						Bonuses: []*service.RewardBonus{
+							{Name: "score_1000", Coin: 50},
+						},
+						Multiplier: 2,
+						TotalCoin:  300,
+					},
+				}, nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body: `{
+						  "coin": 300,
+						  "breakdown": {
+							"baseCoin": 100,
+							"bonuses": [
+							  {"name": "score_1000", "coin": 50}
+							],
+							"multiplier": 2,
+							"cappedCoin": 0
+						  }
+						}`,
+			},
+		},
+		{
+			name: "異常:セッションID未指定",
+			args: args{
+				body: `{"score": 1000}`,
+			},
+			before: func(mock *mock, args args) {},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body: `{
+							"code": 400,
+							"message": "Bad Request",
+							"errors": [
+								{"field": "sessionID", "message": "is required"}
+							]
+						}`,
+			},
+		},
+		{
+			name: "異常:プレイ回数の上限",
+			args: args{
+				body: `{"sessionID": "session1", "score": 1000}`,
+			},
+			before: func(mock *mock, args args) {
+				mock.gameService.EXPECT().FinishGame(&service.FinishGameRequest{
+					UserId:    "UserId1",
+					SessionID: "session1",
+					Score:     1000,
+				}).Return(nil, myerror.ApplicationError{
+					Message: "game play limit per hour exceeded. userID=UserId1, playCount=60",
+					Code:    http.StatusTooManyRequests,
+				})
+			},
+			want: want{
+				statusCode: http.StatusTooManyRequests,
+				body: `{
+							"code": 429,
+							"message": "Too Many Requests"
+						}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMock(ctrl)
+			tt.before(mock, tt.args)
+			writer := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "http://localhost:8080/game/finish", strings.NewReader(tt.args.body))
+			request = request.WithContext(dcontext.SetUserID(request.Context(), "UserId1"))
+
+			h := NewGameHandler(response.NewHttpResponse(), mock.gameService)
+			h.HandleGameFinish(writer, request)
+
+			res := writer.Result()
+			body, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				t.Errorf("ioutil.ReadAll failed %s", err)
+			}
+
+			if res.StatusCode != tt.want.statusCode {
+				t.Errorf("status code = %d, want %d", res.StatusCode, tt.want.statusCode)
+			}
+
+			boolean, err := deepEqualString(string(body), tt.want.body)
+			if err != nil {
+				t.Errorf("response.DeepEqualString() failed %s", err)
+			}
+			if !boolean {
+				t.Errorf("response body = \n%s\n, want \n%s\n", string(body), tt.want.body)
+			}
+		})
+	}
+}
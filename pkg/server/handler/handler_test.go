@@ -13,6 +13,8 @@ type mock struct {
 	gachaService      *mock_service.MockGachaServiceInterface
 	rankingService    *mock_service.MockRankingServiceInterface
 	collectionService *mock_service.MockCollectionServiceInterface
+	friendService     *mock_service.MockFriendServiceInterface
+	tradeService      *mock_service.MockTradeServiceInterface
 }
 
 func newMock(ctrl *gomock.Controller) *mock {
@@ -21,6 +23,8 @@ func newMock(ctrl *gomock.Controller) *mock {
 		gachaService:      mock_service.NewMockGachaServiceInterface(ctrl),
 		rankingService:    mock_service.NewMockRankingServiceInterface(ctrl),
 		collectionService: mock_service.NewMockCollectionServiceInterface(ctrl),
+		friendService:     mock_service.NewMockFriendServiceInterface(ctrl),
+		tradeService:      mock_service.NewMockTradeServiceInterface(ctrl),
 	}
 }
 
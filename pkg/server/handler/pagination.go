@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"20dojo-online/pkg/validation"
+	"net/http"
+	"strconv"
+)
+
+// pageLimitFromQuery クエリストリングから1ページあたりの取得件数を取得する. 指定がなければdefaultLimitとする
+func pageLimitFromQuery(request *http.Request, defaultLimit int, maxLimit int) (int, error) {
+	param := request.URL.Query().Get("limit")
+	if param == "" {
+		return defaultLimit, nil
+	}
+	v := validation.New()
+	limit, err := strconv.Atoi(param)
+	v.Check(err == nil, "limit", "must be int")
+	v.Min("limit", limit, 1)
+	v.Max("limit", limit, maxLimit)
+	return limit, v.Err()
+}
@@ -2,9 +2,12 @@ package handler
 
 import (
 	"20dojo-online/pkg/constant"
+	"20dojo-online/pkg/dcontext"
 	"20dojo-online/pkg/http/response"
 	"20dojo-online/pkg/myerror"
 	"20dojo-online/pkg/server/service"
+	"20dojo-online/pkg/validation"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -12,7 +15,8 @@ import (
 )
 
 type rankingListResponse struct {
-	Ranks []*rank `json:"ranks"`
+	Ranks      []*rank `json:"ranks"`
+	NextCursor string  `json:"nextCursor,omitempty"`
 }
 
 // rank ランキング情報
@@ -23,6 +27,11 @@ type rank struct {
 	Score    int    `json:"score"`
 }
 
+type rankingMeResponse struct {
+	Rank  *rank   `json:"rank"`
+	Ranks []*rank `json:"ranks"`
+}
+
 type RankingHandler struct {
 	HttpResponse   response.HttpResponseInterface
 	RankingService service.RankingServiceInterface
@@ -38,18 +47,33 @@ func NewRankingHandler(httpResponse response.HttpResponseInterface, rankingServi
 // HandleRankingList ランキング情報取得
 func (h *RankingHandler) HandleRankingList(writer http.ResponseWriter, request *http.Request) {
 
-	// クエリストリングから開始順位の受け取り
+	// クエリストリングからカーソルの受け取り. 前のページのnextCursorを指定する
+	cursor := request.URL.Query().Get("cursor")
+
+	// クエリストリングから開始順位の受け取り. カーソルとは併用できず、どちらも指定がなければ1位から
 	param := request.URL.Query().Get("start")
-	start, err := strconv.Atoi(param)
-	if err != nil {
-		err = myerror.ApplicationError{
-			Message: "failed to get query parameter",
-			Code:    http.StatusBadRequest,
-		}
+	if cursor != "" && param != "" {
+		v := validation.New()
+		v.Check(false, "start", "cannot be used with cursor")
+		err := v.Err()
 		log.Println(err)
 		h.HttpResponse.Failed(writer, err)
 		return
 	}
+	start := 1
+	if param != "" {
+		var err error
+		start, err = strconv.Atoi(param)
+		if err != nil {
+			err = myerror.ApplicationError{
+				Message: "failed to get query parameter",
+				Code:    http.StatusBadRequest,
+			}
+			log.Println(err)
+			h.HttpResponse.Failed(writer, err)
+			return
+		}
+	}
 	// startが0以下のときエラーを返す
 	if start <= 0 {
 		err := myerror.ApplicationError{
@@ -61,14 +85,101 @@ func (h *RankingHandler) HandleRankingList(writer http.ResponseWriter, request *
 		return
 	}
 
+	// クエリストリングから取得件数の受け取り
+	limit, err := pageLimitFromQuery(request, constant.RankingListLimit, constant.RankingListMaxLimit)
+	if err != nil {
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	// クエリストリングから順位の付け方の受け取り
+	mode, err := rankingModeFromQuery(request)
+	if err != nil {
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	// クエリストリングから集計期間の受け取り. 指定がなければ全期間
+	period := request.URL.Query().Get("period")
+	if period == "" {
+		period = constant.RankingPeriodAllTime
+	}
+	v := validation.New()
+	v.OneOf("period", period, constant.RankingPeriodAllTime, constant.RankingPeriodDaily, constant.RankingPeriodWeekly)
+	if err := v.Err(); err != nil {
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
 	// ランキング情報取得のロジック
 	res, err := h.RankingService.GetRankInfoList(&service.GetRankInfoListRequest{
 		Offset: start,
-		Limit:  constant.RankingListLimit,
+		Limit:  limit,
+		Cursor: cursor,
+		Mode:   mode,
+		Period: period,
+	})
+	if err != nil {
+		var appErr myerror.ApplicationError
+		if !errors.As(err, &appErr) {
+			err = myerror.ApplicationError{
+				Message:       "failed to get ranking",
+				OriginalError: err,
+				Code:          http.StatusInternalServerError,
+			}
+		}
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	// レスポンスの整形
+	var ranks []*rank
+	for _, rankInfo := range res.RankInfoList {
+		ranks = append(ranks, newRank(rankInfo))
+	}
+
+	h.HttpResponse.Success(writer, rankingListResponse{
+		Ranks:      ranks,
+		NextCursor: res.NextCursor,
+	})
+}
+
+// HandleRankingMe 自分の順位と前後のユーザのランキング情報取得
+func (h *RankingHandler) HandleRankingMe(writer http.ResponseWriter, request *http.Request) {
+	// クエリストリングから順位の付け方の受け取り
+	mode, err := rankingModeFromQuery(request)
+	if err != nil {
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	// ミドルウェアでコンテキストに格納したユーザidの取得
+	ctx := request.Context()
+	userID := dcontext.GetUserIDFromContext(ctx)
+	if userID == "" {
+		userIDEmptyErr := myerror.ApplicationError{
+			Message: "userID from context is empty",
+			Code:    http.StatusInternalServerError,
+		}
+		log.Println(userIDEmptyErr)
+		h.HttpResponse.Failed(writer, userIDEmptyErr)
+		return
+	}
+
+	// 自分の順位取得のロジック
+	res, err := h.RankingService.GetMyRankInfo(&service.GetMyRankInfoRequest{
+		UserID:    userID,
+		Neighbors: constant.RankingNeighborCount,
+		Mode:      mode,
 	})
 	if err != nil {
 		err = myerror.ApplicationError{
-			Message:       "failed to finish game correctly",
+			Message:       "failed to get my rank",
 			OriginalError: err,
 			Code:          http.StatusInternalServerError,
 		}
@@ -78,16 +189,82 @@ func (h *RankingHandler) HandleRankingList(writer http.ResponseWriter, request *
 	}
 
 	// レスポンスの整形
-	var ranks []*rank
+	ranks := make([]*rank, 0, len(res.RankInfoList))
 	for _, rankInfo := range res.RankInfoList {
-		rank := &rank{
-			UserId:   rankInfo.UserId,
-			UserName: rankInfo.UserName,
-			Rank:     rankInfo.Rank,
-			Score:    rankInfo.Score,
+		ranks = append(ranks, newRank(rankInfo))
+	}
+
+	h.HttpResponse.Success(writer, rankingMeResponse{
+		Rank:  newRank(res.MyRankInfo),
+		Ranks: ranks,
+	})
+}
+
+// HandleRankingFriends 自分とフレンドのランキング情報取得
+func (h *RankingHandler) HandleRankingFriends(writer http.ResponseWriter, request *http.Request) {
+	// クエリストリングから順位の付け方の受け取り
+	mode, err := rankingModeFromQuery(request)
+	if err != nil {
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	// ミドルウェアでコンテキストに格納したユーザidの取得
+	ctx := request.Context()
+	userID := dcontext.GetUserIDFromContext(ctx)
+	if userID == "" {
+		userIDEmptyErr := myerror.ApplicationError{
+			Message: "userID from context is empty",
+			Code:    http.StatusInternalServerError,
 		}
-		ranks = append(ranks, rank)
+		log.Println(userIDEmptyErr)
+		h.HttpResponse.Failed(writer, userIDEmptyErr)
+		return
+	}
+
+	// フレンドのランキング情報取得のロジック
+	res, err := h.RankingService.GetFriendRankInfoList(&service.GetFriendRankInfoListRequest{
+		UserID: userID,
+		Mode:   mode,
+	})
+	if err != nil {
+		err = myerror.ApplicationError{
+			Message:       "failed to get friend ranking",
+			OriginalError: err,
+			Code:          http.StatusInternalServerError,
+		}
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	// レスポンスの整形
+	ranks := make([]*rank, 0, len(res.RankInfoList))
+	for _, rankInfo := range res.RankInfoList {
+		ranks = append(ranks, newRank(rankInfo))
 	}
 
 	h.HttpResponse.Success(writer, rankingListResponse{Ranks: ranks})
 }
+
+// rankingModeFromQuery クエリストリングから順位の付け方を取得する. 指定がなければ同点の人数分順位を飛ばす
+func rankingModeFromQuery(request *http.Request) (string, error) {
+	mode := request.URL.Query().Get("mode")
+	if mode == "" {
+		mode = constant.RankingModeCompetition
+	}
+	v := validation.New()
+	v.OneOf("mode", mode, constant.RankingModeCompetition, constant.RankingModeDense)
+	return mode, v.Err()
+}
+
+// newRank ランキング情報をレスポンスの形式に変換する
+func newRank(rankInfo *service.RankInfo) *rank {
+	return &rank{
+		UserId:   rankInfo.UserId,
+		UserName: rankInfo.UserName,
+		Rank:     rankInfo.Rank,
+		Score:    rankInfo.Score,
+	}
+}
@@ -2,7 +2,9 @@ package handler
 
 import (
 	"20dojo-online/pkg/constant"
+	"20dojo-online/pkg/dcontext"
 	"20dojo-online/pkg/http/response"
+	"20dojo-online/pkg/myerror"
 	"20dojo-online/pkg/server/service"
 	"errors"
 	"io/ioutil"
@@ -36,6 +38,8 @@ func TestRankingHandler_HandleRankingList(t *testing.T) {
 				mock.rankingService.EXPECT().GetRankInfoList(&service.GetRankInfoListRequest{
 					Offset: 1,
 					Limit:  constant.RankingListLimit,
+					Mode:   constant.RankingModeCompetition,
+					Period: constant.RankingPeriodAllTime,
 				}).Return(&service.GetRankInfoListResponse{
 					RankInfoList: []*service.RankInfo{
 						{
@@ -73,10 +77,200 @@ func TestRankingHandler_HandleRankingList(t *testing.T) {
 						}`,
 			},
 		},
+		{
+			name: "正常:同点を詰めた順位でランキング取得",
+			args: args{
+				request: httptest.NewRequest("GET", "http://localhost:8080/ranking/list?start=1&mode=dense", nil),
+			},
+			before: func(mock *mock, args args) {
+				mock.rankingService.EXPECT().GetRankInfoList(&service.GetRankInfoListRequest{
+					Offset: 1,
+					Limit:  constant.RankingListLimit,
+					Mode:   constant.RankingModeDense,
+					Period: constant.RankingPeriodAllTime,
+				}).Return(&service.GetRankInfoListResponse{
+					RankInfoList: []*service.RankInfo{
+						{UserId: "UserId2", UserName: "User2", Rank: 1, Score: 10000},
+						{UserId: "UserId3", UserName: "User3", Rank: 1, Score: 10000},
+						{UserId: "UserId1", UserName: "User1", Rank: 2, Score: 10},
+					},
+				}, nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body: `{
+						  "ranks": [
+							{"userId": "UserId2", "userName": "User2", "rank": 1, "score": 10000},
+							{"userId": "UserId3", "userName": "User3", "rank": 1, "score": 10000},
+							{"userId": "UserId1", "userName": "User1", "rank": 2, "score": 10}
+						  ]
+						}`,
+			},
+		},
+		{
+			name: "正常:週別ランキング取得",
+			args: args{
+				request: httptest.NewRequest("GET", "http://localhost:8080/ranking/list?start=1&period=weekly", nil),
+			},
+			before: func(mock *mock, args args) {
+				mock.rankingService.EXPECT().GetRankInfoList(&service.GetRankInfoListRequest{
+					Offset: 1,
+					Limit:  constant.RankingListLimit,
+					Mode:   constant.RankingModeCompetition,
+					Period: constant.RankingPeriodWeekly,
+				}).Return(&service.GetRankInfoListResponse{
+					RankInfoList: []*service.RankInfo{
+						{UserId: "UserId1", UserName: "User1", Rank: 1, Score: 300},
+					},
+				}, nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body: `{
+						  "ranks": [
+							{"userId": "UserId1", "userName": "User1", "rank": 1, "score": 300}
+						  ]
+						}`,
+			},
+		},
+		{
+			name: "異常:集計期間エラー",
+			args: args{
+				request: httptest.NewRequest("GET", "http://localhost:8080/ranking/list?start=1&period=monthly", nil),
+			},
+			before: func(mock *mock, args args) {},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body: `{
+							"code": 400,
+							"message": "Bad Request",
+							"errors": [{"field": "period", "message": "must be one of [all_time, daily, weekly]"}]
+						}`,
+			},
+		},
+		{
+			name: "異常:順位の付け方エラー",
+			args: args{
+				request: httptest.NewRequest("GET", "http://localhost:8080/ranking/list?start=1&mode=unknown", nil),
+			},
+			before: func(mock *mock, args args) {},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body: `{
+							"code": 400,
+							"message": "Bad Request",
+							"errors": [{"field": "mode", "message": "must be one of [competition, dense]"}]
+						}`,
+			},
+		},
+		{
+			name: "正常:開始順位の指定がない場合は1位から",
+			args: args{
+				request: httptest.NewRequest("GET", "http://localhost:8080/ranking/list?limit=1", nil),
+			},
+			before: func(mock *mock, args args) {
+				mock.rankingService.EXPECT().GetRankInfoList(&service.GetRankInfoListRequest{
+					Offset: 1,
+					Limit:  1,
+					Mode:   constant.RankingModeCompetition,
+					Period: constant.RankingPeriodAllTime,
+				}).Return(&service.GetRankInfoListResponse{
+					RankInfoList: []*service.RankInfo{
+						{UserId: "UserId2", UserName: "User2", Rank: 1, Score: 10000},
+					},
+					NextCursor: "next",
+				}, nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body: `{
+						  "ranks": [
+							{"userId": "UserId2", "userName": "User2", "rank": 1, "score": 10000}
+						  ],
+						  "nextCursor": "next"
+						}`,
+			},
+		},
+		{
+			name: "正常:カーソル指定",
+			args: args{
+				request: httptest.NewRequest("GET", "http://localhost:8080/ranking/list?cursor=next&limit=1", nil),
+			},
+			before: func(mock *mock, args args) {
+				mock.rankingService.EXPECT().GetRankInfoList(&service.GetRankInfoListRequest{
+					Offset: 1,
+					Limit:  1,
+					Cursor: "next",
+					Mode:   constant.RankingModeCompetition,
+					Period: constant.RankingPeriodAllTime,
+				}).Return(&service.GetRankInfoListResponse{
+					RankInfoList: []*service.RankInfo{
+						{UserId: "UserId1", UserName: "User1", Rank: 2, Score: 10},
+					},
+				}, nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body: `{
+						  "ranks": [
+							{"userId": "UserId1", "userName": "User1", "rank": 2, "score": 10}
+						  ]
+						}`,
+			},
+		},
+		{
+			name: "異常:カーソルと開始順位の併用",
+			args: args{
+				request: httptest.NewRequest("GET", "http://localhost:8080/ranking/list?cursor=next&start=1", nil),
+			},
+			before: func(mock *mock, args args) {},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body: `{
+							"code": 400,
+							"message": "Bad Request",
+							"errors": [{"field": "start", "message": "cannot be used with cursor"}]
+						}`,
+			},
+		},
+		{
+			name: "異常:取得件数が上限を超える",
+			args: args{
+				request: httptest.NewRequest("GET", "http://localhost:8080/ranking/list?start=1&limit=101", nil),
+			},
+			before: func(mock *mock, args args) {},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body: `{
+							"code": 400,
+							"message": "Bad Request",
+							"errors": [{"field": "limit", "message": "must be 100 or less"}]
+						}`,
+			},
+		},
+		{
+			name: "異常:カーソルの形式が不正",
+			args: args{
+				request: httptest.NewRequest("GET", "http://localhost:8080/ranking/list?cursor=invalid", nil),
+			},
+			before: func(mock *mock, args args) {
+				mock.rankingService.EXPECT().GetRankInfoList(gomock.Any()).Return(nil, myerror.ApplicationError{
+					Message: "failed to decode ranking cursor",
+					Code:    http.StatusBadRequest,
+				})
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body: `{
+							"code": 400,
+							"message": "Bad Request"
+						}`,
+			},
+		},
 		{
 			name: "異常:クエリパラメータエラー",
 			args: args{
-				request: httptest.NewRequest("GET", "http://localhost:8080/ranking/list?nostart=1", nil),
+				request: httptest.NewRequest("GET", "http://localhost:8080/ranking/list?start=first", nil),
 			},
 			before: func(mock *mock, args args) {},
 			want: want{
@@ -110,6 +304,8 @@ func TestRankingHandler_HandleRankingList(t *testing.T) {
 				mock.rankingService.EXPECT().GetRankInfoList(&service.GetRankInfoListRequest{
 					Offset: 1,
 					Limit:  constant.RankingListLimit,
+					Mode:   constant.RankingModeCompetition,
+					Period: constant.RankingPeriodAllTime,
 				}).Return(nil, errors.New("GetRankInfoList"))
 			},
 			want: want{
@@ -152,3 +348,224 @@ func TestRankingHandler_HandleRankingList(t *testing.T) {
 		})
 	}
 }
+
+func TestRankingHandler_HandleRankingMe(t *testing.T) {
+	type args struct {
+		request *http.Request
+	}
+	type want struct {
+		statusCode int
+		body       string
+	}
+	tests := []struct {
+		name   string
+		args   args
+		before func(mock *mock, args args)
+		want   want
+	}{
+		{
+			name: "正常:自分の順位と前後のユーザ",
+			args: args{
+				request: httptest.NewRequest("GET", "http://localhost:8080/ranking/me", nil),
+			},
+			before: func(mock *mock, args args) {
+				myRankInfo := &service.RankInfo{UserId: "UserId1", UserName: "User1", Rank: 2, Score: 100}
+				mock.rankingService.EXPECT().GetMyRankInfo(&service.GetMyRankInfoRequest{
+					UserID:    "UserId1",
+					Neighbors: constant.RankingNeighborCount,
+					Mode:      constant.RankingModeCompetition,
+				}).Return(&service.GetMyRankInfoResponse{
+					MyRankInfo: myRankInfo,
+					RankInfoList: []*service.RankInfo{
+						{UserId: "UserId2", UserName: "User2", Rank: 1, Score: 10000},
+						myRankInfo,
+					},
+				}, nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body: `{
+						  "rank": {"userId": "UserId1", "userName": "User1", "rank": 2, "score": 100},
+						  "ranks": [
+							{"userId": "UserId2", "userName": "User2", "rank": 1, "score": 10000},
+							{"userId": "UserId1", "userName": "User1", "rank": 2, "score": 100}
+						  ]
+						}`,
+			},
+		},
+		{
+			name: "異常:順位の付け方エラー",
+			args: args{
+				request: httptest.NewRequest("GET", "http://localhost:8080/ranking/me?mode=unknown", nil),
+			},
+			before: func(mock *mock, args args) {},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body: `{
+							"code": 400,
+							"message": "Bad Request",
+							"errors": [{"field": "mode", "message": "must be one of [competition, dense]"}]
+						}`,
+			},
+		},
+		{
+			name: "異常:順位取得エラー",
+			args: args{
+				request: httptest.NewRequest("GET", "http://localhost:8080/ranking/me?mode=dense", nil),
+			},
+			before: func(mock *mock, args args) {
+				mock.rankingService.EXPECT().GetMyRankInfo(&service.GetMyRankInfoRequest{
+					UserID:    "UserId1",
+					Neighbors: constant.RankingNeighborCount,
+					Mode:      constant.RankingModeDense,
+				}).Return(nil, errors.New("GetMyRankInfo"))
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+				body: `{
+							"code": 500,
+							"message": "Internal Server Error"
+						}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMock(ctrl)
+			tt.before(mock, tt.args)
+
+			writer := httptest.NewRecorder()
+			request := tt.args.request.WithContext(dcontext.SetUserID(tt.args.request.Context(), "UserId1"))
+
+			h := NewRankingHandler(response.NewHttpResponse(), mock.rankingService)
+			h.HandleRankingMe(writer, request)
+
+			res := writer.Result()
+			body, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				t.Errorf("ioutil.ReadAll failed %s", err)
+			}
+
+			if res.StatusCode != tt.want.statusCode {
+				t.Errorf("status code = %d, want %d", res.StatusCode, tt.want.statusCode)
+			}
+
+			boolean, err := deepEqualString(string(body), tt.want.body)
+			if err != nil {
+				t.Errorf("response.DeepEqualString() failed %s", err)
+			}
+			if !boolean {
+				t.Errorf("response body = \n%s\n, want \n%s\n", string(body), tt.want.body)
+			}
+		})
+	}
+}
+
+func TestRankingHandler_HandleRankingFriends(t *testing.T) {
+	type args struct {
+		request *http.Request
+	}
+	type want struct {
+		statusCode int
+		body       string
+	}
+	tests := []struct {
+		name   string
+		args   args
+		before func(mock *mock, args args)
+		want   want
+	}{
+		{
+			name: "正常:自分とフレンドのランキング",
+			args: args{
+				request: httptest.NewRequest("GET", "http://localhost:8080/ranking/friends", nil),
+			},
+			before: func(mock *mock, args args) {
+				mock.rankingService.EXPECT().GetFriendRankInfoList(&service.GetFriendRankInfoListRequest{
+					UserID: "UserId1",
+					Mode:   constant.RankingModeCompetition,
+				}).Return(&service.GetRankInfoListResponse{
+					RankInfoList: []*service.RankInfo{
+						{UserId: "UserId2", UserName: "User2", Rank: 1, Score: 10000},
+						{UserId: "UserId1", UserName: "User1", Rank: 2, Score: 100},
+					},
+				}, nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body: `{
+						  "ranks": [
+							{"userId": "UserId2", "userName": "User2", "rank": 1, "score": 10000},
+							{"userId": "UserId1", "userName": "User1", "rank": 2, "score": 100}
+						  ]
+						}`,
+			},
+		},
+		{
+			name: "異常:順位の付け方エラー",
+			args: args{
+				request: httptest.NewRequest("GET", "http://localhost:8080/ranking/friends?mode=unknown", nil),
+			},
+			before: func(mock *mock, args args) {},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body: `{
+							"code": 400,
+							"message": "Bad Request",
+							"errors": [{"field": "mode", "message": "must be one of [competition, dense]"}]
+						}`,
+			},
+		},
+		{
+			name: "異常:ランキング取得エラー",
+			args: args{
+				request: httptest.NewRequest("GET", "http://localhost:8080/ranking/friends?mode=dense", nil),
+			},
+			before: func(mock *mock, args args) {
+				mock.rankingService.EXPECT().GetFriendRankInfoList(&service.GetFriendRankInfoListRequest{
+					UserID: "UserId1",
+					Mode:   constant.RankingModeDense,
+				}).Return(nil, errors.New("GetFriendRankInfoList"))
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+				body: `{
+							"code": 500,
+							"message": "Internal Server Error"
+						}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMock(ctrl)
+			tt.before(mock, tt.args)
+
+			writer := httptest.NewRecorder()
+			request := tt.args.request.WithContext(dcontext.SetUserID(tt.args.request.Context(), "UserId1"))
+
+			h := NewRankingHandler(response.NewHttpResponse(), mock.rankingService)
+			h.HandleRankingFriends(writer, request)
+
+			res := writer.Result()
+			body, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				t.Errorf("ioutil.ReadAll failed %s", err)
+			}
+
+			if res.StatusCode != tt.want.statusCode {
+				t.Errorf("status code = %d, want %d", res.StatusCode, tt.want.statusCode)
+			}
+
+			boolean, err := deepEqualString(string(body), tt.want.body)
+			if err != nil {
+				t.Errorf("response.DeepEqualString() failed %s", err)
+			}
+			if !boolean {
+				t.Errorf("response body = \n%s\n, want \n%s\n", string(body), tt.want.body)
+			}
+		})
+	}
+}
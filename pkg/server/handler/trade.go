@@ -0,0 +1,295 @@
+package handler
+
+import (
+	"20dojo-online/pkg/constant"
+	"20dojo-online/pkg/dcontext"
+	"20dojo-online/pkg/http/response"
+	"20dojo-online/pkg/myerror"
+	"20dojo-online/pkg/server/service"
+	"20dojo-online/pkg/validation"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+type tradeProposeRequest struct {
+	TargetUserId string       `json:"targetUserId"`
+	OfferItems   []*tradeItem `json:"offerItems"`
+	RequestItems []*tradeItem `json:"requestItems"`
+	OfferCoin    int          `json:"offerCoin"`
+	RequestCoin  int          `json:"requestCoin"`
+}
+
+// Validate 提案先のユーザIDは必須. アイテムは両方合わせて1つ以上、片方につきTradeMaxItems種類まで
+func (r *tradeProposeRequest) Validate(v *validation.Validator) {
+	v.Required("targetUserId", r.TargetUserId)
+	v.MaxLength("targetUserId", r.TargetUserId, constant.MaxNameLength)
+	v.Check(len(r.OfferItems)+len(r.RequestItems) > 0, "offerItems", "at least one item is required in offerItems or requestItems")
+	v.Check(len(r.OfferItems) <= constant.TradeMaxItems, "offerItems", fmt.Sprintf("must be at most %d items", constant.TradeMaxItems))
+	v.Check(len(r.RequestItems) <= constant.TradeMaxItems, "requestItems", fmt.Sprintf("must be at most %d items", constant.TradeMaxItems))
+	for _, side := range []struct {
+		field string
+		items []*tradeItem
+	}{
+		{field: "offerItems", items: r.OfferItems},
+		{field: "requestItems", items: r.RequestItems},
+	} {
+		for i, item := range side.items {
+			prefix := fmt.Sprintf("%s[%d]", side.field, i)
+			if item == nil {
+				v.Check(false, prefix, "is required")
+				continue
+			}
+			v.Required(prefix+".collectionID", item.CollectionID)
+			v.MaxLength(prefix+".collectionID", item.CollectionID, constant.MaxNameLength)
+			v.Min(prefix+".count", item.Count, 1)
+		}
+	}
+	v.Min("offerCoin", r.OfferCoin, 0)
+	v.Min("requestCoin", r.RequestCoin, 0)
+}
+
+// tradeItem トレードで受け渡すアイテム
+type tradeItem struct {
+	CollectionID string `json:"collectionID"`
+	Name         string `json:"name,omitempty"`
+	Rarity       int    `json:"rarity,omitempty"`
+	Count        int    `json:"count"`
+}
+
+type tradeProposeResponse struct {
+	TradeId   string `json:"tradeId"`
+	ExpiresAt int64  `json:"expiresAt"`
+}
+
+type tradeActionRequest struct {
+	TradeId string `json:"tradeId"`
+}
+
+// Validate トレードIDは必須
+func (r *tradeActionRequest) Validate(v *validation.Validator) {
+	v.Required("tradeId", r.TradeId)
+	v.MaxLength("tradeId", r.TradeId, constant.MaxNameLength)
+}
+
+type tradeListResponse struct {
+	Sent     []*trade `json:"sent"`
+	Received []*trade `json:"received"`
+}
+
+// trade 承認待ちのトレード情報
+type trade struct {
+	TradeId        string       `json:"tradeId"`
+	ProposerUserId string       `json:"proposerUserId"`
+	TargetUserId   string       `json:"targetUserId"`
+	OfferItems     []*tradeItem `json:"offerItems"`
+	RequestItems   []*tradeItem `json:"requestItems"`
+	OfferCoin      int          `json:"offerCoin"`
+	RequestCoin    int          `json:"requestCoin"`
+	ExpiresAt      int64        `json:"expiresAt"`
+	CreatedAt      int64        `json:"createdAt"`
+}
+
+type TradeHandler struct {
+	HttpResponse response.HttpResponseInterface
+	TradeService service.TradeServiceInterface
+}
+
+func NewTradeHandler(httpResponse response.HttpResponseInterface, tradeService service.TradeServiceInterface) *TradeHandler {
+	return &TradeHandler{
+		HttpResponse: httpResponse,
+		TradeService: tradeService,
+	}
+}
+
+// HandleTradeList 承認待ちのトレード一覧の取得
+func (h *TradeHandler) HandleTradeList(writer http.ResponseWriter, request *http.Request) {
+	// コンテキストからユーザidを取得
+	ctx := request.Context()
+	userID := dcontext.GetUserIDFromContext(ctx)
+	if userID == "" {
+		userIDEmptyErr := myerror.ApplicationError{
+			Message: "userID from context is empty",
+			Code:    http.StatusInternalServerError,
+		}
+		log.Println(userIDEmptyErr)
+		h.HttpResponse.Failed(writer, userIDEmptyErr)
+		return
+	}
+
+	// トレード一覧取得のロジック
+	res, err := h.TradeService.GetTradeList(&service.GetTradeListRequest{UserID: userID})
+	if err != nil {
+		var appErr myerror.ApplicationError
+		if !errors.As(err, &appErr) {
+			err = myerror.ApplicationError{
+				Message:       "failed to get trade list",
+				OriginalError: err,
+				Code:          http.StatusInternalServerError,
+			}
+		}
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	// レスポンスの整形
+	h.HttpResponse.Success(writer, &tradeListResponse{
+		Sent:     newTrades(res.Sent),
+		Received: newTrades(res.Received),
+	})
+}
+
+// HandleTradePropose トレードの提案
+func (h *TradeHandler) HandleTradePropose(writer http.ResponseWriter, request *http.Request) {
+	var requestBody tradeProposeRequest
+	if err := validation.DecodeJSON(request.Body, &requestBody); err != nil {
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	// コンテキストからユーザidを取得
+	ctx := request.Context()
+	userID := dcontext.GetUserIDFromContext(ctx)
+	if userID == "" {
+		userIDEmptyErr := myerror.ApplicationError{
+			Message: "userID from context is empty",
+			Code:    http.StatusInternalServerError,
+		}
+		log.Println(userIDEmptyErr)
+		h.HttpResponse.Failed(writer, userIDEmptyErr)
+		return
+	}
+
+	// トレード提案のロジック
+	res, err := h.TradeService.ProposeTrade(&service.ProposeTradeRequest{
+		UserID:       userID,
+		TargetUserID: requestBody.TargetUserId,
+		OfferItems:   newTradeItemRequests(requestBody.OfferItems),
+		RequestItems: newTradeItemRequests(requestBody.RequestItems),
+		OfferCoin:    requestBody.OfferCoin,
+		RequestCoin:  requestBody.RequestCoin,
+	})
+	if err != nil {
+		var appErr myerror.ApplicationError
+		if !errors.As(err, &appErr) {
+			err = myerror.ApplicationError{
+				Message:       "failed to propose trade",
+				OriginalError: err,
+				Code:          http.StatusInternalServerError,
+			}
+		}
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	h.HttpResponse.Success(writer, &tradeProposeResponse{
+		TradeId:   res.TradeID,
+		ExpiresAt: res.ExpiresAt.Unix(),
+	})
+}
+
+// HandleTradeAccept トレードの承認. アイテムとコインを受け渡す
+func (h *TradeHandler) HandleTradeAccept(writer http.ResponseWriter, request *http.Request) {
+	h.handleTradeAction(writer, request, h.TradeService.AcceptTrade, "failed to accept trade")
+}
+
+// HandleTradeDecline 自分宛てのトレードの拒否
+func (h *TradeHandler) HandleTradeDecline(writer http.ResponseWriter, request *http.Request) {
+	h.handleTradeAction(writer, request, h.TradeService.DeclineTrade, "failed to decline trade")
+}
+
+// HandleTradeCancel 自分が提案したトレードの取り消し
+func (h *TradeHandler) HandleTradeCancel(writer http.ResponseWriter, request *http.Request) {
+	h.handleTradeAction(writer, request, h.TradeService.CancelTrade, "failed to cancel trade")
+}
+
+// handleTradeAction トレードIDを受け取り、トレードを操作する
+func (h *TradeHandler) handleTradeAction(writer http.ResponseWriter, request *http.Request, action func(*service.TradeActionRequest) error, message string) {
+	var requestBody tradeActionRequest
+	if err := validation.DecodeJSON(request.Body, &requestBody); err != nil {
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	// コンテキストからユーザidを取得
+	ctx := request.Context()
+	userID := dcontext.GetUserIDFromContext(ctx)
+	if userID == "" {
+		userIDEmptyErr := myerror.ApplicationError{
+			Message: "userID from context is empty",
+			Code:    http.StatusInternalServerError,
+		}
+		log.Println(userIDEmptyErr)
+		h.HttpResponse.Failed(writer, userIDEmptyErr)
+		return
+	}
+
+	if err := action(&service.TradeActionRequest{
+		UserID:  userID,
+		TradeID: requestBody.TradeId,
+	}); err != nil {
+		var appErr myerror.ApplicationError
+		if !errors.As(err, &appErr) {
+			err = myerror.ApplicationError{
+				Message:       message,
+				OriginalError: err,
+				Code:          http.StatusInternalServerError,
+			}
+		}
+		log.Println(err)
+		h.HttpResponse.Failed(writer, err)
+		return
+	}
+
+	h.HttpResponse.Success(writer, nil)
+}
+
+// newTradeItemRequests リクエストのアイテムをサービスの形式に変換する
+func newTradeItemRequests(items []*tradeItem) []*service.TradeItemRequest {
+	requests := make([]*service.TradeItemRequest, 0, len(items))
+	for _, item := range items {
+		requests = append(requests, &service.TradeItemRequest{
+			CollectionID: item.CollectionID,
+			Count:        item.Count,
+		})
+	}
+	return requests
+}
+
+// newTrades トレード情報をレスポンスの形式に変換する
+func newTrades(tradeInfoList []*service.TradeInfo) []*trade {
+	trades := make([]*trade, 0, len(tradeInfoList))
+	for _, tradeInfo := range tradeInfoList {
+		trades = append(trades, &trade{
+			TradeId:        tradeInfo.TradeID,
+			ProposerUserId: tradeInfo.ProposerUserID,
+			TargetUserId:   tradeInfo.TargetUserID,
+			OfferItems:     newTradeItems(tradeInfo.OfferItems),
+			RequestItems:   newTradeItems(tradeInfo.RequestItems),
+			OfferCoin:      tradeInfo.OfferCoin,
+			RequestCoin:    tradeInfo.RequestCoin,
+			ExpiresAt:      tradeInfo.ExpiresAt.Unix(),
+			CreatedAt:      tradeInfo.CreatedAt.Unix(),
+		})
+	}
+	return trades
+}
+
+// newTradeItems トレードで受け渡すアイテム情報をレスポンスの形式に変換する
+func newTradeItems(tradeItemInfoList []*service.TradeItemInfo) []*tradeItem {
+	items := make([]*tradeItem, 0, len(tradeItemInfoList))
+	for _, tradeItemInfo := range tradeItemInfoList {
+		items = append(items, &tradeItem{
+			CollectionID: tradeItemInfo.CollectionID,
+			Name:         tradeItemInfo.Name,
+			Rarity:       tradeItemInfo.Rarity,
+			Count:        tradeItemInfo.Count,
+		})
+	}
+	return items
+}
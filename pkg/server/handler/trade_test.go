@@ -0,0 +1,271 @@
+package handler
+
+import (
+	"20dojo-online/pkg/dcontext"
+	"20dojo-online/pkg/http/response"
+	"20dojo-online/pkg/myerror"
+	"20dojo-online/pkg/server/service"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestTradeHandler_HandleTradePropose(t *testing.T) {
+	type args struct {
+		body string
+	}
+	type want struct {
+		statusCode int
+		body       string
+	}
+	tests := []struct {
+		name   string
+		args   args
+		before func(mock *mock, args args)
+		want   want
+	}{
+		{
+			name: "正常:提案",
+			args: args{
+				body: `{"targetUserId": "UserId2", "offerItems": [{"collectionID": "1001", "count": 2}], "requestItems": [{"collectionID": "2001", "count": 1}], "offerCoin": 100}`,
+			},
+			before: func(mock *mock, args args) {
+				mock.tradeService.EXPECT().ProposeTrade(&service.ProposeTradeRequest{
+					UserID:       "UserId1",
+					TargetUserID: "UserId2",
+					OfferItems:   []*service.TradeItemRequest{{CollectionID: "1001", Count: 2}},
+					RequestItems: []*service.TradeItemRequest{{CollectionID: "2001", Count: 1}},
+					OfferCoin:    100,
+				}).Return(&service.ProposeTradeResponse{
+					TradeID:   "TradeId1",
+					ExpiresAt: time.Unix(1596510000, 0),
+				}, nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       `{"tradeId": "TradeId1", "expiresAt": 1596510000}`,
+			},
+		},
+		{
+			name: "異常:入力値エラー",
+			args: args{
+				body: `{"offerItems": [{"collectionID": "", "count": 0}], "requestCoin": -1}`,
+			},
+			before: func(mock *mock, args args) {},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body: `{
+							"code": 400,
+							"message": "Bad Request",
+							"errors": [
+								{"field": "targetUserId", "message": "is required"},
+								{"field": "offerItems[0].collectionID", "message": "is required"},
+								{"field": "offerItems[0].count", "message": "must be 1 or more"},
+								{"field": "requestCoin", "message": "must be 0 or more"}
+							]
+						}`,
+			},
+		},
+		{
+			name: "異常:アイテムなし",
+			args: args{
+				body: `{"targetUserId": "UserId2", "offerCoin": 100}`,
+			},
+			before: func(mock *mock, args args) {},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body: `{
+							"code": 400,
+							"message": "Bad Request",
+							"errors": [{"field": "offerItems", "message": "at least one item is required in offerItems or requestItems"}]
+						}`,
+			},
+		},
+		{
+			name: "異常:アイテム不足",
+			args: args{
+				body: `{"targetUserId": "UserId2", "offerItems": [{"collectionID": "1001", "count": 2}]}`,
+			},
+			before: func(mock *mock, args args) {
+				mock.tradeService.EXPECT().ProposeTrade(gomock.Any()).Return(nil, myerror.ApplicationError{
+					Message: "collection item is not enough for trade",
+					Code:    http.StatusBadRequest,
+				})
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body: `{
+							"code": 400,
+							"message": "Bad Request"
+						}`,
+			},
+		},
+		{
+			name: "異常:提案エラー",
+			args: args{
+				body: `{"targetUserId": "UserId2", "offerItems": [{"collectionID": "1001", "count": 2}]}`,
+			},
+			before: func(mock *mock, args args) {
+				mock.tradeService.EXPECT().ProposeTrade(gomock.Any()).Return(nil, errors.New("ProposeTrade"))
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+				body: `{
+							"code": 500,
+							"message": "Internal Server Error"
+						}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMock(ctrl)
+			tt.before(mock, tt.args)
+			writer := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "http://localhost:8080/trade/propose", strings.NewReader(tt.args.body))
+			request = request.WithContext(dcontext.SetUserID(request.Context(), "UserId1"))
+
+			h := NewTradeHandler(response.NewHttpResponse(), mock.tradeService)
+			h.HandleTradePropose(writer, request)
+
+			res := writer.Result()
+			body, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				t.Errorf("ioutil.ReadAll failed %s", err)
+			}
+
+			if res.StatusCode != tt.want.statusCode {
+				t.Errorf("status code = %d, want %d", res.StatusCode, tt.want.statusCode)
+			}
+
+			boolean, err := deepEqualString(string(body), tt.want.body)
+			if err != nil {
+				t.Errorf("response.DeepEqualString() failed %s", err)
+			}
+			if !boolean {
+				t.Errorf("response body = \n%s\n, want \n%s\n", string(body), tt.want.body)
+			}
+		})
+	}
+}
+
+func TestTradeHandler_HandleTradeAccept(t *testing.T) {
+	type args struct {
+		body string
+	}
+	type want struct {
+		statusCode int
+		body       string
+	}
+	tests := []struct {
+		name   string
+		args   args
+		before func(mock *mock, args args)
+		want   want
+	}{
+		{
+			name: "正常:承認",
+			args: args{
+				body: `{"tradeId": "TradeId1"}`,
+			},
+			before: func(mock *mock, args args) {
+				mock.tradeService.EXPECT().AcceptTrade(&service.TradeActionRequest{
+					UserID:  "UserId1",
+					TradeID: "TradeId1",
+				}).Return(nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       ``,
+			},
+		},
+		{
+			name: "異常:トレードID未指定",
+			args: args{
+				body: `{}`,
+			},
+			before: func(mock *mock, args args) {},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body: `{
+							"code": 400,
+							"message": "Bad Request",
+							"errors": [{"field": "tradeId", "message": "is required"}]
+						}`,
+			},
+		},
+		{
+			name: "異常:有効期限切れ",
+			args: args{
+				body: `{"tradeId": "TradeId1"}`,
+			},
+			before: func(mock *mock, args args) {
+				mock.tradeService.EXPECT().AcceptTrade(gomock.Any()).Return(myerror.ApplicationError{
+					Message: "trade has expired",
+					Code:    http.StatusBadRequest,
+				})
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body: `{
+							"code": 400,
+							"message": "Bad Request"
+						}`,
+			},
+		},
+		{
+			name: "異常:承認エラー",
+			args: args{
+				body: `{"tradeId": "TradeId1"}`,
+			},
+			before: func(mock *mock, args args) {
+				mock.tradeService.EXPECT().AcceptTrade(gomock.Any()).Return(errors.New("AcceptTrade"))
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+				body: `{
+							"code": 500,
+							"message": "Internal Server Error"
+						}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMock(ctrl)
+			tt.before(mock, tt.args)
+			writer := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "http://localhost:8080/trade/accept", strings.NewReader(tt.args.body))
+			request = request.WithContext(dcontext.SetUserID(request.Context(), "UserId1"))
+
+			h := NewTradeHandler(response.NewHttpResponse(), mock.tradeService)
+			h.HandleTradeAccept(writer, request)
+
+			res := writer.Result()
+			body, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				t.Errorf("ioutil.ReadAll failed %s", err)
+			}
+
+			if res.StatusCode != tt.want.statusCode {
+				t.Errorf("status code = %d, want %d", res.StatusCode, tt.want.statusCode)
+			}
+
+			boolean, err := deepEqualString(string(body), tt.want.body)
+			if err != nil {
+				t.Errorf("response.DeepEqualString() failed %s", err)
+			}
+			if !boolean {
+				t.Errorf("response body = \n%s\n, want \n%s\n", string(body), tt.want.body)
+			}
+		})
+	}
+}
@@ -2,15 +2,16 @@ package handler
 
 import (
 	"20dojo-online/pkg/myerror"
-	"encoding/json"
 	"log"
 	"net/http"
 
 	"github.com/google/uuid"
 
+	"20dojo-online/pkg/constant"
 	"20dojo-online/pkg/dcontext"
 	"20dojo-online/pkg/http/response"
 	"20dojo-online/pkg/server/model"
+	"20dojo-online/pkg/validation"
 )
 
 type UserHandler struct {
@@ -29,6 +30,12 @@ type userCreateRequest struct {
 	Name string `json:"name"`
 }
 
+// Validate ユーザ名は必須かつ最大文字数以下
+func (r *userCreateRequest) Validate(v *validation.Validator) {
+	v.Required("name", r.Name)
+	v.MaxLength("name", r.Name, constant.MaxNameLength)
+}
+
 type userCreateResponse struct {
 	Token string `json:"token"`
 }
@@ -38,12 +45,7 @@ func (h *UserHandler) HandleUserCreate(writer http.ResponseWriter, request *http
 
 	// リクエストBodyから更新後情報を取得
 	var requestBody userCreateRequest
-	if err := json.NewDecoder(request.Body).Decode(&requestBody); err != nil {
-		err = myerror.ApplicationError{
-			Message:       "failed to decode request body",
-			OriginalError: err,
-			Code:          http.StatusBadRequest,
-		}
+	if err := validation.DecodeJSON(request.Body, &requestBody); err != nil {
 		log.Println(err)
 		h.HttpResponse.Failed(writer, err)
 		return
@@ -102,6 +104,7 @@ type userGetResponse struct {
 	Name      string `json:"name"`
 	HighScore int    `json:"highScore"`
 	Coin      int    `json:"coin"`
+	Shard     int    `json:"shard"`
 }
 
 // HandleUserGet ユーザ情報取得処理
@@ -147,6 +150,7 @@ func (h *UserHandler) HandleUserGet(writer http.ResponseWriter, request *http.Re
 		Name:      user.Name,
 		HighScore: user.HighScore,
 		Coin:      user.Coin,
+		Shard:     user.Shard,
 	})
 }
 
@@ -154,17 +158,18 @@ type userUpdateRequest struct {
 	Name string `json:"name"`
 }
 
+// Validate ユーザ名は必須かつ最大文字数以下
+func (r *userUpdateRequest) Validate(v *validation.Validator) {
+	v.Required("name", r.Name)
+	v.MaxLength("name", r.Name, constant.MaxNameLength)
+}
+
 // HandleUserUpdate ユーザ情報更新処理
 func (h *UserHandler) HandleUserUpdate(writer http.ResponseWriter, request *http.Request) {
 
 	// リクエストBodyから更新後情報を取得
 	var requestBody userUpdateRequest
-	if err := json.NewDecoder(request.Body).Decode(&requestBody); err != nil {
-		err = myerror.ApplicationError{
-			Message:       "failed to decode request body",
-			OriginalError: err,
-			Code:          http.StatusBadRequest,
-		}
+	if err := validation.DecodeJSON(request.Body, &requestBody); err != nil {
 		log.Println(err)
 		h.HttpResponse.Failed(writer, err)
 		return
@@ -3,6 +3,7 @@ package server
 import (
 	"20dojo-online/pkg/db"
 	"20dojo-online/pkg/http/middleware"
+	"20dojo-online/pkg/leaderboard"
 	"20dojo-online/pkg/server/handler"
 	"20dojo-online/pkg/server/model"
 	"20dojo-online/pkg/server/service"
@@ -13,7 +14,9 @@ import (
 var (
 	testUserRepository = model.NewUserRepository(db.Conn)
 	testAuthMiddleware = middleware.NewMiddleware(httpResponse, testUserRepository)
-	testRankingService = service.NewRankingService(testUserRepository)
+	testLeaderboards   = leaderboard.NewMemoryFactory()
+	testRankingService = service.NewRankingService(testUserRepository, model.NewGamePlayRepository(db.Conn),
+		model.NewRankingSeasonRepository(db.Conn), model.NewRankingSnapshotRepository(db.Conn), model.NewFriendRepository(db.Conn), testLeaderboards)
 	testRankingHandler = handler.NewRankingHandler(httpResponse, testRankingService)
 )
 
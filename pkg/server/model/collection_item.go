@@ -1,3 +1,5 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=./mock_$GOPACKAGE/mock_$GOFILE
+
 package model
 
 import (
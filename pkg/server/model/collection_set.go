@@ -0,0 +1,110 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=./mock_$GOPACKAGE/mock_$GOFILE
+
+package model
+
+import (
+	"database/sql"
+	"log"
+)
+
+// CollectionSet collection_setテーブルデータ
+type CollectionSet struct {
+	ID           string
+	Name         string
+	Rarity       int // 0より大きい場合はこのレアリティの全アイテムが対象. 0の場合はCollectionSetItemで指定したアイテムが対象
+	RewardCoin   int
+	RewardTicket int
+}
+
+// CollectionSetItem collection_set_itemテーブルデータ
+type CollectionSetItem struct {
+	CollectionSetID  string
+	CollectionItemID string
+}
+
+type CollectionSetRepository struct {
+	Conn *sql.DB
+}
+
+func NewCollectionSetRepository(conn *sql.DB) *CollectionSetRepository {
+	return &CollectionSetRepository{
+		Conn: conn,
+	}
+}
+
+type CollectionSetRepositoryInterface interface {
+	SelectCollectionSetAll() ([]*CollectionSet, error)
+	SelectCollectionSetByPrimaryKey(id string) (*CollectionSet, error)
+	SelectCollectionSetItemAll() ([]*CollectionSetItem, error)
+	SelectCollectionSetItemsByCollectionSetID(collectionSetID string) ([]*CollectionSetItem, error)
+}
+
+var _ CollectionSetRepositoryInterface = (*CollectionSetRepository)(nil)
+
+// SelectCollectionSetAll コレクションセットをID順に全取得する
+func (r *CollectionSetRepository) SelectCollectionSetAll() ([]*CollectionSet, error) {
+	rows, err := r.Conn.Query("SELECT * FROM collection_set ORDER BY id ASC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var collectionSets []*CollectionSet
+	for rows.Next() {
+		collectionSet := CollectionSet{}
+		if err := rows.Scan(&collectionSet.ID, &collectionSet.Name, &collectionSet.Rarity, &collectionSet.RewardCoin, &collectionSet.RewardTicket); err != nil {
+			log.Println(err)
+			return nil, err
+		}
+		collectionSets = append(collectionSets, &collectionSet)
+	}
+	return collectionSets, rows.Err()
+}
+
+// SelectCollectionSetByPrimaryKey 主キーを条件にコレクションセットを取得する
+func (r *CollectionSetRepository) SelectCollectionSetByPrimaryKey(id string) (*CollectionSet, error) {
+	row := r.Conn.QueryRow("SELECT * FROM collection_set WHERE id = ?", id)
+	collectionSet := CollectionSet{}
+	if err := row.Scan(&collectionSet.ID, &collectionSet.Name, &collectionSet.Rarity, &collectionSet.RewardCoin, &collectionSet.RewardTicket); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return &collectionSet, nil
+}
+
+// SelectCollectionSetItemAll コレクションセットの対象アイテムを全取得する
+func (r *CollectionSetRepository) SelectCollectionSetItemAll() ([]*CollectionSetItem, error) {
+	rows, err := r.Conn.Query("SELECT * FROM collection_set_item")
+	if err != nil {
+		return nil, err
+	}
+	return convertToCollectionSetItems(rows)
+}
+
+// SelectCollectionSetItemsByCollectionSetID コレクションセットIDを条件に対象アイテムを取得する
+func (r *CollectionSetRepository) SelectCollectionSetItemsByCollectionSetID(collectionSetID string) ([]*CollectionSetItem, error) {
+	rows, err := r.Conn.Query("SELECT * FROM collection_set_item WHERE collection_set_id = ?", collectionSetID)
+	if err != nil {
+		return nil, err
+	}
+	return convertToCollectionSetItems(rows)
+}
+
+// convertToCollectionSetItems rowsデータをCollectionSetItemのスライスへ変換する
+func convertToCollectionSetItems(rows *sql.Rows) ([]*CollectionSetItem, error) {
+	defer rows.Close()
+
+	var collectionSetItems []*CollectionSetItem
+	for rows.Next() {
+		collectionSetItem := CollectionSetItem{}
+		if err := rows.Scan(&collectionSetItem.CollectionSetID, &collectionSetItem.CollectionItemID); err != nil {
+			log.Println(err)
+			return nil, err
+		}
+		collectionSetItems = append(collectionSetItems, &collectionSetItem)
+	}
+	return collectionSetItems, rows.Err()
+}
@@ -0,0 +1,119 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=./mock_$GOPACKAGE/mock_$GOFILE
+
+package model
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// Friend friendテーブルデータ
+type Friend struct {
+	UserID       string
+	FriendUserID string
+	CreatedAt    time.Time
+}
+
+type FriendRepository struct {
+	Conn *sql.DB
+}
+
+func NewFriendRepository(conn *sql.DB) *FriendRepository {
+	return &FriendRepository{
+		Conn: conn,
+	}
+}
+
+type FriendRepositoryInterface interface {
+	SelectFriendsByUserID(userID string) ([]*Friend, error)
+	SelectFriendByPrimaryKey(tx *sql.Tx, userID string, friendUserID string) (*Friend, error)
+	SelectFriendCountByUserID(tx *sql.Tx, userID string) (int, error)
+	BulkInsertFriend(tx *sql.Tx, records []*Friend) error
+	DeleteFriendsBetween(tx *sql.Tx, userID string, otherUserID string) (int64, error)
+}
+
+var _ FriendRepositoryInterface = (*FriendRepository)(nil)
+
+// SelectFriendsByUserID ユーザIDを条件にフレンドを取得する
+func (r *FriendRepository) SelectFriendsByUserID(userID string) ([]*Friend, error) {
+	rows, err := r.Conn.Query("SELECT * FROM friend WHERE user_id = ? ORDER BY created_at ASC, friend_user_id ASC", userID)
+	if err != nil {
+		return nil, err
+	}
+	return convertToFriends(rows)
+}
+
+// SelectFriendByPrimaryKey 主キーを条件にフレンドを取得する
+func (r *FriendRepository) SelectFriendByPrimaryKey(tx *sql.Tx, userID string, friendUserID string) (*Friend, error) {
+	row := tx.QueryRow("SELECT * FROM friend WHERE user_id = ? AND friend_user_id = ?", userID, friendUserID)
+	friend := Friend{}
+	if err := row.Scan(&friend.UserID, &friend.FriendUserID, &friend.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return &friend, nil
+}
+
+// SelectFriendCountByUserID ユーザIDを条件にフレンド数を取得する
+func (r *FriendRepository) SelectFriendCountByUserID(tx *sql.Tx, userID string) (int, error) {
+	var count int
+	err := tx.QueryRow("SELECT COUNT(*) FROM friend WHERE user_id = ?", userID).Scan(&count)
+	return count, err
+}
+
+// BulkInsertFriend フレンドをまとめて登録する
+func (r *FriendRepository) BulkInsertFriend(tx *sql.Tx, records []*Friend) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	placeholder := make([]string, 0, len(records))
+	queryArgs := make([]interface{}, 0, len(records)*3)
+	for _, record := range records {
+		placeholder = append(placeholder, "(?, ?, ?)")
+		queryArgs = append(queryArgs, record.UserID, record.FriendUserID, record.CreatedAt)
+	}
+
+	stmt, err := tx.Prepare(fmt.Sprintf("INSERT INTO friend (user_id, friend_user_id, created_at) VALUES %s", strings.Join(placeholder, ", ")))
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(queryArgs...)
+	return err
+}
+
+// DeleteFriendsBetween 2人のユーザ間のフレンドを双方向とも削除し、削除した件数を返す
+func (r *FriendRepository) DeleteFriendsBetween(tx *sql.Tx, userID string, otherUserID string) (int64, error) {
+	stmt, err := tx.Prepare("DELETE FROM friend WHERE (user_id = ? AND friend_user_id = ?) OR (user_id = ? AND friend_user_id = ?)")
+	if err != nil {
+		return 0, err
+	}
+	result, err := stmt.Exec(userID, otherUserID, otherUserID, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// convertToFriends rowsデータをFriendのスライスへ変換する
+func convertToFriends(rows *sql.Rows) ([]*Friend, error) {
+	defer rows.Close()
+
+	var friends []*Friend
+	for rows.Next() {
+		friend := Friend{}
+		if err := rows.Scan(&friend.UserID, &friend.FriendUserID, &friend.CreatedAt); err != nil {
+			log.Println(err)
+			return nil, err
+		}
+		friends = append(friends, &friend)
+	}
+	return friends, rows.Err()
+}
@@ -0,0 +1,92 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=./mock_$GOPACKAGE/mock_$GOFILE
+
+package model
+
+import (
+	"database/sql"
+	"log"
+	"time"
+)
+
+// FriendRequest friend_requestテーブルデータ
+type FriendRequest struct {
+	UserID       string // 申請したユーザID
+	TargetUserID string // 申請されたユーザID
+	CreatedAt    time.Time
+}
+
+type FriendRequestRepository struct {
+	Conn *sql.DB
+}
+
+func NewFriendRequestRepository(conn *sql.DB) *FriendRequestRepository {
+	return &FriendRequestRepository{
+		Conn: conn,
+	}
+}
+
+type FriendRequestRepositoryInterface interface {
+	SelectFriendRequestByPrimaryKey(tx *sql.Tx, userID string, targetUserID string) (*FriendRequest, error)
+	SelectFriendRequestsByTargetUserID(targetUserID string) ([]*FriendRequest, error)
+	InsertFriendRequest(tx *sql.Tx, record *FriendRequest) error
+	DeleteFriendRequestsBetween(tx *sql.Tx, userID string, otherUserID string) (int64, error)
+}
+
+var _ FriendRequestRepositoryInterface = (*FriendRequestRepository)(nil)
+
+// SelectFriendRequestByPrimaryKey 主キーを条件にフレンド申請を取得する
+func (r *FriendRequestRepository) SelectFriendRequestByPrimaryKey(tx *sql.Tx, userID string, targetUserID string) (*FriendRequest, error) {
+	row := tx.QueryRow("SELECT * FROM friend_request WHERE user_id = ? AND target_user_id = ?", userID, targetUserID)
+	friendRequest := FriendRequest{}
+	if err := row.Scan(&friendRequest.UserID, &friendRequest.TargetUserID, &friendRequest.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return &friendRequest, nil
+}
+
+// SelectFriendRequestsByTargetUserID 申請されたユーザIDを条件に承認待ちのフレンド申請を古い順に取得する
+func (r *FriendRequestRepository) SelectFriendRequestsByTargetUserID(targetUserID string) ([]*FriendRequest, error) {
+	rows, err := r.Conn.Query("SELECT * FROM friend_request WHERE target_user_id = ? ORDER BY created_at ASC, user_id ASC", targetUserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var friendRequests []*FriendRequest
+	for rows.Next() {
+		friendRequest := FriendRequest{}
+		if err := rows.Scan(&friendRequest.UserID, &friendRequest.TargetUserID, &friendRequest.CreatedAt); err != nil {
+			log.Println(err)
+			return nil, err
+		}
+		friendRequests = append(friendRequests, &friendRequest)
+	}
+	return friendRequests, rows.Err()
+}
+
+// InsertFriendRequest フレンド申請を登録する
+func (r *FriendRequestRepository) InsertFriendRequest(tx *sql.Tx, record *FriendRequest) error {
+	stmt, err := tx.Prepare("INSERT INTO friend_request(user_id, target_user_id, created_at) VALUES(?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec(record.UserID, record.TargetUserID, record.CreatedAt)
+	return err
+}
+
+// DeleteFriendRequestsBetween 2人のユーザ間のフレンド申請を双方向とも削除し、削除した件数を返す
+func (r *FriendRequestRepository) DeleteFriendRequestsBetween(tx *sql.Tx, userID string, otherUserID string) (int64, error) {
+	stmt, err := tx.Prepare("DELETE FROM friend_request WHERE (user_id = ? AND target_user_id = ?) OR (user_id = ? AND target_user_id = ?)")
+	if err != nil {
+		return 0, err
+	}
+	result, err := stmt.Exec(userID, otherUserID, otherUserID, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
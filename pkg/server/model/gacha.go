@@ -0,0 +1,95 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=./mock_$GOPACKAGE/mock_$GOFILE
+
+package model
+
+import (
+	"database/sql"
+	"log"
+	"time"
+)
+
+// Gacha gachaテーブルデータ
+type Gacha struct {
+	ID              string
+	Name            string
+	CoinConsumption int
+	Mode            string
+	StartAt         time.Time
+	EndAt           time.Time
+	UpdatedAt       time.Time
+}
+
+type GachaRepository struct {
+	Conn *sql.DB
+}
+
+func NewGachaRepository(conn *sql.DB) *GachaRepository {
+	return &GachaRepository{
+		Conn: conn,
+	}
+}
+
+type GachaRepositoryInterface interface {
+	SelectGachaByPrimaryKey(gachaID string) (*Gacha, error)
+	SelectGachasInSession(now time.Time) ([]*Gacha, error)
+}
+
+var _ GachaRepositoryInterface = (*GachaRepository)(nil)
+
+// SelectGachaByPrimaryKey 主キーを条件にガチャを取得する
+func (r *GachaRepository) SelectGachaByPrimaryKey(gachaID string) (*Gacha, error) {
+	row := r.Conn.QueryRow("SELECT * FROM gacha WHERE id = ?", gachaID)
+	return convertToGacha(row)
+}
+
+// SelectGachasInSession 指定日時に開催中のガチャを取得する
+func (r *GachaRepository) SelectGachasInSession(now time.Time) ([]*Gacha, error) {
+	stmt, err := r.Conn.Prepare("SELECT * FROM gacha WHERE start_at <= ? AND ? < end_at ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := stmt.Query(now, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertToGachas(rows)
+}
+
+// convertToGacha rowデータをGachaデータへ変換する
+func convertToGacha(row *sql.Row) (*Gacha, error) {
+	gacha := Gacha{}
+	err := row.Scan(&gacha.ID, &gacha.Name, &gacha.CoinConsumption, &gacha.Mode, &gacha.StartAt, &gacha.EndAt, &gacha.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return &gacha, nil
+}
+
+// convertToGachas rowsデータをGachaのスライスへ変換する
+func convertToGachas(rows *sql.Rows) ([]*Gacha, error) {
+	defer rows.Close()
+
+	var (
+		gachas []*Gacha
+		err    error
+	)
+
+	for rows.Next() {
+		gacha := Gacha{}
+		if err = rows.Scan(&gacha.ID, &gacha.Name, &gacha.CoinConsumption, &gacha.Mode, &gacha.StartAt, &gacha.EndAt, &gacha.UpdatedAt); err != nil {
+			if err == sql.ErrNoRows {
+				return nil, nil
+			}
+			log.Println(err)
+			return nil, err
+		}
+		gachas = append(gachas, &gacha)
+	}
+	return gachas, err
+}
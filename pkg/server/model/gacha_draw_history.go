@@ -0,0 +1,131 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=./mock_$GOPACKAGE/mock_$GOFILE
+
+package model
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// GachaDrawHistory gacha_draw_historyテーブルデータ
+type GachaDrawHistory struct {
+	ID               int64
+	UserID           string
+	GachaID          string
+	CollectionItemID string
+	Rarity           int
+	CoinConsumption  int
+	Payment          string
+	Seed             int64
+	DrawIndex        int    // 乱数シードから排出した順番(0始まり)
+	PityCount        int    // 実行前の天井カウント
+	Step             int    // 実行したステップ(ステップアップガチャ以外は0)
+	BoxDrawnCounts   string // 実行前のボックスの排出済み個数(JSON. ボックスガチャ以外は空文字)
+	CreatedAt        time.Time
+}
+
+type GachaDrawHistoryRepository struct {
+	Conn *sql.DB
+}
+
+func NewGachaDrawHistoryRepository(conn *sql.DB) *GachaDrawHistoryRepository {
+	return &GachaDrawHistoryRepository{
+		Conn: conn,
+	}
+}
+
+type GachaDrawHistoryRepositoryInterface interface {
+	BulkInsertGachaDrawHistory(tx *sql.Tx, gachaDrawHistorySlice []*GachaDrawHistory) error
+	SelectGachaDrawHistoriesByUserID(userID string, limit int, offset int) ([]*GachaDrawHistory, error)
+	SelectGachaDrawHistoryByPrimaryKey(id int64) (*GachaDrawHistory, error)
+}
+
+var _ GachaDrawHistoryRepositoryInterface = (*GachaDrawHistoryRepository)(nil)
+
+// BulkInsertGachaDrawHistory ガチャ実行履歴を登録する
+func (r *GachaDrawHistoryRepository) BulkInsertGachaDrawHistory(tx *sql.Tx, gachaDrawHistorySlice []*GachaDrawHistory) error {
+
+	placeholder := make([]string, 0, len(gachaDrawHistorySlice))
+	queryArgs := make([]interface{}, 0, len(gachaDrawHistorySlice)*12)
+	for _, gachaDrawHistory := range gachaDrawHistorySlice {
+		placeholder = append(placeholder, "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+		queryArgs = append(queryArgs, gachaDrawHistory.UserID, gachaDrawHistory.GachaID, gachaDrawHistory.CollectionItemID,
+			gachaDrawHistory.Rarity, gachaDrawHistory.CoinConsumption, gachaDrawHistory.Payment, gachaDrawHistory.Seed,
+			gachaDrawHistory.DrawIndex, gachaDrawHistory.PityCount, gachaDrawHistory.Step, gachaDrawHistory.BoxDrawnCounts, gachaDrawHistory.CreatedAt)
+	}
+
+	query := fmt.Sprintf("INSERT INTO gacha_draw_history (user_id, gacha_id, collection_item_id, rarity, coin_consumption, payment, seed, "+
+		"draw_index, pity_count, step, box_drawn_counts, created_at) VALUES %s", strings.Join(placeholder, ", "))
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(queryArgs...)
+	return err
+}
+
+// SelectGachaDrawHistoriesByUserID ユーザIDを条件に新しい順に指定位置から指定件数のガチャ実行履歴を取得する
+func (r *GachaDrawHistoryRepository) SelectGachaDrawHistoriesByUserID(userID string, limit int, offset int) ([]*GachaDrawHistory, error) {
+	stmt, err := r.Conn.Prepare("SELECT * FROM gacha_draw_history WHERE user_id = ? ORDER BY id DESC LIMIT ? OFFSET ?")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := stmt.Query(userID, limit, offset-1)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertToGachaDrawHistories(rows)
+}
+
+// SelectGachaDrawHistoryByPrimaryKey 主キーを条件にガチャ実行履歴を取得する
+func (r *GachaDrawHistoryRepository) SelectGachaDrawHistoryByPrimaryKey(id int64) (*GachaDrawHistory, error) {
+	row := r.Conn.QueryRow("SELECT * FROM gacha_draw_history WHERE id = ?", id)
+	return convertToGachaDrawHistory(row)
+}
+
+// convertToGachaDrawHistory rowデータをGachaDrawHistoryデータへ変換する
+func convertToGachaDrawHistory(row *sql.Row) (*GachaDrawHistory, error) {
+	gachaDrawHistory := GachaDrawHistory{}
+	err := row.Scan(&gachaDrawHistory.ID, &gachaDrawHistory.UserID, &gachaDrawHistory.GachaID, &gachaDrawHistory.CollectionItemID,
+		&gachaDrawHistory.Rarity, &gachaDrawHistory.CoinConsumption, &gachaDrawHistory.Payment, &gachaDrawHistory.Seed,
+		&gachaDrawHistory.DrawIndex, &gachaDrawHistory.PityCount, &gachaDrawHistory.Step, &gachaDrawHistory.BoxDrawnCounts, &gachaDrawHistory.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return &gachaDrawHistory, nil
+}
+
+// convertToGachaDrawHistories rowsデータをGachaDrawHistoryのスライスへ変換する
+func convertToGachaDrawHistories(rows *sql.Rows) ([]*GachaDrawHistory, error) {
+	defer rows.Close()
+
+	var (
+		gachaDrawHistories []*GachaDrawHistory
+		err                error
+	)
+
+	for rows.Next() {
+		gachaDrawHistory := GachaDrawHistory{}
+		if err = rows.Scan(&gachaDrawHistory.ID, &gachaDrawHistory.UserID, &gachaDrawHistory.GachaID, &gachaDrawHistory.CollectionItemID,
+			&gachaDrawHistory.Rarity, &gachaDrawHistory.CoinConsumption, &gachaDrawHistory.Payment, &gachaDrawHistory.Seed,
+			&gachaDrawHistory.DrawIndex, &gachaDrawHistory.PityCount, &gachaDrawHistory.Step, &gachaDrawHistory.BoxDrawnCounts, &gachaDrawHistory.CreatedAt); err != nil {
+			if err == sql.ErrNoRows {
+				return nil, nil
+			}
+			log.Println(err)
+			return nil, err
+		}
+		gachaDrawHistories = append(gachaDrawHistories, &gachaDrawHistory)
+	}
+	return gachaDrawHistories, err
+}
@@ -1,3 +1,5 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=./mock_$GOPACKAGE/mock_$GOFILE
+
 package model
 
 import (
@@ -5,7 +7,9 @@ import (
 	"log"
 )
 
+// GachaProbability gacha_probabilityテーブルデータ
 type GachaProbability struct {
+	GachaID          string
 	CollectionItemId string
 	Ratio            int
 }
@@ -21,19 +25,19 @@ func NewGachaRepositoryRepository(conn *sql.DB) *GachaProbabilityRepository {
 }
 
 type GachaProbabilityRepositoryInterface interface {
-	SelectGachaProbabilityAll() ([]*GachaProbability, error)
+	SelectGachaProbabilitiesByGachaID(gachaID string) ([]*GachaProbability, error)
 }
 
 var _ GachaProbabilityRepositoryInterface = (*GachaProbabilityRepository)(nil)
 
-// SelectGachaProbabilityAll ガチャ排出確率情報を全取得する
-func (r *GachaProbabilityRepository) SelectGachaProbabilityAll() ([]*GachaProbability, error) {
-	stmt, err := r.Conn.Prepare("SELECT * FROM gacha_probability")
+// SelectGachaProbabilitiesByGachaID ガチャIDを条件にガチャ排出確率情報を取得する
+func (r *GachaProbabilityRepository) SelectGachaProbabilitiesByGachaID(gachaID string) ([]*GachaProbability, error) {
+	stmt, err := r.Conn.Prepare("SELECT * FROM gacha_probability WHERE gacha_id = ?")
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := stmt.Query()
+	rows, err := stmt.Query(gachaID)
 	if err != nil {
 		return nil, err
 	}
@@ -52,7 +56,7 @@ func convertToGachaProbabilities(rows *sql.Rows) ([]*GachaProbability, error) {
 
 	for rows.Next() {
 		gachaProbability := GachaProbability{}
-		if err = rows.Scan(&gachaProbability.CollectionItemId, &gachaProbability.Ratio); err != nil {
+		if err = rows.Scan(&gachaProbability.GachaID, &gachaProbability.CollectionItemId, &gachaProbability.Ratio); err != nil {
 			if err == sql.ErrNoRows {
 				return nil, nil
 			}
@@ -0,0 +1,71 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=./mock_$GOPACKAGE/mock_$GOFILE
+
+package model
+
+import (
+	"database/sql"
+	"log"
+)
+
+// GachaStep gacha_stepテーブルデータ
+type GachaStep struct {
+	GachaID          string
+	Step             int
+	Times            int
+	CoinConsumption  int
+	GuaranteedRarity int
+}
+
+type GachaStepRepository struct {
+	Conn *sql.DB
+}
+
+func NewGachaStepRepository(conn *sql.DB) *GachaStepRepository {
+	return &GachaStepRepository{
+		Conn: conn,
+	}
+}
+
+type GachaStepRepositoryInterface interface {
+	SelectGachaStepsByGachaID(gachaID string) ([]*GachaStep, error)
+}
+
+var _ GachaStepRepositoryInterface = (*GachaStepRepository)(nil)
+
+// SelectGachaStepsByGachaID ガチャIDを条件にステップ順でステップ情報を取得する
+func (r *GachaStepRepository) SelectGachaStepsByGachaID(gachaID string) ([]*GachaStep, error) {
+	stmt, err := r.Conn.Prepare("SELECT * FROM gacha_step WHERE gacha_id = ? ORDER BY step")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := stmt.Query(gachaID)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertToGachaSteps(rows)
+}
+
+// convertToGachaSteps rowsデータをGachaStepのスライスへ変換する
+func convertToGachaSteps(rows *sql.Rows) ([]*GachaStep, error) {
+	defer rows.Close()
+
+	var (
+		gachaSteps []*GachaStep
+		err        error
+	)
+
+	for rows.Next() {
+		gachaStep := GachaStep{}
+		if err = rows.Scan(&gachaStep.GachaID, &gachaStep.Step, &gachaStep.Times, &gachaStep.CoinConsumption, &gachaStep.GuaranteedRarity); err != nil {
+			if err == sql.ErrNoRows {
+				return nil, nil
+			}
+			log.Println(err)
+			return nil, err
+		}
+		gachaSteps = append(gachaSteps, &gachaStep)
+	}
+	return gachaSteps, err
+}
@@ -0,0 +1,160 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=./mock_$GOPACKAGE/mock_$GOFILE
+
+package model
+
+import (
+	"database/sql"
+	"log"
+	"time"
+)
+
+// GamePlay game_playテーブルデータ
+type GamePlay struct {
+	ID         int64
+	UserID     string
+	Score      int
+	RewardCoin int
+	Duration   int // プレイ時間(秒)
+	CreatedAt  time.Time
+}
+
+// GamePlayStats ゲームプレイの集計値
+type GamePlayStats struct {
+	PlayCount    int
+	AverageScore float64
+	BestScore    int
+}
+
+// GamePlayBestScore ユーザごとの期間内の最高スコア
+type GamePlayBestScore struct {
+	UserID     string
+	Score      int
+	AchievedAt time.Time // 最高スコアを最初に記録した日時
+}
+
+type GamePlayRepository struct {
+	Conn *sql.DB
+}
+
+func NewGamePlayRepository(conn *sql.DB) *GamePlayRepository {
+	return &GamePlayRepository{
+		Conn: conn,
+	}
+}
+
+type GamePlayRepositoryInterface interface {
+	InsertGamePlay(tx *sql.Tx, record *GamePlay) error
+	SelectGamePlaysByUserID(userID string, limit int, offset int) ([]*GamePlay, error)
+	SelectGamePlayStatsByUserIDSince(userID string, since time.Time) (*GamePlayStats, error)
+	SelectRewardCoinSumByUserIDSince(tx *sql.Tx, userID string, since time.Time) (int, error)
+	SelectGamePlayCountByUserIDSince(tx *sql.Tx, userID string, since time.Time) (int, error)
+	SelectBestScoresBetween(since time.Time, until time.Time) ([]*GamePlayBestScore, error)
+}
+
+var _ GamePlayRepositoryInterface = (*GamePlayRepository)(nil)
+
+// InsertGamePlay ゲームプレイ記録を登録する
+func (r *GamePlayRepository) InsertGamePlay(tx *sql.Tx, record *GamePlay) error {
+	stmt, err := tx.Prepare("INSERT INTO game_play(user_id, score, reward_coin, duration, created_at) VALUES(?, ?, ?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec(record.UserID, record.Score, record.RewardCoin, record.Duration, record.CreatedAt)
+	return err
+}
+
+// SelectGamePlaysByUserID ユーザIDを条件に新しい順に指定位置から指定件数のゲームプレイ記録を取得する
+func (r *GamePlayRepository) SelectGamePlaysByUserID(userID string, limit int, offset int) ([]*GamePlay, error) {
+	stmt, err := r.Conn.Prepare("SELECT * FROM game_play WHERE user_id = ? ORDER BY id DESC LIMIT ? OFFSET ?")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := stmt.Query(userID, limit, offset-1)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertToGamePlays(rows)
+}
+
+// SelectGamePlayStatsByUserIDSince ユーザIDを条件に指定日時以降のプレイ回数・平均スコア・最高スコアを取得する
+func (r *GamePlayRepository) SelectGamePlayStatsByUserIDSince(userID string, since time.Time) (*GamePlayStats, error) {
+	row := r.Conn.QueryRow("SELECT COUNT(*), COALESCE(AVG(score), 0), COALESCE(MAX(score), 0) FROM game_play WHERE user_id = ? AND created_at >= ?", userID, since)
+	gamePlayStats := GamePlayStats{}
+	if err := row.Scan(&gamePlayStats.PlayCount, &gamePlayStats.AverageScore, &gamePlayStats.BestScore); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return &gamePlayStats, nil
+}
+
+// SelectRewardCoinSumByUserIDSince ユーザIDを条件に指定日時以降に獲得した報酬コインの合計を取得する
+func (r *GamePlayRepository) SelectRewardCoinSumByUserIDSince(tx *sql.Tx, userID string, since time.Time) (int, error) {
+	row := tx.QueryRow("SELECT COALESCE(SUM(reward_coin), 0) FROM game_play WHERE user_id = ? AND created_at >= ?", userID, since)
+	var rewardCoinSum int
+	if err := row.Scan(&rewardCoinSum); err != nil {
+		log.Println(err)
+		return 0, err
+	}
+	return rewardCoinSum, nil
+}
+
+// SelectGamePlayCountByUserIDSince ユーザIDを条件に指定日時以降のプレイ回数を取得する
+func (r *GamePlayRepository) SelectGamePlayCountByUserIDSince(tx *sql.Tx, userID string, since time.Time) (int, error) {
+	row := tx.QueryRow("SELECT COUNT(*) FROM game_play WHERE user_id = ? AND created_at >= ?", userID, since)
+	var count int
+	if err := row.Scan(&count); err != nil {
+		log.Println(err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// SelectBestScoresBetween 指定日時以降、終了日時より前のユーザごとの最高スコアを取得する
+func (r *GamePlayRepository) SelectBestScoresBetween(since time.Time, until time.Time) ([]*GamePlayBestScore, error) {
+	rows, err := r.Conn.Query(`SELECT p.user_id, p.score, MIN(p.created_at) FROM game_play p
+		INNER JOIN (
+			SELECT user_id, MAX(score) AS score FROM game_play WHERE created_at >= ? AND created_at < ? GROUP BY user_id
+		) b ON p.user_id = b.user_id AND p.score = b.score
+		WHERE p.created_at >= ? AND p.created_at < ?
+		GROUP BY p.user_id, p.score`, since, until, since, until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bestScores []*GamePlayBestScore
+	for rows.Next() {
+		bestScore := GamePlayBestScore{}
+		if err = rows.Scan(&bestScore.UserID, &bestScore.Score, &bestScore.AchievedAt); err != nil {
+			log.Println(err)
+			return nil, err
+		}
+		bestScores = append(bestScores, &bestScore)
+	}
+	return bestScores, rows.Err()
+}
+
+// convertToGamePlays rowsデータをGamePlayのスライスへ変換する
+func convertToGamePlays(rows *sql.Rows) ([]*GamePlay, error) {
+	defer rows.Close()
+
+	var (
+		gamePlays []*GamePlay
+		err       error
+	)
+
+	for rows.Next() {
+		gamePlay := GamePlay{}
+		if err = rows.Scan(&gamePlay.ID, &gamePlay.UserID, &gamePlay.Score, &gamePlay.RewardCoin, &gamePlay.Duration, &gamePlay.CreatedAt); err != nil {
+			if err == sql.ErrNoRows {
+				return nil, nil
+			}
+			log.Println(err)
+			return nil, err
+		}
+		gamePlays = append(gamePlays, &gamePlay)
+	}
+	return gamePlays, err
+}
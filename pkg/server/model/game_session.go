@@ -0,0 +1,61 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=./mock_$GOPACKAGE/mock_$GOFILE
+
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// GameSession game_sessionテーブルデータ
+type GameSession struct {
+	ID         string
+	UserID     string
+	StartedAt  time.Time
+	FinishedAt sql.NullTime
+}
+
+type GameSessionRepository struct {
+	Conn *sql.DB
+}
+
+func NewGameSessionRepository(conn *sql.DB) *GameSessionRepository {
+	return &GameSessionRepository{
+		Conn: conn,
+	}
+}
+
+type GameSessionRepositoryInterface interface {
+	InsertGameSession(record *GameSession) error
+	UpdateGameSessionFinishedAt(tx *sql.Tx, sessionID string, userID string, finishedAt time.Time) (bool, error)
+}
+
+var _ GameSessionRepositoryInterface = (*GameSessionRepository)(nil)
+
+// InsertGameSession ゲームセッションを登録する
+func (r *GameSessionRepository) InsertGameSession(record *GameSession) error {
+	stmt, err := r.Conn.Prepare("INSERT INTO game_session(id, user_id, started_at) VALUES(?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec(record.ID, record.UserID, record.StartedAt)
+	return err
+}
+
+// UpdateGameSessionFinishedAt 未終了のゲームセッションを終了済みにする
+// 該当するセッションがない(存在しない・終了済み)場合はfalseを返す
+func (r *GameSessionRepository) UpdateGameSessionFinishedAt(tx *sql.Tx, sessionID string, userID string, finishedAt time.Time) (bool, error) {
+	stmt, err := tx.Prepare("UPDATE game_session SET finished_at = ? WHERE id = ? AND user_id = ? AND finished_at IS NULL")
+	if err != nil {
+		return false, err
+	}
+	result, err := stmt.Exec(finishedAt, sessionID, userID)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
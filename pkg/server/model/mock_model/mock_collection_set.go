@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: collection_set.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	model "20dojo-online/pkg/server/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCollectionSetRepositoryInterface is a mock of CollectionSetRepositoryInterface interface.
+type MockCollectionSetRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCollectionSetRepositoryInterfaceMockRecorder
+}
+
+// MockCollectionSetRepositoryInterfaceMockRecorder is the mock recorder for MockCollectionSetRepositoryInterface.
+type MockCollectionSetRepositoryInterfaceMockRecorder struct {
+	mock *MockCollectionSetRepositoryInterface
+}
+
+// NewMockCollectionSetRepositoryInterface creates a new mock instance.
+func NewMockCollectionSetRepositoryInterface(ctrl *gomock.Controller) *MockCollectionSetRepositoryInterface {
+	mock := &MockCollectionSetRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockCollectionSetRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCollectionSetRepositoryInterface) EXPECT() *MockCollectionSetRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// SelectCollectionSetAll mocks base method.
+func (m *MockCollectionSetRepositoryInterface) SelectCollectionSetAll() ([]*model.CollectionSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectCollectionSetAll")
+	ret0, _ := ret[0].([]*model.CollectionSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectCollectionSetAll indicates an expected call of SelectCollectionSetAll.
+func (mr *MockCollectionSetRepositoryInterfaceMockRecorder) SelectCollectionSetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectCollectionSetAll", reflect.TypeOf((*MockCollectionSetRepositoryInterface)(nil).SelectCollectionSetAll))
+}
+
+// SelectCollectionSetByPrimaryKey mocks base method.
+func (m *MockCollectionSetRepositoryInterface) SelectCollectionSetByPrimaryKey(id string) (*model.CollectionSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectCollectionSetByPrimaryKey", id)
+	ret0, _ := ret[0].(*model.CollectionSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectCollectionSetByPrimaryKey indicates an expected call of SelectCollectionSetByPrimaryKey.
+func (mr *MockCollectionSetRepositoryInterfaceMockRecorder) SelectCollectionSetByPrimaryKey(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectCollectionSetByPrimaryKey", reflect.TypeOf((*MockCollectionSetRepositoryInterface)(nil).SelectCollectionSetByPrimaryKey), id)
+}
+
+// SelectCollectionSetItemAll mocks base method.
+func (m *MockCollectionSetRepositoryInterface) SelectCollectionSetItemAll() ([]*model.CollectionSetItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectCollectionSetItemAll")
+	ret0, _ := ret[0].([]*model.CollectionSetItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectCollectionSetItemAll indicates an expected call of SelectCollectionSetItemAll.
+func (mr *MockCollectionSetRepositoryInterfaceMockRecorder) SelectCollectionSetItemAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectCollectionSetItemAll", reflect.TypeOf((*MockCollectionSetRepositoryInterface)(nil).SelectCollectionSetItemAll))
+}
+
+// SelectCollectionSetItemsByCollectionSetID mocks base method.
+func (m *MockCollectionSetRepositoryInterface) SelectCollectionSetItemsByCollectionSetID(collectionSetID string) ([]*model.CollectionSetItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectCollectionSetItemsByCollectionSetID", collectionSetID)
+	ret0, _ := ret[0].([]*model.CollectionSetItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectCollectionSetItemsByCollectionSetID indicates an expected call of SelectCollectionSetItemsByCollectionSetID.
+func (mr *MockCollectionSetRepositoryInterfaceMockRecorder) SelectCollectionSetItemsByCollectionSetID(collectionSetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectCollectionSetItemsByCollectionSetID", reflect.TypeOf((*MockCollectionSetRepositoryInterface)(nil).SelectCollectionSetItemsByCollectionSetID), collectionSetID)
+}
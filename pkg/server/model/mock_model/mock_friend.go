@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: friend.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	model "20dojo-online/pkg/server/model"
+	sql "database/sql"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockFriendRepositoryInterface is a mock of FriendRepositoryInterface interface.
+type MockFriendRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockFriendRepositoryInterfaceMockRecorder
+}
+
+// MockFriendRepositoryInterfaceMockRecorder is the mock recorder for MockFriendRepositoryInterface.
+type MockFriendRepositoryInterfaceMockRecorder struct {
+	mock *MockFriendRepositoryInterface
+}
+
+// NewMockFriendRepositoryInterface creates a new mock instance.
+func NewMockFriendRepositoryInterface(ctrl *gomock.Controller) *MockFriendRepositoryInterface {
+	mock := &MockFriendRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockFriendRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFriendRepositoryInterface) EXPECT() *MockFriendRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// BulkInsertFriend mocks base method.
+func (m *MockFriendRepositoryInterface) BulkInsertFriend(tx *sql.Tx, records []*model.Friend) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkInsertFriend", tx, records)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BulkInsertFriend indicates an expected call of BulkInsertFriend.
+func (mr *MockFriendRepositoryInterfaceMockRecorder) BulkInsertFriend(tx, records interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkInsertFriend", reflect.TypeOf((*MockFriendRepositoryInterface)(nil).BulkInsertFriend), tx, records)
+}
+
+// DeleteFriendsBetween mocks base method.
+func (m *MockFriendRepositoryInterface) DeleteFriendsBetween(tx *sql.Tx, userID, otherUserID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFriendsBetween", tx, userID, otherUserID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFriendsBetween indicates an expected call of DeleteFriendsBetween.
+func (mr *MockFriendRepositoryInterfaceMockRecorder) DeleteFriendsBetween(tx, userID, otherUserID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFriendsBetween", reflect.TypeOf((*MockFriendRepositoryInterface)(nil).DeleteFriendsBetween), tx, userID, otherUserID)
+}
+
+// SelectFriendByPrimaryKey mocks base method.
+func (m *MockFriendRepositoryInterface) SelectFriendByPrimaryKey(tx *sql.Tx, userID, friendUserID string) (*model.Friend, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectFriendByPrimaryKey", tx, userID, friendUserID)
+	ret0, _ := ret[0].(*model.Friend)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectFriendByPrimaryKey indicates an expected call of SelectFriendByPrimaryKey.
+func (mr *MockFriendRepositoryInterfaceMockRecorder) SelectFriendByPrimaryKey(tx, userID, friendUserID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectFriendByPrimaryKey", reflect.TypeOf((*MockFriendRepositoryInterface)(nil).SelectFriendByPrimaryKey), tx, userID, friendUserID)
+}
+
+// SelectFriendCountByUserID mocks base method.
+func (m *MockFriendRepositoryInterface) SelectFriendCountByUserID(tx *sql.Tx, userID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectFriendCountByUserID", tx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectFriendCountByUserID indicates an expected call of SelectFriendCountByUserID.
+func (mr *MockFriendRepositoryInterfaceMockRecorder) SelectFriendCountByUserID(tx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectFriendCountByUserID", reflect.TypeOf((*MockFriendRepositoryInterface)(nil).SelectFriendCountByUserID), tx, userID)
+}
+
+// SelectFriendsByUserID mocks base method.
+func (m *MockFriendRepositoryInterface) SelectFriendsByUserID(userID string) ([]*model.Friend, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectFriendsByUserID", userID)
+	ret0, _ := ret[0].([]*model.Friend)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectFriendsByUserID indicates an expected call of SelectFriendsByUserID.
+func (mr *MockFriendRepositoryInterfaceMockRecorder) SelectFriendsByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectFriendsByUserID", reflect.TypeOf((*MockFriendRepositoryInterface)(nil).SelectFriendsByUserID), userID)
+}
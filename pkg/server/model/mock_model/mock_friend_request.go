@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: friend_request.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	model "20dojo-online/pkg/server/model"
+	sql "database/sql"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockFriendRequestRepositoryInterface is a mock of FriendRequestRepositoryInterface interface.
+type MockFriendRequestRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockFriendRequestRepositoryInterfaceMockRecorder
+}
+
+// MockFriendRequestRepositoryInterfaceMockRecorder is the mock recorder for MockFriendRequestRepositoryInterface.
+type MockFriendRequestRepositoryInterfaceMockRecorder struct {
+	mock *MockFriendRequestRepositoryInterface
+}
+
+// NewMockFriendRequestRepositoryInterface creates a new mock instance.
+func NewMockFriendRequestRepositoryInterface(ctrl *gomock.Controller) *MockFriendRequestRepositoryInterface {
+	mock := &MockFriendRequestRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockFriendRequestRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFriendRequestRepositoryInterface) EXPECT() *MockFriendRequestRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeleteFriendRequestsBetween mocks base method.
+func (m *MockFriendRequestRepositoryInterface) DeleteFriendRequestsBetween(tx *sql.Tx, userID, otherUserID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFriendRequestsBetween", tx, userID, otherUserID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFriendRequestsBetween indicates an expected call of DeleteFriendRequestsBetween.
+func (mr *MockFriendRequestRepositoryInterfaceMockRecorder) DeleteFriendRequestsBetween(tx, userID, otherUserID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFriendRequestsBetween", reflect.TypeOf((*MockFriendRequestRepositoryInterface)(nil).DeleteFriendRequestsBetween), tx, userID, otherUserID)
+}
+
+// InsertFriendRequest mocks base method.
+func (m *MockFriendRequestRepositoryInterface) InsertFriendRequest(tx *sql.Tx, record *model.FriendRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertFriendRequest", tx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertFriendRequest indicates an expected call of InsertFriendRequest.
+func (mr *MockFriendRequestRepositoryInterfaceMockRecorder) InsertFriendRequest(tx, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertFriendRequest", reflect.TypeOf((*MockFriendRequestRepositoryInterface)(nil).InsertFriendRequest), tx, record)
+}
+
+// SelectFriendRequestByPrimaryKey mocks base method.
+func (m *MockFriendRequestRepositoryInterface) SelectFriendRequestByPrimaryKey(tx *sql.Tx, userID, targetUserID string) (*model.FriendRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectFriendRequestByPrimaryKey", tx, userID, targetUserID)
+	ret0, _ := ret[0].(*model.FriendRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectFriendRequestByPrimaryKey indicates an expected call of SelectFriendRequestByPrimaryKey.
+func (mr *MockFriendRequestRepositoryInterfaceMockRecorder) SelectFriendRequestByPrimaryKey(tx, userID, targetUserID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectFriendRequestByPrimaryKey", reflect.TypeOf((*MockFriendRequestRepositoryInterface)(nil).SelectFriendRequestByPrimaryKey), tx, userID, targetUserID)
+}
+
+// SelectFriendRequestsByTargetUserID mocks base method.
+func (m *MockFriendRequestRepositoryInterface) SelectFriendRequestsByTargetUserID(targetUserID string) ([]*model.FriendRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectFriendRequestsByTargetUserID", targetUserID)
+	ret0, _ := ret[0].([]*model.FriendRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectFriendRequestsByTargetUserID indicates an expected call of SelectFriendRequestsByTargetUserID.
+func (mr *MockFriendRequestRepositoryInterfaceMockRecorder) SelectFriendRequestsByTargetUserID(targetUserID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectFriendRequestsByTargetUserID", reflect.TypeOf((*MockFriendRequestRepositoryInterface)(nil).SelectFriendRequestsByTargetUserID), targetUserID)
+}
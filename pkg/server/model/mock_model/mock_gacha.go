@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: gacha.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	model "20dojo-online/pkg/server/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGachaRepositoryInterface is a mock of GachaRepositoryInterface interface.
+type MockGachaRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockGachaRepositoryInterfaceMockRecorder
+}
+
+// MockGachaRepositoryInterfaceMockRecorder is the mock recorder for MockGachaRepositoryInterface.
+type MockGachaRepositoryInterfaceMockRecorder struct {
+	mock *MockGachaRepositoryInterface
+}
+
+// NewMockGachaRepositoryInterface creates a new mock instance.
+func NewMockGachaRepositoryInterface(ctrl *gomock.Controller) *MockGachaRepositoryInterface {
+	mock := &MockGachaRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockGachaRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGachaRepositoryInterface) EXPECT() *MockGachaRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// SelectGachaByPrimaryKey mocks base method.
+func (m *MockGachaRepositoryInterface) SelectGachaByPrimaryKey(gachaID string) (*model.Gacha, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectGachaByPrimaryKey", gachaID)
+	ret0, _ := ret[0].(*model.Gacha)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectGachaByPrimaryKey indicates an expected call of SelectGachaByPrimaryKey.
+func (mr *MockGachaRepositoryInterfaceMockRecorder) SelectGachaByPrimaryKey(gachaID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectGachaByPrimaryKey", reflect.TypeOf((*MockGachaRepositoryInterface)(nil).SelectGachaByPrimaryKey), gachaID)
+}
+
+// SelectGachasInSession mocks base method.
+func (m *MockGachaRepositoryInterface) SelectGachasInSession(now time.Time) ([]*model.Gacha, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectGachasInSession", now)
+	ret0, _ := ret[0].([]*model.Gacha)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectGachasInSession indicates an expected call of SelectGachasInSession.
+func (mr *MockGachaRepositoryInterfaceMockRecorder) SelectGachasInSession(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectGachasInSession", reflect.TypeOf((*MockGachaRepositoryInterface)(nil).SelectGachasInSession), now)
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: gacha_draw_history.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	model "20dojo-online/pkg/server/model"
+	sql "database/sql"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGachaDrawHistoryRepositoryInterface is a mock of GachaDrawHistoryRepositoryInterface interface.
+type MockGachaDrawHistoryRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockGachaDrawHistoryRepositoryInterfaceMockRecorder
+}
+
+// MockGachaDrawHistoryRepositoryInterfaceMockRecorder is the mock recorder for MockGachaDrawHistoryRepositoryInterface.
+type MockGachaDrawHistoryRepositoryInterfaceMockRecorder struct {
+	mock *MockGachaDrawHistoryRepositoryInterface
+}
+
+// NewMockGachaDrawHistoryRepositoryInterface creates a new mock instance.
+func NewMockGachaDrawHistoryRepositoryInterface(ctrl *gomock.Controller) *MockGachaDrawHistoryRepositoryInterface {
+	mock := &MockGachaDrawHistoryRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockGachaDrawHistoryRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGachaDrawHistoryRepositoryInterface) EXPECT() *MockGachaDrawHistoryRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// BulkInsertGachaDrawHistory mocks base method.
+func (m *MockGachaDrawHistoryRepositoryInterface) BulkInsertGachaDrawHistory(tx *sql.Tx, gachaDrawHistorySlice []*model.GachaDrawHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkInsertGachaDrawHistory", tx, gachaDrawHistorySlice)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BulkInsertGachaDrawHistory indicates an expected call of BulkInsertGachaDrawHistory.
+func (mr *MockGachaDrawHistoryRepositoryInterfaceMockRecorder) BulkInsertGachaDrawHistory(tx, gachaDrawHistorySlice interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkInsertGachaDrawHistory", reflect.TypeOf((*MockGachaDrawHistoryRepositoryInterface)(nil).BulkInsertGachaDrawHistory), tx, gachaDrawHistorySlice)
+}
+
+// SelectGachaDrawHistoriesByUserID mocks base method.
+func (m *MockGachaDrawHistoryRepositoryInterface) SelectGachaDrawHistoriesByUserID(userID string, limit, offset int) ([]*model.GachaDrawHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectGachaDrawHistoriesByUserID", userID, limit, offset)
+	ret0, _ := ret[0].([]*model.GachaDrawHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectGachaDrawHistoriesByUserID indicates an expected call of SelectGachaDrawHistoriesByUserID.
+func (mr *MockGachaDrawHistoryRepositoryInterfaceMockRecorder) SelectGachaDrawHistoriesByUserID(userID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectGachaDrawHistoriesByUserID", reflect.TypeOf((*MockGachaDrawHistoryRepositoryInterface)(nil).SelectGachaDrawHistoriesByUserID), userID, limit, offset)
+}
+
+// SelectGachaDrawHistoryByPrimaryKey mocks base method.
+func (m *MockGachaDrawHistoryRepositoryInterface) SelectGachaDrawHistoryByPrimaryKey(id int64) (*model.GachaDrawHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectGachaDrawHistoryByPrimaryKey", id)
+	ret0, _ := ret[0].(*model.GachaDrawHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectGachaDrawHistoryByPrimaryKey indicates an expected call of SelectGachaDrawHistoryByPrimaryKey.
+func (mr *MockGachaDrawHistoryRepositoryInterfaceMockRecorder) SelectGachaDrawHistoryByPrimaryKey(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectGachaDrawHistoryByPrimaryKey", reflect.TypeOf((*MockGachaDrawHistoryRepositoryInterface)(nil).SelectGachaDrawHistoryByPrimaryKey), id)
+}
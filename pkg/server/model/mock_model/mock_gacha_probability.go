@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: gacha_probability.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	model "20dojo-online/pkg/server/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGachaProbabilityRepositoryInterface is a mock of GachaProbabilityRepositoryInterface interface.
+type MockGachaProbabilityRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockGachaProbabilityRepositoryInterfaceMockRecorder
+}
+
+// MockGachaProbabilityRepositoryInterfaceMockRecorder is the mock recorder for MockGachaProbabilityRepositoryInterface.
+type MockGachaProbabilityRepositoryInterfaceMockRecorder struct {
+	mock *MockGachaProbabilityRepositoryInterface
+}
+
+// NewMockGachaProbabilityRepositoryInterface creates a new mock instance.
+func NewMockGachaProbabilityRepositoryInterface(ctrl *gomock.Controller) *MockGachaProbabilityRepositoryInterface {
+	mock := &MockGachaProbabilityRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockGachaProbabilityRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGachaProbabilityRepositoryInterface) EXPECT() *MockGachaProbabilityRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// SelectGachaProbabilitiesByGachaID mocks base method.
+func (m *MockGachaProbabilityRepositoryInterface) SelectGachaProbabilitiesByGachaID(gachaID string) ([]*model.GachaProbability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectGachaProbabilitiesByGachaID", gachaID)
+	ret0, _ := ret[0].([]*model.GachaProbability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectGachaProbabilitiesByGachaID indicates an expected call of SelectGachaProbabilitiesByGachaID.
+func (mr *MockGachaProbabilityRepositoryInterfaceMockRecorder) SelectGachaProbabilitiesByGachaID(gachaID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectGachaProbabilitiesByGachaID", reflect.TypeOf((*MockGachaProbabilityRepositoryInterface)(nil).SelectGachaProbabilitiesByGachaID), gachaID)
+}
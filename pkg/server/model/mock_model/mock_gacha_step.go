@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: gacha_step.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	model "20dojo-online/pkg/server/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGachaStepRepositoryInterface is a mock of GachaStepRepositoryInterface interface.
+type MockGachaStepRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockGachaStepRepositoryInterfaceMockRecorder
+}
+
+// MockGachaStepRepositoryInterfaceMockRecorder is the mock recorder for MockGachaStepRepositoryInterface.
+type MockGachaStepRepositoryInterfaceMockRecorder struct {
+	mock *MockGachaStepRepositoryInterface
+}
+
+// NewMockGachaStepRepositoryInterface creates a new mock instance.
+func NewMockGachaStepRepositoryInterface(ctrl *gomock.Controller) *MockGachaStepRepositoryInterface {
+	mock := &MockGachaStepRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockGachaStepRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGachaStepRepositoryInterface) EXPECT() *MockGachaStepRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// SelectGachaStepsByGachaID mocks base method.
+func (m *MockGachaStepRepositoryInterface) SelectGachaStepsByGachaID(gachaID string) ([]*model.GachaStep, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectGachaStepsByGachaID", gachaID)
+	ret0, _ := ret[0].([]*model.GachaStep)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectGachaStepsByGachaID indicates an expected call of SelectGachaStepsByGachaID.
+func (mr *MockGachaStepRepositoryInterfaceMockRecorder) SelectGachaStepsByGachaID(gachaID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectGachaStepsByGachaID", reflect.TypeOf((*MockGachaStepRepositoryInterface)(nil).SelectGachaStepsByGachaID), gachaID)
+}
@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: game_play.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	model "20dojo-online/pkg/server/model"
+	sql "database/sql"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGamePlayRepositoryInterface is a mock of GamePlayRepositoryInterface interface.
+type MockGamePlayRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockGamePlayRepositoryInterfaceMockRecorder
+}
+
+// MockGamePlayRepositoryInterfaceMockRecorder is the mock recorder for MockGamePlayRepositoryInterface.
+type MockGamePlayRepositoryInterfaceMockRecorder struct {
+	mock *MockGamePlayRepositoryInterface
+}
+
+// NewMockGamePlayRepositoryInterface creates a new mock instance.
+func NewMockGamePlayRepositoryInterface(ctrl *gomock.Controller) *MockGamePlayRepositoryInterface {
+	mock := &MockGamePlayRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockGamePlayRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGamePlayRepositoryInterface) EXPECT() *MockGamePlayRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// InsertGamePlay mocks base method.
+func (m *MockGamePlayRepositoryInterface) InsertGamePlay(tx *sql.Tx, record *model.GamePlay) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertGamePlay", tx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertGamePlay indicates an expected call of InsertGamePlay.
+func (mr *MockGamePlayRepositoryInterfaceMockRecorder) InsertGamePlay(tx, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertGamePlay", reflect.TypeOf((*MockGamePlayRepositoryInterface)(nil).InsertGamePlay), tx, record)
+}
+
+// SelectBestScoresBetween mocks base method.
+func (m *MockGamePlayRepositoryInterface) SelectBestScoresBetween(since, until time.Time) ([]*model.GamePlayBestScore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectBestScoresBetween", since, until)
+	ret0, _ := ret[0].([]*model.GamePlayBestScore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectBestScoresBetween indicates an expected call of SelectBestScoresBetween.
+func (mr *MockGamePlayRepositoryInterfaceMockRecorder) SelectBestScoresBetween(since, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectBestScoresBetween", reflect.TypeOf((*MockGamePlayRepositoryInterface)(nil).SelectBestScoresBetween), since, until)
+}
+
+// SelectGamePlayCountByUserIDSince mocks base method.
+func (m *MockGamePlayRepositoryInterface) SelectGamePlayCountByUserIDSince(tx *sql.Tx, userID string, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectGamePlayCountByUserIDSince", tx, userID, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectGamePlayCountByUserIDSince indicates an expected call of SelectGamePlayCountByUserIDSince.
+func (mr *MockGamePlayRepositoryInterfaceMockRecorder) SelectGamePlayCountByUserIDSince(tx, userID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectGamePlayCountByUserIDSince", reflect.TypeOf((*MockGamePlayRepositoryInterface)(nil).SelectGamePlayCountByUserIDSince), tx, userID, since)
+}
+
+// SelectGamePlayStatsByUserIDSince mocks base method.
+func (m *MockGamePlayRepositoryInterface) SelectGamePlayStatsByUserIDSince(userID string, since time.Time) (*model.GamePlayStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectGamePlayStatsByUserIDSince", userID, since)
+	ret0, _ := ret[0].(*model.GamePlayStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectGamePlayStatsByUserIDSince indicates an expected call of SelectGamePlayStatsByUserIDSince.
+func (mr *MockGamePlayRepositoryInterfaceMockRecorder) SelectGamePlayStatsByUserIDSince(userID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectGamePlayStatsByUserIDSince", reflect.TypeOf((*MockGamePlayRepositoryInterface)(nil).SelectGamePlayStatsByUserIDSince), userID, since)
+}
+
+// SelectGamePlaysByUserID mocks base method.
+func (m *MockGamePlayRepositoryInterface) SelectGamePlaysByUserID(userID string, limit, offset int) ([]*model.GamePlay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectGamePlaysByUserID", userID, limit, offset)
+	ret0, _ := ret[0].([]*model.GamePlay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectGamePlaysByUserID indicates an expected call of SelectGamePlaysByUserID.
+func (mr *MockGamePlayRepositoryInterfaceMockRecorder) SelectGamePlaysByUserID(userID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectGamePlaysByUserID", reflect.TypeOf((*MockGamePlayRepositoryInterface)(nil).SelectGamePlaysByUserID), userID, limit, offset)
+}
+
+// SelectRewardCoinSumByUserIDSince mocks base method.
+func (m *MockGamePlayRepositoryInterface) SelectRewardCoinSumByUserIDSince(tx *sql.Tx, userID string, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectRewardCoinSumByUserIDSince", tx, userID, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectRewardCoinSumByUserIDSince indicates an expected call of SelectRewardCoinSumByUserIDSince.
+func (mr *MockGamePlayRepositoryInterfaceMockRecorder) SelectRewardCoinSumByUserIDSince(tx, userID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectRewardCoinSumByUserIDSince", reflect.TypeOf((*MockGamePlayRepositoryInterface)(nil).SelectRewardCoinSumByUserIDSince), tx, userID, since)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: game_session.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	model "20dojo-online/pkg/server/model"
+	sql "database/sql"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGameSessionRepositoryInterface is a mock of GameSessionRepositoryInterface interface.
+type MockGameSessionRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockGameSessionRepositoryInterfaceMockRecorder
+}
+
+// MockGameSessionRepositoryInterfaceMockRecorder is the mock recorder for MockGameSessionRepositoryInterface.
+type MockGameSessionRepositoryInterfaceMockRecorder struct {
+	mock *MockGameSessionRepositoryInterface
+}
+
+// NewMockGameSessionRepositoryInterface creates a new mock instance.
+func NewMockGameSessionRepositoryInterface(ctrl *gomock.Controller) *MockGameSessionRepositoryInterface {
+	mock := &MockGameSessionRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockGameSessionRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGameSessionRepositoryInterface) EXPECT() *MockGameSessionRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// InsertGameSession mocks base method.
+func (m *MockGameSessionRepositoryInterface) InsertGameSession(record *model.GameSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertGameSession", record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertGameSession indicates an expected call of InsertGameSession.
+func (mr *MockGameSessionRepositoryInterfaceMockRecorder) InsertGameSession(record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertGameSession", reflect.TypeOf((*MockGameSessionRepositoryInterface)(nil).InsertGameSession), record)
+}
+
+// UpdateGameSessionFinishedAt mocks base method.
+func (m *MockGameSessionRepositoryInterface) UpdateGameSessionFinishedAt(tx *sql.Tx, sessionID, userID string, finishedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGameSessionFinishedAt", tx, sessionID, userID, finishedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGameSessionFinishedAt indicates an expected call of UpdateGameSessionFinishedAt.
+func (mr *MockGameSessionRepositoryInterfaceMockRecorder) UpdateGameSessionFinishedAt(tx, sessionID, userID, finishedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGameSessionFinishedAt", reflect.TypeOf((*MockGameSessionRepositoryInterface)(nil).UpdateGameSessionFinishedAt), tx, sessionID, userID, finishedAt)
+}
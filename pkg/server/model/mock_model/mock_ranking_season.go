@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ranking_season.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	model "20dojo-online/pkg/server/model"
+	sql "database/sql"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRankingSeasonRepositoryInterface is a mock of RankingSeasonRepositoryInterface interface.
+type MockRankingSeasonRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRankingSeasonRepositoryInterfaceMockRecorder
+}
+
+// MockRankingSeasonRepositoryInterfaceMockRecorder is the mock recorder for MockRankingSeasonRepositoryInterface.
+type MockRankingSeasonRepositoryInterfaceMockRecorder struct {
+	mock *MockRankingSeasonRepositoryInterface
+}
+
+// NewMockRankingSeasonRepositoryInterface creates a new mock instance.
+func NewMockRankingSeasonRepositoryInterface(ctrl *gomock.Controller) *MockRankingSeasonRepositoryInterface {
+	mock := &MockRankingSeasonRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockRankingSeasonRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRankingSeasonRepositoryInterface) EXPECT() *MockRankingSeasonRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// InsertRankingSeason mocks base method.
+func (m *MockRankingSeasonRepositoryInterface) InsertRankingSeason(tx *sql.Tx, record *model.RankingSeason) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertRankingSeason", tx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertRankingSeason indicates an expected call of InsertRankingSeason.
+func (mr *MockRankingSeasonRepositoryInterfaceMockRecorder) InsertRankingSeason(tx, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertRankingSeason", reflect.TypeOf((*MockRankingSeasonRepositoryInterface)(nil).InsertRankingSeason), tx, record)
+}
+
+// SelectRankingSeasonByPrimaryKey mocks base method.
+func (m *MockRankingSeasonRepositoryInterface) SelectRankingSeasonByPrimaryKey(tx *sql.Tx, period string, startAt time.Time) (*model.RankingSeason, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectRankingSeasonByPrimaryKey", tx, period, startAt)
+	ret0, _ := ret[0].(*model.RankingSeason)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectRankingSeasonByPrimaryKey indicates an expected call of SelectRankingSeasonByPrimaryKey.
+func (mr *MockRankingSeasonRepositoryInterfaceMockRecorder) SelectRankingSeasonByPrimaryKey(tx, period, startAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectRankingSeasonByPrimaryKey", reflect.TypeOf((*MockRankingSeasonRepositoryInterface)(nil).SelectRankingSeasonByPrimaryKey), tx, period, startAt)
+}
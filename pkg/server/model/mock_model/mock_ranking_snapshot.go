@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ranking_snapshot.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	model "20dojo-online/pkg/server/model"
+	sql "database/sql"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRankingSnapshotRepositoryInterface is a mock of RankingSnapshotRepositoryInterface interface.
+type MockRankingSnapshotRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRankingSnapshotRepositoryInterfaceMockRecorder
+}
+
+// MockRankingSnapshotRepositoryInterfaceMockRecorder is the mock recorder for MockRankingSnapshotRepositoryInterface.
+type MockRankingSnapshotRepositoryInterfaceMockRecorder struct {
+	mock *MockRankingSnapshotRepositoryInterface
+}
+
+// NewMockRankingSnapshotRepositoryInterface creates a new mock instance.
+func NewMockRankingSnapshotRepositoryInterface(ctrl *gomock.Controller) *MockRankingSnapshotRepositoryInterface {
+	mock := &MockRankingSnapshotRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockRankingSnapshotRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRankingSnapshotRepositoryInterface) EXPECT() *MockRankingSnapshotRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// BulkInsertRankingSnapshot mocks base method.
+func (m *MockRankingSnapshotRepositoryInterface) BulkInsertRankingSnapshot(tx *sql.Tx, records []*model.RankingSnapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkInsertRankingSnapshot", tx, records)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BulkInsertRankingSnapshot indicates an expected call of BulkInsertRankingSnapshot.
+func (mr *MockRankingSnapshotRepositoryInterfaceMockRecorder) BulkInsertRankingSnapshot(tx, records interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkInsertRankingSnapshot", reflect.TypeOf((*MockRankingSnapshotRepositoryInterface)(nil).BulkInsertRankingSnapshot), tx, records)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reward_event.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	model "20dojo-online/pkg/server/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRewardEventRepositoryInterface is a mock of RewardEventRepositoryInterface interface.
+type MockRewardEventRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRewardEventRepositoryInterfaceMockRecorder
+}
+
+// MockRewardEventRepositoryInterfaceMockRecorder is the mock recorder for MockRewardEventRepositoryInterface.
+type MockRewardEventRepositoryInterfaceMockRecorder struct {
+	mock *MockRewardEventRepositoryInterface
+}
+
+// NewMockRewardEventRepositoryInterface creates a new mock instance.
+func NewMockRewardEventRepositoryInterface(ctrl *gomock.Controller) *MockRewardEventRepositoryInterface {
+	mock := &MockRewardEventRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockRewardEventRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRewardEventRepositoryInterface) EXPECT() *MockRewardEventRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// SelectRewardEventsInSession mocks base method.
+func (m *MockRewardEventRepositoryInterface) SelectRewardEventsInSession(now time.Time) ([]*model.RewardEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectRewardEventsInSession", now)
+	ret0, _ := ret[0].([]*model.RewardEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectRewardEventsInSession indicates an expected call of SelectRewardEventsInSession.
+func (mr *MockRewardEventRepositoryInterfaceMockRecorder) SelectRewardEventsInSession(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectRewardEventsInSession", reflect.TypeOf((*MockRewardEventRepositoryInterface)(nil).SelectRewardEventsInSession), now)
+}
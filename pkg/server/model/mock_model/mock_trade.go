@@ -0,0 +1,154 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: trade.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	model "20dojo-online/pkg/server/model"
+	sql "database/sql"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTradeRepositoryInterface is a mock of TradeRepositoryInterface interface.
+type MockTradeRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTradeRepositoryInterfaceMockRecorder
+}
+
+// MockTradeRepositoryInterfaceMockRecorder is the mock recorder for MockTradeRepositoryInterface.
+type MockTradeRepositoryInterfaceMockRecorder struct {
+	mock *MockTradeRepositoryInterface
+}
+
+// NewMockTradeRepositoryInterface creates a new mock instance.
+func NewMockTradeRepositoryInterface(ctrl *gomock.Controller) *MockTradeRepositoryInterface {
+	mock := &MockTradeRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockTradeRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTradeRepositoryInterface) EXPECT() *MockTradeRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// BulkInsertTradeItem mocks base method.
+func (m *MockTradeRepositoryInterface) BulkInsertTradeItem(tx *sql.Tx, tradeItemSlice []*model.TradeItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkInsertTradeItem", tx, tradeItemSlice)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BulkInsertTradeItem indicates an expected call of BulkInsertTradeItem.
+func (mr *MockTradeRepositoryInterfaceMockRecorder) BulkInsertTradeItem(tx, tradeItemSlice interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkInsertTradeItem", reflect.TypeOf((*MockTradeRepositoryInterface)(nil).BulkInsertTradeItem), tx, tradeItemSlice)
+}
+
+// InsertTrade mocks base method.
+func (m *MockTradeRepositoryInterface) InsertTrade(tx *sql.Tx, record *model.Trade) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertTrade", tx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertTrade indicates an expected call of InsertTrade.
+func (mr *MockTradeRepositoryInterfaceMockRecorder) InsertTrade(tx, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertTrade", reflect.TypeOf((*MockTradeRepositoryInterface)(nil).InsertTrade), tx, record)
+}
+
+// SelectPendingTradeCountByProposerUserID mocks base method.
+func (m *MockTradeRepositoryInterface) SelectPendingTradeCountByProposerUserID(tx *sql.Tx, proposerUserID string, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectPendingTradeCountByProposerUserID", tx, proposerUserID, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectPendingTradeCountByProposerUserID indicates an expected call of SelectPendingTradeCountByProposerUserID.
+func (mr *MockTradeRepositoryInterfaceMockRecorder) SelectPendingTradeCountByProposerUserID(tx, proposerUserID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectPendingTradeCountByProposerUserID", reflect.TypeOf((*MockTradeRepositoryInterface)(nil).SelectPendingTradeCountByProposerUserID), tx, proposerUserID, now)
+}
+
+// SelectPendingTradesByUserID mocks base method.
+func (m *MockTradeRepositoryInterface) SelectPendingTradesByUserID(userID string, now time.Time) ([]*model.Trade, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectPendingTradesByUserID", userID, now)
+	ret0, _ := ret[0].([]*model.Trade)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectPendingTradesByUserID indicates an expected call of SelectPendingTradesByUserID.
+func (mr *MockTradeRepositoryInterfaceMockRecorder) SelectPendingTradesByUserID(userID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectPendingTradesByUserID", reflect.TypeOf((*MockTradeRepositoryInterface)(nil).SelectPendingTradesByUserID), userID, now)
+}
+
+// SelectTradeByPrimaryKey mocks base method.
+func (m *MockTradeRepositoryInterface) SelectTradeByPrimaryKey(id string) (*model.Trade, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectTradeByPrimaryKey", id)
+	ret0, _ := ret[0].(*model.Trade)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectTradeByPrimaryKey indicates an expected call of SelectTradeByPrimaryKey.
+func (mr *MockTradeRepositoryInterfaceMockRecorder) SelectTradeByPrimaryKey(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectTradeByPrimaryKey", reflect.TypeOf((*MockTradeRepositoryInterface)(nil).SelectTradeByPrimaryKey), id)
+}
+
+// SelectTradeByPrimaryKeyForUpdate mocks base method.
+func (m *MockTradeRepositoryInterface) SelectTradeByPrimaryKeyForUpdate(tx *sql.Tx, id string) (*model.Trade, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectTradeByPrimaryKeyForUpdate", tx, id)
+	ret0, _ := ret[0].(*model.Trade)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectTradeByPrimaryKeyForUpdate indicates an expected call of SelectTradeByPrimaryKeyForUpdate.
+func (mr *MockTradeRepositoryInterfaceMockRecorder) SelectTradeByPrimaryKeyForUpdate(tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectTradeByPrimaryKeyForUpdate", reflect.TypeOf((*MockTradeRepositoryInterface)(nil).SelectTradeByPrimaryKeyForUpdate), tx, id)
+}
+
+// SelectTradeItemsByTradeIDs mocks base method.
+func (m *MockTradeRepositoryInterface) SelectTradeItemsByTradeIDs(tradeIDs []string) ([]*model.TradeItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectTradeItemsByTradeIDs", tradeIDs)
+	ret0, _ := ret[0].([]*model.TradeItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectTradeItemsByTradeIDs indicates an expected call of SelectTradeItemsByTradeIDs.
+func (mr *MockTradeRepositoryInterfaceMockRecorder) SelectTradeItemsByTradeIDs(tradeIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectTradeItemsByTradeIDs", reflect.TypeOf((*MockTradeRepositoryInterface)(nil).SelectTradeItemsByTradeIDs), tradeIDs)
+}
+
+// UpdateTradeStatusByPrimaryKey mocks base method.
+func (m *MockTradeRepositoryInterface) UpdateTradeStatusByPrimaryKey(tx *sql.Tx, id, status string, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTradeStatusByPrimaryKey", tx, id, status, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTradeStatusByPrimaryKey indicates an expected call of UpdateTradeStatusByPrimaryKey.
+func (mr *MockTradeRepositoryInterfaceMockRecorder) UpdateTradeStatusByPrimaryKey(tx, id, status, updatedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTradeStatusByPrimaryKey", reflect.TypeOf((*MockTradeRepositoryInterface)(nil).UpdateTradeStatusByPrimaryKey), tx, id, status, updatedAt)
+}
@@ -8,6 +8,7 @@ import (
 	model "20dojo-online/pkg/server/model"
 	sql "database/sql"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -94,19 +95,34 @@ func (mr *MockUserRepositoryInterfaceMockRecorder) SelectUserByPrimaryKeyForUpda
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectUserByPrimaryKeyForUpdate", reflect.TypeOf((*MockUserRepositoryInterface)(nil).SelectUserByPrimaryKeyForUpdate), tx, userID)
 }
 
-// SelectUsersOrderByHighScoreDesc mocks base method.
-func (m *MockUserRepositoryInterface) SelectUsersOrderByHighScoreDesc(limit, offset int) ([]*model.User, error) {
+// SelectUsersAll mocks base method.
+func (m *MockUserRepositoryInterface) SelectUsersAll() ([]*model.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SelectUsersOrderByHighScoreDesc", limit, offset)
+	ret := m.ctrl.Call(m, "SelectUsersAll")
 	ret0, _ := ret[0].([]*model.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SelectUsersOrderByHighScoreDesc indicates an expected call of SelectUsersOrderByHighScoreDesc.
-func (mr *MockUserRepositoryInterfaceMockRecorder) SelectUsersOrderByHighScoreDesc(limit, offset interface{}) *gomock.Call {
+// SelectUsersAll indicates an expected call of SelectUsersAll.
+func (mr *MockUserRepositoryInterfaceMockRecorder) SelectUsersAll() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectUsersOrderByHighScoreDesc", reflect.TypeOf((*MockUserRepositoryInterface)(nil).SelectUsersOrderByHighScoreDesc), limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectUsersAll", reflect.TypeOf((*MockUserRepositoryInterface)(nil).SelectUsersAll))
+}
+
+// SelectUsersByPrimaryKeys mocks base method.
+func (m *MockUserRepositoryInterface) SelectUsersByPrimaryKeys(userIDs []string) ([]*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectUsersByPrimaryKeys", userIDs)
+	ret0, _ := ret[0].([]*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectUsersByPrimaryKeys indicates an expected call of SelectUsersByPrimaryKeys.
+func (mr *MockUserRepositoryInterfaceMockRecorder) SelectUsersByPrimaryKeys(userIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectUsersByPrimaryKeys", reflect.TypeOf((*MockUserRepositoryInterface)(nil).SelectUsersByPrimaryKeys), userIDs)
 }
 
 // UpdateUserByPrimaryKey mocks base method.
@@ -124,17 +140,31 @@ func (mr *MockUserRepositoryInterfaceMockRecorder) UpdateUserByPrimaryKey(record
 }
 
 // UpdateUserCoinAndHighScoreByPrimaryKey mocks base method.
-func (m *MockUserRepositoryInterface) UpdateUserCoinAndHighScoreByPrimaryKey(id string, coin, highScore int) error {
+func (m *MockUserRepositoryInterface) UpdateUserCoinAndHighScoreByPrimaryKey(tx *sql.Tx, id string, coin, highScore int, highScoreUpdatedAt time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUserCoinAndHighScoreByPrimaryKey", id, coin, highScore)
+	ret := m.ctrl.Call(m, "UpdateUserCoinAndHighScoreByPrimaryKey", tx, id, coin, highScore, highScoreUpdatedAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateUserCoinAndHighScoreByPrimaryKey indicates an expected call of UpdateUserCoinAndHighScoreByPrimaryKey.
-func (mr *MockUserRepositoryInterfaceMockRecorder) UpdateUserCoinAndHighScoreByPrimaryKey(id, coin, highScore interface{}) *gomock.Call {
+func (mr *MockUserRepositoryInterfaceMockRecorder) UpdateUserCoinAndHighScoreByPrimaryKey(tx, id, coin, highScore, highScoreUpdatedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserCoinAndHighScoreByPrimaryKey", reflect.TypeOf((*MockUserRepositoryInterface)(nil).UpdateUserCoinAndHighScoreByPrimaryKey), tx, id, coin, highScore, highScoreUpdatedAt)
+}
+
+// UpdateUserCoinAndShardByPrimaryKey mocks base method.
+func (m *MockUserRepositoryInterface) UpdateUserCoinAndShardByPrimaryKey(tx *sql.Tx, userID string, coin, shard int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserCoinAndShardByPrimaryKey", tx, userID, coin, shard)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserCoinAndShardByPrimaryKey indicates an expected call of UpdateUserCoinAndShardByPrimaryKey.
+func (mr *MockUserRepositoryInterfaceMockRecorder) UpdateUserCoinAndShardByPrimaryKey(tx, userID, coin, shard interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserCoinAndHighScoreByPrimaryKey", reflect.TypeOf((*MockUserRepositoryInterface)(nil).UpdateUserCoinAndHighScoreByPrimaryKey), id, coin, highScore)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserCoinAndShardByPrimaryKey", reflect.TypeOf((*MockUserRepositoryInterface)(nil).UpdateUserCoinAndShardByPrimaryKey), tx, userID, coin, shard)
 }
 
 // UpdateUserCoinByPrimaryKey mocks base method.
@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_collection_item.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	model "20dojo-online/pkg/server/model"
+	sql "database/sql"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserCollectionItemRepositoryInterface is a mock of UserCollectionItemRepositoryInterface interface.
+type MockUserCollectionItemRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserCollectionItemRepositoryInterfaceMockRecorder
+}
+
+// MockUserCollectionItemRepositoryInterfaceMockRecorder is the mock recorder for MockUserCollectionItemRepositoryInterface.
+type MockUserCollectionItemRepositoryInterfaceMockRecorder struct {
+	mock *MockUserCollectionItemRepositoryInterface
+}
+
+// NewMockUserCollectionItemRepositoryInterface creates a new mock instance.
+func NewMockUserCollectionItemRepositoryInterface(ctrl *gomock.Controller) *MockUserCollectionItemRepositoryInterface {
+	mock := &MockUserCollectionItemRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockUserCollectionItemRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserCollectionItemRepositoryInterface) EXPECT() *MockUserCollectionItemRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// BulkUpsertUserCollectionItem mocks base method.
+func (m *MockUserCollectionItemRepositoryInterface) BulkUpsertUserCollectionItem(tx *sql.Tx, userCollectionItemSlice []*model.UserCollectionItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpsertUserCollectionItem", tx, userCollectionItemSlice)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BulkUpsertUserCollectionItem indicates an expected call of BulkUpsertUserCollectionItem.
+func (mr *MockUserCollectionItemRepositoryInterfaceMockRecorder) BulkUpsertUserCollectionItem(tx, userCollectionItemSlice interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpsertUserCollectionItem", reflect.TypeOf((*MockUserCollectionItemRepositoryInterface)(nil).BulkUpsertUserCollectionItem), tx, userCollectionItemSlice)
+}
+
+// DeleteUserCollectionItemsByPrimaryKeys mocks base method.
+func (m *MockUserCollectionItemRepositoryInterface) DeleteUserCollectionItemsByPrimaryKeys(tx *sql.Tx, userID string, collectionItemIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserCollectionItemsByPrimaryKeys", tx, userID, collectionItemIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserCollectionItemsByPrimaryKeys indicates an expected call of DeleteUserCollectionItemsByPrimaryKeys.
+func (mr *MockUserCollectionItemRepositoryInterfaceMockRecorder) DeleteUserCollectionItemsByPrimaryKeys(tx, userID, collectionItemIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserCollectionItemsByPrimaryKeys", reflect.TypeOf((*MockUserCollectionItemRepositoryInterface)(nil).DeleteUserCollectionItemsByPrimaryKeys), tx, userID, collectionItemIDs)
+}
+
+// SelectUserCollectionItemsByUserID mocks base method.
+func (m *MockUserCollectionItemRepositoryInterface) SelectUserCollectionItemsByUserID(userID string) ([]*model.UserCollectionItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectUserCollectionItemsByUserID", userID)
+	ret0, _ := ret[0].([]*model.UserCollectionItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectUserCollectionItemsByUserID indicates an expected call of SelectUserCollectionItemsByUserID.
+func (mr *MockUserCollectionItemRepositoryInterfaceMockRecorder) SelectUserCollectionItemsByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectUserCollectionItemsByUserID", reflect.TypeOf((*MockUserCollectionItemRepositoryInterface)(nil).SelectUserCollectionItemsByUserID), userID)
+}
+
+// SelectUserCollectionItemsByUserIDForUpdate mocks base method.
+func (m *MockUserCollectionItemRepositoryInterface) SelectUserCollectionItemsByUserIDForUpdate(tx *sql.Tx, userID string) ([]*model.UserCollectionItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectUserCollectionItemsByUserIDForUpdate", tx, userID)
+	ret0, _ := ret[0].([]*model.UserCollectionItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectUserCollectionItemsByUserIDForUpdate indicates an expected call of SelectUserCollectionItemsByUserIDForUpdate.
+func (mr *MockUserCollectionItemRepositoryInterfaceMockRecorder) SelectUserCollectionItemsByUserIDForUpdate(tx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectUserCollectionItemsByUserIDForUpdate", reflect.TypeOf((*MockUserCollectionItemRepositoryInterface)(nil).SelectUserCollectionItemsByUserIDForUpdate), tx, userID)
+}
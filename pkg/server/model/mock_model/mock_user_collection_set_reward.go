@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_collection_set_reward.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	model "20dojo-online/pkg/server/model"
+	sql "database/sql"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserCollectionSetRewardRepositoryInterface is a mock of UserCollectionSetRewardRepositoryInterface interface.
+type MockUserCollectionSetRewardRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserCollectionSetRewardRepositoryInterfaceMockRecorder
+}
+
+// MockUserCollectionSetRewardRepositoryInterfaceMockRecorder is the mock recorder for MockUserCollectionSetRewardRepositoryInterface.
+type MockUserCollectionSetRewardRepositoryInterfaceMockRecorder struct {
+	mock *MockUserCollectionSetRewardRepositoryInterface
+}
+
+// NewMockUserCollectionSetRewardRepositoryInterface creates a new mock instance.
+func NewMockUserCollectionSetRewardRepositoryInterface(ctrl *gomock.Controller) *MockUserCollectionSetRewardRepositoryInterface {
+	mock := &MockUserCollectionSetRewardRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockUserCollectionSetRewardRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserCollectionSetRewardRepositoryInterface) EXPECT() *MockUserCollectionSetRewardRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// InsertUserCollectionSetReward mocks base method.
+func (m *MockUserCollectionSetRewardRepositoryInterface) InsertUserCollectionSetReward(tx *sql.Tx, record *model.UserCollectionSetReward) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertUserCollectionSetReward", tx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertUserCollectionSetReward indicates an expected call of InsertUserCollectionSetReward.
+func (mr *MockUserCollectionSetRewardRepositoryInterfaceMockRecorder) InsertUserCollectionSetReward(tx, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUserCollectionSetReward", reflect.TypeOf((*MockUserCollectionSetRewardRepositoryInterface)(nil).InsertUserCollectionSetReward), tx, record)
+}
+
+// SelectUserCollectionSetRewardByPrimaryKey mocks base method.
+func (m *MockUserCollectionSetRewardRepositoryInterface) SelectUserCollectionSetRewardByPrimaryKey(tx *sql.Tx, userID, collectionSetID string) (*model.UserCollectionSetReward, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectUserCollectionSetRewardByPrimaryKey", tx, userID, collectionSetID)
+	ret0, _ := ret[0].(*model.UserCollectionSetReward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectUserCollectionSetRewardByPrimaryKey indicates an expected call of SelectUserCollectionSetRewardByPrimaryKey.
+func (mr *MockUserCollectionSetRewardRepositoryInterfaceMockRecorder) SelectUserCollectionSetRewardByPrimaryKey(tx, userID, collectionSetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectUserCollectionSetRewardByPrimaryKey", reflect.TypeOf((*MockUserCollectionSetRewardRepositoryInterface)(nil).SelectUserCollectionSetRewardByPrimaryKey), tx, userID, collectionSetID)
+}
+
+// SelectUserCollectionSetRewardsByUserID mocks base method.
+func (m *MockUserCollectionSetRewardRepositoryInterface) SelectUserCollectionSetRewardsByUserID(userID string) ([]*model.UserCollectionSetReward, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectUserCollectionSetRewardsByUserID", userID)
+	ret0, _ := ret[0].([]*model.UserCollectionSetReward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectUserCollectionSetRewardsByUserID indicates an expected call of SelectUserCollectionSetRewardsByUserID.
+func (mr *MockUserCollectionSetRewardRepositoryInterfaceMockRecorder) SelectUserCollectionSetRewardsByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectUserCollectionSetRewardsByUserID", reflect.TypeOf((*MockUserCollectionSetRewardRepositoryInterface)(nil).SelectUserCollectionSetRewardsByUserID), userID)
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_gacha_box_item.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	model "20dojo-online/pkg/server/model"
+	sql "database/sql"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserGachaBoxItemRepositoryInterface is a mock of UserGachaBoxItemRepositoryInterface interface.
+type MockUserGachaBoxItemRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserGachaBoxItemRepositoryInterfaceMockRecorder
+}
+
+// MockUserGachaBoxItemRepositoryInterfaceMockRecorder is the mock recorder for MockUserGachaBoxItemRepositoryInterface.
+type MockUserGachaBoxItemRepositoryInterfaceMockRecorder struct {
+	mock *MockUserGachaBoxItemRepositoryInterface
+}
+
+// NewMockUserGachaBoxItemRepositoryInterface creates a new mock instance.
+func NewMockUserGachaBoxItemRepositoryInterface(ctrl *gomock.Controller) *MockUserGachaBoxItemRepositoryInterface {
+	mock := &MockUserGachaBoxItemRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockUserGachaBoxItemRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserGachaBoxItemRepositoryInterface) EXPECT() *MockUserGachaBoxItemRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// BulkUpsertUserGachaBoxItem mocks base method.
+func (m *MockUserGachaBoxItemRepositoryInterface) BulkUpsertUserGachaBoxItem(tx *sql.Tx, userGachaBoxItemSlice []*model.UserGachaBoxItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpsertUserGachaBoxItem", tx, userGachaBoxItemSlice)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BulkUpsertUserGachaBoxItem indicates an expected call of BulkUpsertUserGachaBoxItem.
+func (mr *MockUserGachaBoxItemRepositoryInterfaceMockRecorder) BulkUpsertUserGachaBoxItem(tx, userGachaBoxItemSlice interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpsertUserGachaBoxItem", reflect.TypeOf((*MockUserGachaBoxItemRepositoryInterface)(nil).BulkUpsertUserGachaBoxItem), tx, userGachaBoxItemSlice)
+}
+
+// DeleteUserGachaBoxItemsByUserIDAndGachaID mocks base method.
+func (m *MockUserGachaBoxItemRepositoryInterface) DeleteUserGachaBoxItemsByUserIDAndGachaID(userID, gachaID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserGachaBoxItemsByUserIDAndGachaID", userID, gachaID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserGachaBoxItemsByUserIDAndGachaID indicates an expected call of DeleteUserGachaBoxItemsByUserIDAndGachaID.
+func (mr *MockUserGachaBoxItemRepositoryInterfaceMockRecorder) DeleteUserGachaBoxItemsByUserIDAndGachaID(userID, gachaID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserGachaBoxItemsByUserIDAndGachaID", reflect.TypeOf((*MockUserGachaBoxItemRepositoryInterface)(nil).DeleteUserGachaBoxItemsByUserIDAndGachaID), userID, gachaID)
+}
+
+// SelectUserGachaBoxItemsByUserIDAndGachaIDForUpdate mocks base method.
+func (m *MockUserGachaBoxItemRepositoryInterface) SelectUserGachaBoxItemsByUserIDAndGachaIDForUpdate(tx *sql.Tx, userID, gachaID string) ([]*model.UserGachaBoxItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectUserGachaBoxItemsByUserIDAndGachaIDForUpdate", tx, userID, gachaID)
+	ret0, _ := ret[0].([]*model.UserGachaBoxItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectUserGachaBoxItemsByUserIDAndGachaIDForUpdate indicates an expected call of SelectUserGachaBoxItemsByUserIDAndGachaIDForUpdate.
+func (mr *MockUserGachaBoxItemRepositoryInterfaceMockRecorder) SelectUserGachaBoxItemsByUserIDAndGachaIDForUpdate(tx, userID, gachaID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectUserGachaBoxItemsByUserIDAndGachaIDForUpdate", reflect.TypeOf((*MockUserGachaBoxItemRepositoryInterface)(nil).SelectUserGachaBoxItemsByUserIDAndGachaIDForUpdate), tx, userID, gachaID)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_gacha_free_draw.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	model "20dojo-online/pkg/server/model"
+	sql "database/sql"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserGachaFreeDrawRepositoryInterface is a mock of UserGachaFreeDrawRepositoryInterface interface.
+type MockUserGachaFreeDrawRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserGachaFreeDrawRepositoryInterfaceMockRecorder
+}
+
+// MockUserGachaFreeDrawRepositoryInterfaceMockRecorder is the mock recorder for MockUserGachaFreeDrawRepositoryInterface.
+type MockUserGachaFreeDrawRepositoryInterfaceMockRecorder struct {
+	mock *MockUserGachaFreeDrawRepositoryInterface
+}
+
+// NewMockUserGachaFreeDrawRepositoryInterface creates a new mock instance.
+func NewMockUserGachaFreeDrawRepositoryInterface(ctrl *gomock.Controller) *MockUserGachaFreeDrawRepositoryInterface {
+	mock := &MockUserGachaFreeDrawRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockUserGachaFreeDrawRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserGachaFreeDrawRepositoryInterface) EXPECT() *MockUserGachaFreeDrawRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// SelectUserGachaFreeDrawByPrimaryKeyForUpdate mocks base method.
+func (m *MockUserGachaFreeDrawRepositoryInterface) SelectUserGachaFreeDrawByPrimaryKeyForUpdate(tx *sql.Tx, userID string) (*model.UserGachaFreeDraw, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectUserGachaFreeDrawByPrimaryKeyForUpdate", tx, userID)
+	ret0, _ := ret[0].(*model.UserGachaFreeDraw)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectUserGachaFreeDrawByPrimaryKeyForUpdate indicates an expected call of SelectUserGachaFreeDrawByPrimaryKeyForUpdate.
+func (mr *MockUserGachaFreeDrawRepositoryInterfaceMockRecorder) SelectUserGachaFreeDrawByPrimaryKeyForUpdate(tx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectUserGachaFreeDrawByPrimaryKeyForUpdate", reflect.TypeOf((*MockUserGachaFreeDrawRepositoryInterface)(nil).SelectUserGachaFreeDrawByPrimaryKeyForUpdate), tx, userID)
+}
+
+// UpsertUserGachaFreeDraw mocks base method.
+func (m *MockUserGachaFreeDrawRepositoryInterface) UpsertUserGachaFreeDraw(tx *sql.Tx, record *model.UserGachaFreeDraw) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertUserGachaFreeDraw", tx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertUserGachaFreeDraw indicates an expected call of UpsertUserGachaFreeDraw.
+func (mr *MockUserGachaFreeDrawRepositoryInterfaceMockRecorder) UpsertUserGachaFreeDraw(tx, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserGachaFreeDraw", reflect.TypeOf((*MockUserGachaFreeDrawRepositoryInterface)(nil).UpsertUserGachaFreeDraw), tx, record)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_gacha_pity.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	model "20dojo-online/pkg/server/model"
+	sql "database/sql"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserGachaPityRepositoryInterface is a mock of UserGachaPityRepositoryInterface interface.
+type MockUserGachaPityRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserGachaPityRepositoryInterfaceMockRecorder
+}
+
+// MockUserGachaPityRepositoryInterfaceMockRecorder is the mock recorder for MockUserGachaPityRepositoryInterface.
+type MockUserGachaPityRepositoryInterfaceMockRecorder struct {
+	mock *MockUserGachaPityRepositoryInterface
+}
+
+// NewMockUserGachaPityRepositoryInterface creates a new mock instance.
+func NewMockUserGachaPityRepositoryInterface(ctrl *gomock.Controller) *MockUserGachaPityRepositoryInterface {
+	mock := &MockUserGachaPityRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockUserGachaPityRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserGachaPityRepositoryInterface) EXPECT() *MockUserGachaPityRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// SelectUserGachaPityByPrimaryKeyForUpdate mocks base method.
+func (m *MockUserGachaPityRepositoryInterface) SelectUserGachaPityByPrimaryKeyForUpdate(tx *sql.Tx, userID, gachaID string) (*model.UserGachaPity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectUserGachaPityByPrimaryKeyForUpdate", tx, userID, gachaID)
+	ret0, _ := ret[0].(*model.UserGachaPity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectUserGachaPityByPrimaryKeyForUpdate indicates an expected call of SelectUserGachaPityByPrimaryKeyForUpdate.
+func (mr *MockUserGachaPityRepositoryInterfaceMockRecorder) SelectUserGachaPityByPrimaryKeyForUpdate(tx, userID, gachaID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectUserGachaPityByPrimaryKeyForUpdate", reflect.TypeOf((*MockUserGachaPityRepositoryInterface)(nil).SelectUserGachaPityByPrimaryKeyForUpdate), tx, userID, gachaID)
+}
+
+// UpsertUserGachaPity mocks base method.
+func (m *MockUserGachaPityRepositoryInterface) UpsertUserGachaPity(tx *sql.Tx, record *model.UserGachaPity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertUserGachaPity", tx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertUserGachaPity indicates an expected call of UpsertUserGachaPity.
+func (mr *MockUserGachaPityRepositoryInterfaceMockRecorder) UpsertUserGachaPity(tx, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserGachaPity", reflect.TypeOf((*MockUserGachaPityRepositoryInterface)(nil).UpsertUserGachaPity), tx, record)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_gacha_step.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	model "20dojo-online/pkg/server/model"
+	sql "database/sql"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserGachaStepRepositoryInterface is a mock of UserGachaStepRepositoryInterface interface.
+type MockUserGachaStepRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserGachaStepRepositoryInterfaceMockRecorder
+}
+
+// MockUserGachaStepRepositoryInterfaceMockRecorder is the mock recorder for MockUserGachaStepRepositoryInterface.
+type MockUserGachaStepRepositoryInterfaceMockRecorder struct {
+	mock *MockUserGachaStepRepositoryInterface
+}
+
+// NewMockUserGachaStepRepositoryInterface creates a new mock instance.
+func NewMockUserGachaStepRepositoryInterface(ctrl *gomock.Controller) *MockUserGachaStepRepositoryInterface {
+	mock := &MockUserGachaStepRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockUserGachaStepRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserGachaStepRepositoryInterface) EXPECT() *MockUserGachaStepRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// SelectUserGachaStepByPrimaryKeyForUpdate mocks base method.
+func (m *MockUserGachaStepRepositoryInterface) SelectUserGachaStepByPrimaryKeyForUpdate(tx *sql.Tx, userID, gachaID string) (*model.UserGachaStep, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectUserGachaStepByPrimaryKeyForUpdate", tx, userID, gachaID)
+	ret0, _ := ret[0].(*model.UserGachaStep)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectUserGachaStepByPrimaryKeyForUpdate indicates an expected call of SelectUserGachaStepByPrimaryKeyForUpdate.
+func (mr *MockUserGachaStepRepositoryInterfaceMockRecorder) SelectUserGachaStepByPrimaryKeyForUpdate(tx, userID, gachaID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectUserGachaStepByPrimaryKeyForUpdate", reflect.TypeOf((*MockUserGachaStepRepositoryInterface)(nil).SelectUserGachaStepByPrimaryKeyForUpdate), tx, userID, gachaID)
+}
+
+// UpsertUserGachaStep mocks base method.
+func (m *MockUserGachaStepRepositoryInterface) UpsertUserGachaStep(tx *sql.Tx, record *model.UserGachaStep) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertUserGachaStep", tx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertUserGachaStep indicates an expected call of UpsertUserGachaStep.
+func (mr *MockUserGachaStepRepositoryInterfaceMockRecorder) UpsertUserGachaStep(tx, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserGachaStep", reflect.TypeOf((*MockUserGachaStepRepositoryInterface)(nil).UpsertUserGachaStep), tx, record)
+}
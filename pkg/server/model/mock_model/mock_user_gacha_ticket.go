@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_gacha_ticket.go
+
+// Package mock_model is a generated GoMock package.
+package mock_model
+
+import (
+	model "20dojo-online/pkg/server/model"
+	sql "database/sql"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserGachaTicketRepositoryInterface is a mock of UserGachaTicketRepositoryInterface interface.
+type MockUserGachaTicketRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserGachaTicketRepositoryInterfaceMockRecorder
+}
+
+// MockUserGachaTicketRepositoryInterfaceMockRecorder is the mock recorder for MockUserGachaTicketRepositoryInterface.
+type MockUserGachaTicketRepositoryInterfaceMockRecorder struct {
+	mock *MockUserGachaTicketRepositoryInterface
+}
+
+// NewMockUserGachaTicketRepositoryInterface creates a new mock instance.
+func NewMockUserGachaTicketRepositoryInterface(ctrl *gomock.Controller) *MockUserGachaTicketRepositoryInterface {
+	mock := &MockUserGachaTicketRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockUserGachaTicketRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserGachaTicketRepositoryInterface) EXPECT() *MockUserGachaTicketRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// SelectUserGachaTicketByPrimaryKeyForUpdate mocks base method.
+func (m *MockUserGachaTicketRepositoryInterface) SelectUserGachaTicketByPrimaryKeyForUpdate(tx *sql.Tx, userID string) (*model.UserGachaTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectUserGachaTicketByPrimaryKeyForUpdate", tx, userID)
+	ret0, _ := ret[0].(*model.UserGachaTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectUserGachaTicketByPrimaryKeyForUpdate indicates an expected call of SelectUserGachaTicketByPrimaryKeyForUpdate.
+func (mr *MockUserGachaTicketRepositoryInterfaceMockRecorder) SelectUserGachaTicketByPrimaryKeyForUpdate(tx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectUserGachaTicketByPrimaryKeyForUpdate", reflect.TypeOf((*MockUserGachaTicketRepositoryInterface)(nil).SelectUserGachaTicketByPrimaryKeyForUpdate), tx, userID)
+}
+
+// UpsertUserGachaTicket mocks base method.
+func (m *MockUserGachaTicketRepositoryInterface) UpsertUserGachaTicket(tx *sql.Tx, record *model.UserGachaTicket) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertUserGachaTicket", tx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertUserGachaTicket indicates an expected call of UpsertUserGachaTicket.
+func (mr *MockUserGachaTicketRepositoryInterfaceMockRecorder) UpsertUserGachaTicket(tx, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserGachaTicket", reflect.TypeOf((*MockUserGachaTicketRepositoryInterface)(nil).UpsertUserGachaTicket), tx, record)
+}
@@ -0,0 +1,64 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=./mock_$GOPACKAGE/mock_$GOFILE
+
+package model
+
+import (
+	"database/sql"
+	"log"
+	"time"
+)
+
+// RankingSeason ranking_seasonテーブルデータ
+type RankingSeason struct {
+	Period   string
+	StartAt  time.Time
+	EndAt    time.Time
+	ClosedAt time.Time
+}
+
+type RankingSeasonRepository struct {
+	Conn *sql.DB
+}
+
+func NewRankingSeasonRepository(conn *sql.DB) *RankingSeasonRepository {
+	return &RankingSeasonRepository{
+		Conn: conn,
+	}
+}
+
+type RankingSeasonRepositoryInterface interface {
+	SelectRankingSeasonByPrimaryKey(tx *sql.Tx, period string, startAt time.Time) (*RankingSeason, error)
+	InsertRankingSeason(tx *sql.Tx, record *RankingSeason) error
+}
+
+var _ RankingSeasonRepositoryInterface = (*RankingSeasonRepository)(nil)
+
+// SelectRankingSeasonByPrimaryKey 主キーを条件に締め処理済みの期間を取得する
+func (r *RankingSeasonRepository) SelectRankingSeasonByPrimaryKey(tx *sql.Tx, period string, startAt time.Time) (*RankingSeason, error) {
+	row := tx.QueryRow("SELECT * FROM ranking_season WHERE period = ? AND start_at = ?", period, startAt)
+	return convertToRankingSeason(row)
+}
+
+// InsertRankingSeason 締め処理済みの期間を登録する
+func (r *RankingSeasonRepository) InsertRankingSeason(tx *sql.Tx, record *RankingSeason) error {
+	stmt, err := tx.Prepare("INSERT INTO ranking_season(period, start_at, end_at, closed_at) VALUES(?, ?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec(record.Period, record.StartAt, record.EndAt, record.ClosedAt)
+	return err
+}
+
+// convertToRankingSeason rowデータをRankingSeasonデータへ変換する
+func convertToRankingSeason(row *sql.Row) (*RankingSeason, error) {
+	rankingSeason := RankingSeason{}
+	err := row.Scan(&rankingSeason.Period, &rankingSeason.StartAt, &rankingSeason.EndAt, &rankingSeason.ClosedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return &rankingSeason, nil
+}
@@ -0,0 +1,59 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=./mock_$GOPACKAGE/mock_$GOFILE
+
+package model
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RankingSnapshot ranking_snapshotテーブルデータ
+type RankingSnapshot struct {
+	Period     string
+	StartAt    time.Time
+	UserID     string
+	Rank       int
+	Score      int
+	RewardCoin int
+}
+
+type RankingSnapshotRepository struct {
+	Conn *sql.DB
+}
+
+func NewRankingSnapshotRepository(conn *sql.DB) *RankingSnapshotRepository {
+	return &RankingSnapshotRepository{
+		Conn: conn,
+	}
+}
+
+type RankingSnapshotRepositoryInterface interface {
+	BulkInsertRankingSnapshot(tx *sql.Tx, records []*RankingSnapshot) error
+}
+
+var _ RankingSnapshotRepositoryInterface = (*RankingSnapshotRepository)(nil)
+
+// BulkInsertRankingSnapshot 締め時点の上位ユーザをまとめて登録する
+func (r *RankingSnapshotRepository) BulkInsertRankingSnapshot(tx *sql.Tx, records []*RankingSnapshot) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	placeholder := make([]string, 0, len(records))
+	queryArgs := make([]interface{}, 0, len(records)*6)
+	for _, record := range records {
+		placeholder = append(placeholder, "(?, ?, ?, ?, ?, ?)")
+		queryArgs = append(queryArgs, record.Period, record.StartAt, record.UserID, record.Rank, record.Score, record.RewardCoin)
+	}
+
+	query := fmt.Sprintf("INSERT INTO ranking_snapshot (period, start_at, user_id, `rank`, score, reward_coin) VALUES %s", strings.Join(placeholder, ", "))
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(queryArgs...)
+	return err
+}
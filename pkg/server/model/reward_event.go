@@ -0,0 +1,72 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=./mock_$GOPACKAGE/mock_$GOFILE
+
+package model
+
+import (
+	"database/sql"
+	"log"
+	"time"
+)
+
+// RewardEvent reward_eventテーブルデータ
+type RewardEvent struct {
+	ID         string
+	Name       string
+	Multiplier float64
+	StartAt    time.Time
+	EndAt      time.Time
+}
+
+type RewardEventRepository struct {
+	Conn *sql.DB
+}
+
+func NewRewardEventRepository(conn *sql.DB) *RewardEventRepository {
+	return &RewardEventRepository{
+		Conn: conn,
+	}
+}
+
+type RewardEventRepositoryInterface interface {
+	SelectRewardEventsInSession(now time.Time) ([]*RewardEvent, error)
+}
+
+var _ RewardEventRepositoryInterface = (*RewardEventRepository)(nil)
+
+// SelectRewardEventsInSession 指定日時に開催中の報酬イベントを取得する
+func (r *RewardEventRepository) SelectRewardEventsInSession(now time.Time) ([]*RewardEvent, error) {
+	stmt, err := r.Conn.Prepare("SELECT * FROM reward_event WHERE start_at <= ? AND ? < end_at ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := stmt.Query(now, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertToRewardEvents(rows)
+}
+
+// convertToRewardEvents rowsデータをRewardEventのスライスへ変換する
+func convertToRewardEvents(rows *sql.Rows) ([]*RewardEvent, error) {
+	defer rows.Close()
+
+	var (
+		rewardEvents []*RewardEvent
+		err          error
+	)
+
+	for rows.Next() {
+		rewardEvent := RewardEvent{}
+		if err = rows.Scan(&rewardEvent.ID, &rewardEvent.Name, &rewardEvent.Multiplier, &rewardEvent.StartAt, &rewardEvent.EndAt); err != nil {
+			if err == sql.ErrNoRows {
+				return nil, nil
+			}
+			log.Println(err)
+			return nil, err
+		}
+		rewardEvents = append(rewardEvents, &rewardEvent)
+	}
+	return rewardEvents, err
+}
@@ -0,0 +1,190 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=./mock_$GOPACKAGE/mock_$GOFILE
+
+package model
+
+import (
+	"20dojo-online/pkg/constant"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// Trade tradeテーブルデータ
+type Trade struct {
+	ID             string
+	ProposerUserID string
+	TargetUserID   string
+	OfferCoin      int
+	RequestCoin    int
+	Status         string
+	ExpiresAt      time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// TradeItem trade_itemテーブルデータ
+type TradeItem struct {
+	TradeID          string
+	UserID           string // アイテムを渡すユーザID
+	CollectionItemID string
+	Count            int
+}
+
+type TradeRepository struct {
+	Conn *sql.DB
+}
+
+func NewTradeRepository(conn *sql.DB) *TradeRepository {
+	return &TradeRepository{
+		Conn: conn,
+	}
+}
+
+type TradeRepositoryInterface interface {
+	SelectTradeByPrimaryKey(id string) (*Trade, error)
+	SelectTradeByPrimaryKeyForUpdate(tx *sql.Tx, id string) (*Trade, error)
+	SelectPendingTradesByUserID(userID string, now time.Time) ([]*Trade, error)
+	SelectPendingTradeCountByProposerUserID(tx *sql.Tx, proposerUserID string, now time.Time) (int, error)
+	InsertTrade(tx *sql.Tx, record *Trade) error
+	UpdateTradeStatusByPrimaryKey(tx *sql.Tx, id string, status string, updatedAt time.Time) error
+	SelectTradeItemsByTradeIDs(tradeIDs []string) ([]*TradeItem, error)
+	BulkInsertTradeItem(tx *sql.Tx, tradeItemSlice []*TradeItem) error
+}
+
+var _ TradeRepositoryInterface = (*TradeRepository)(nil)
+
+// SelectTradeByPrimaryKey 主キーを条件にトレードを取得する
+func (r *TradeRepository) SelectTradeByPrimaryKey(id string) (*Trade, error) {
+	row := r.Conn.QueryRow("SELECT * FROM trade WHERE id = ?", id)
+	return convertToTrade(row)
+}
+
+// SelectTradeByPrimaryKeyForUpdate 主キーを条件にトレードを排他ロックで取得する
+func (r *TradeRepository) SelectTradeByPrimaryKeyForUpdate(tx *sql.Tx, id string) (*Trade, error) {
+	row := tx.QueryRow("SELECT * FROM trade WHERE id = ? FOR UPDATE", id)
+	return convertToTrade(row)
+}
+
+// SelectPendingTradesByUserID ユーザが提案した、またはユーザに提案された有効期限内の承認待ちのトレードを提案日時の新しい順に取得する
+func (r *TradeRepository) SelectPendingTradesByUserID(userID string, now time.Time) ([]*Trade, error) {
+	rows, err := r.Conn.Query("SELECT * FROM trade WHERE (proposer_user_id = ? OR target_user_id = ?) AND status = ? AND expires_at > ? ORDER BY created_at DESC, id ASC",
+		userID, userID, constant.TradeStatusPending, now)
+	if err != nil {
+		return nil, err
+	}
+	return convertToTrades(rows)
+}
+
+// SelectPendingTradeCountByProposerUserID ユーザが提案した有効期限内の承認待ちのトレード数を取得する
+func (r *TradeRepository) SelectPendingTradeCountByProposerUserID(tx *sql.Tx, proposerUserID string, now time.Time) (int, error) {
+	var count int
+	err := tx.QueryRow("SELECT COUNT(*) FROM trade WHERE proposer_user_id = ? AND status = ? AND expires_at > ?", proposerUserID, constant.TradeStatusPending, now).Scan(&count)
+	return count, err
+}
+
+// InsertTrade トレードを登録する
+func (r *TradeRepository) InsertTrade(tx *sql.Tx, record *Trade) error {
+	stmt, err := tx.Prepare("INSERT INTO trade(id, proposer_user_id, target_user_id, offer_coin, request_coin, status, expires_at, created_at, updated_at) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec(record.ID, record.ProposerUserID, record.TargetUserID, record.OfferCoin, record.RequestCoin,
+		record.Status, record.ExpiresAt, record.CreatedAt, record.UpdatedAt)
+	return err
+}
+
+// UpdateTradeStatusByPrimaryKey 主キーを条件にトレードの状態を更新する
+func (r *TradeRepository) UpdateTradeStatusByPrimaryKey(tx *sql.Tx, id string, status string, updatedAt time.Time) error {
+	stmt, err := tx.Prepare("UPDATE trade SET status = ?, updated_at = ? WHERE id = ?")
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec(status, updatedAt, id)
+	return err
+}
+
+// SelectTradeItemsByTradeIDs トレードIDを条件に受け渡すアイテムを取得する
+func (r *TradeRepository) SelectTradeItemsByTradeIDs(tradeIDs []string) ([]*TradeItem, error) {
+	if len(tradeIDs) == 0 {
+		return nil, nil
+	}
+	placeholder := make([]string, 0, len(tradeIDs))
+	queryArgs := make([]interface{}, 0, len(tradeIDs))
+	for _, tradeID := range tradeIDs {
+		placeholder = append(placeholder, "?")
+		queryArgs = append(queryArgs, tradeID)
+	}
+	rows, err := r.Conn.Query(fmt.Sprintf("SELECT * FROM trade_item WHERE trade_id IN (%s) ORDER BY collection_item_id ASC", strings.Join(placeholder, ", ")), queryArgs...)
+	if err != nil {
+		return nil, err
+	}
+	return convertToTradeItems(rows)
+}
+
+// BulkInsertTradeItem トレードで受け渡すアイテムを登録する
+func (r *TradeRepository) BulkInsertTradeItem(tx *sql.Tx, tradeItemSlice []*TradeItem) error {
+	placeholder := make([]string, 0, len(tradeItemSlice))
+	queryArgs := make([]interface{}, 0, len(tradeItemSlice)*4)
+	for _, tradeItem := range tradeItemSlice {
+		placeholder = append(placeholder, "(?, ?, ?, ?)")
+		queryArgs = append(queryArgs, tradeItem.TradeID, tradeItem.UserID, tradeItem.CollectionItemID, tradeItem.Count)
+	}
+
+	query := fmt.Sprintf("INSERT INTO trade_item (trade_id, user_id, collection_item_id, count) VALUES %s", strings.Join(placeholder, ", "))
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(queryArgs...)
+	return err
+}
+
+// convertToTrade rowデータをTradeデータへ変換する. 存在しない場合はnilを返す
+func convertToTrade(row *sql.Row) (*Trade, error) {
+	trade := Trade{}
+	if err := row.Scan(&trade.ID, &trade.ProposerUserID, &trade.TargetUserID, &trade.OfferCoin, &trade.RequestCoin,
+		&trade.Status, &trade.ExpiresAt, &trade.CreatedAt, &trade.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return &trade, nil
+}
+
+// convertToTrades rowsデータをTradeのスライスへ変換する
+func convertToTrades(rows *sql.Rows) ([]*Trade, error) {
+	defer rows.Close()
+
+	var trades []*Trade
+	for rows.Next() {
+		trade := Trade{}
+		if err := rows.Scan(&trade.ID, &trade.ProposerUserID, &trade.TargetUserID, &trade.OfferCoin, &trade.RequestCoin,
+			&trade.Status, &trade.ExpiresAt, &trade.CreatedAt, &trade.UpdatedAt); err != nil {
+			log.Println(err)
+			return nil, err
+		}
+		trades = append(trades, &trade)
+	}
+	return trades, rows.Err()
+}
+
+// convertToTradeItems rowsデータをTradeItemのスライスへ変換する
+func convertToTradeItems(rows *sql.Rows) ([]*TradeItem, error) {
+	defer rows.Close()
+
+	var tradeItems []*TradeItem
+	for rows.Next() {
+		tradeItem := TradeItem{}
+		if err := rows.Scan(&tradeItem.TradeID, &tradeItem.UserID, &tradeItem.CollectionItemID, &tradeItem.Count); err != nil {
+			log.Println(err)
+			return nil, err
+		}
+		tradeItems = append(tradeItems, &tradeItem)
+	}
+	return tradeItems, rows.Err()
+}
@@ -4,7 +4,10 @@ package model
 
 import (
 	"database/sql"
+	"fmt"
 	"log"
+	"strings"
+	"time"
 )
 
 // User userテーブルデータ
@@ -14,6 +17,9 @@ type User struct {
 	Name      string
 	HighScore int
 	Coin      int
+	Shard     int
+	// ハイスコアを達成した日時. 同点の場合は先に達成したユーザを上位とする
+	HighScoreUpdatedAt time.Time
 }
 
 type UserRepository struct {
@@ -31,10 +37,12 @@ type UserRepositoryInterface interface {
 	SelectUserByAuthToken(authToken string) (*User, error)
 	UpdateUserByPrimaryKey(record *User) error
 	SelectUserByPrimaryKey(userID string) (*User, error)
-	UpdateUserCoinAndHighScoreByPrimaryKey(id string, coin int, highScore int) error
-	SelectUsersOrderByHighScoreDesc(limit int, offset int) ([]*User, error)
+	UpdateUserCoinAndHighScoreByPrimaryKey(tx *sql.Tx, id string, coin int, highScore int, highScoreUpdatedAt time.Time) error
+	SelectUsersByPrimaryKeys(userIDs []string) ([]*User, error)
+	SelectUsersAll() ([]*User, error)
 	UpdateUserCoinByPrimaryKey(tx *sql.Tx, userID string, coin int) error
 	SelectUserByPrimaryKeyForUpdate(tx *sql.Tx, userID string) (*User, error)
+	UpdateUserCoinAndShardByPrimaryKey(tx *sql.Tx, userID string, coin int, shard int) error
 }
 
 // インターフェースを満たしているかを確認
@@ -42,11 +50,11 @@ var _ UserRepositoryInterface = (*UserRepository)(nil)
 
 // InsertUser データベースをレコードを登録する
 func (r *UserRepository) InsertUser(record *User) error {
-	stmt, err := r.Conn.Prepare("INSERT INTO user(id, auth_token, name, high_score, coin) VALUES(?, ?, ?, ?, ?)")
+	stmt, err := r.Conn.Prepare("INSERT INTO user(id, auth_token, name, high_score, coin, shard) VALUES(?, ?, ?, ?, ?, ?)")
 	if err != nil {
 		return err
 	}
-	_, err = stmt.Exec(record.ID, record.AuthToken, record.Name, record.HighScore, record.Coin)
+	_, err = stmt.Exec(record.ID, record.AuthToken, record.Name, record.HighScore, record.Coin, record.Shard)
 	return err
 }
 
@@ -72,29 +80,42 @@ func (r *UserRepository) UpdateUserByPrimaryKey(record *User) error {
 	return err
 }
 
-// UpdateUserCoinAndHighScoreByPrimaryKey 主キーを条件に所持コインとハイスコアを更新する
-func (r *UserRepository) UpdateUserCoinAndHighScoreByPrimaryKey(id string, coin int, highScore int) error {
-	stmt, err := r.Conn.Prepare("Update user SET coin = ?, high_score = ? where id = ?")
+// UpdateUserCoinAndHighScoreByPrimaryKey 主キーを条件に所持コインとハイスコア・ハイスコア達成日時を更新する
+func (r *UserRepository) UpdateUserCoinAndHighScoreByPrimaryKey(tx *sql.Tx, id string, coin int, highScore int, highScoreUpdatedAt time.Time) error {
+	stmt, err := tx.Prepare("Update user SET coin = ?, high_score = ?, high_score_updated_at = ? where id = ?")
 	if err != nil {
 		return err
 	}
-	_, err = stmt.Exec(coin, highScore, id)
+	_, err = stmt.Exec(coin, highScore, highScoreUpdatedAt, id)
 
 	return err
 }
 
-// SelectUsersOrderByHighScoreDesc ハイスコア順に指定順位から指定件数を取得する
-func (r *UserRepository) SelectUsersOrderByHighScoreDesc(limit int, offset int) ([]*User, error) {
-	stmt, err := r.Conn.Prepare("SELECT * FROM user ORDER BY high_score DESC LIMIT ? OFFSET ?")
+// SelectUsersByPrimaryKeys 主キーのいずれかに一致するレコードを取得する
+func (r *UserRepository) SelectUsersByPrimaryKeys(userIDs []string) ([]*User, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	placeholder := make([]string, 0, len(userIDs))
+	queryArgs := make([]interface{}, 0, len(userIDs))
+	for _, userID := range userIDs {
+		placeholder = append(placeholder, "?")
+		queryArgs = append(queryArgs, userID)
+	}
+
+	rows, err := r.Conn.Query(fmt.Sprintf("SELECT * FROM user WHERE id IN (%s)", strings.Join(placeholder, ", ")), queryArgs...)
 	if err != nil {
 		return nil, err
 	}
+	return convertToUsers(rows)
+}
 
-	rows, err := stmt.Query(limit, offset-1)
+// SelectUsersAll 全てのレコードを取得する
+func (r *UserRepository) SelectUsersAll() ([]*User, error) {
+	rows, err := r.Conn.Query("SELECT * FROM user")
 	if err != nil {
 		return nil, err
 	}
-
 	return convertToUsers(rows)
 }
 
@@ -109,6 +130,17 @@ func (r *UserRepository) UpdateUserCoinByPrimaryKey(tx *sql.Tx, userID string, c
 	return err
 }
 
+// UpdateUserCoinAndShardByPrimaryKey 主キーを条件にコインとシャードを更新する
+func (r *UserRepository) UpdateUserCoinAndShardByPrimaryKey(tx *sql.Tx, userID string, coin int, shard int) error {
+	stmt, err := tx.Prepare("UPDATE user SET coin = ?, shard = ? where id = ?")
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(coin, shard, userID)
+	return err
+}
+
 // SelectUserByPrimaryKeyForUpdate 主キーを条件に排他ロックでユーザ情報を取得する
 func (r *UserRepository) SelectUserByPrimaryKeyForUpdate(tx *sql.Tx, userID string) (*User, error) {
 	row := tx.QueryRow("SELECT * from user WHERE id = ? FOR UPDATE", userID)
@@ -118,7 +150,7 @@ func (r *UserRepository) SelectUserByPrimaryKeyForUpdate(tx *sql.Tx, userID stri
 // convertToUser rowデータをUserデータへ変換する
 func convertToUser(row *sql.Row) (*User, error) {
 	user := User{}
-	err := row.Scan(&user.ID, &user.AuthToken, &user.Name, &user.HighScore, &user.Coin)
+	err := row.Scan(&user.ID, &user.AuthToken, &user.Name, &user.HighScore, &user.Coin, &user.Shard, &user.HighScoreUpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -140,7 +172,7 @@ func convertToUsers(rows *sql.Rows) ([]*User, error) {
 
 	for rows.Next() {
 		user := User{}
-		if err = rows.Scan(&user.ID, &user.AuthToken, &user.Name, &user.HighScore, &user.Coin); err != nil {
+		if err = rows.Scan(&user.ID, &user.AuthToken, &user.Name, &user.HighScore, &user.Coin, &user.Shard, &user.HighScoreUpdatedAt); err != nil {
 			if err == sql.ErrNoRows {
 				return nil, nil
 			}
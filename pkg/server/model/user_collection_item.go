@@ -1,3 +1,5 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=./mock_$GOPACKAGE/mock_$GOFILE
+
 package model
 
 import (
@@ -5,12 +7,17 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 )
 
 // UserCollectionItem user_collection_itemテーブルデータ
 type UserCollectionItem struct {
 	UserID           string
 	CollectionItemID string
+	Count            int
+	Level            int
+	FirstAcquiredAt  time.Time
+	LastAcquiredAt   time.Time
 }
 
 type UserCollectionItemRepository struct {
@@ -25,7 +32,9 @@ func NewUserCollectionItemRepository(conn *sql.DB) *UserCollectionItemRepository
 
 type UserCollectionItemRepositoryInterface interface {
 	SelectUserCollectionItemsByUserID(userID string) ([]*UserCollectionItem, error)
-	BulkInsertUserCollectionItem(tx *sql.Tx, newCollectionItemSlice []*UserCollectionItem) error
+	SelectUserCollectionItemsByUserIDForUpdate(tx *sql.Tx, userID string) ([]*UserCollectionItem, error)
+	BulkUpsertUserCollectionItem(tx *sql.Tx, userCollectionItemSlice []*UserCollectionItem) error
+	DeleteUserCollectionItemsByPrimaryKeys(tx *sql.Tx, userID string, collectionItemIDs []string) error
 }
 
 var _ UserCollectionItemRepositoryInterface = (*UserCollectionItemRepository)(nil)
@@ -45,27 +54,54 @@ func (r *UserCollectionItemRepository) SelectUserCollectionItemsByUserID(userID
 	return convertToUserCollectionItems(rows)
 }
 
-// BulkInsertUserCollectionItem Newアイテムを登録する
-func (r *UserCollectionItemRepository) BulkInsertUserCollectionItem(tx *sql.Tx, newCollectionItemSlice []*UserCollectionItem) error {
+// SelectUserCollectionItemsByUserIDForUpdate ユーザIDを条件に所持アイテムを排他ロックで取得する
+func (r *UserCollectionItemRepository) SelectUserCollectionItemsByUserIDForUpdate(tx *sql.Tx, userID string) ([]*UserCollectionItem, error) {
+	rows, err := tx.Query("SELECT * FROM user_collection_item WHERE user_id = ? FOR UPDATE", userID)
+	if err != nil {
+		return nil, err
+	}
+	return convertToUserCollectionItems(rows)
+}
+
+// BulkUpsertUserCollectionItem 獲得したアイテムを登録する. 所持済みのアイテムは獲得数・レベル・最終獲得日時を更新する
+func (r *UserCollectionItemRepository) BulkUpsertUserCollectionItem(tx *sql.Tx, userCollectionItemSlice []*UserCollectionItem) error {
 
-	placeholder := make([]string, 0, len(newCollectionItemSlice))
-	queryArgs := make([]interface{}, 0, len(newCollectionItemSlice)*2)
-	for _, newCollectionItem := range newCollectionItemSlice {
-		placeholder = append(placeholder, "(?, ?)")
-		queryArgs = append(queryArgs, newCollectionItem.UserID, &newCollectionItem.CollectionItemID)
+	placeholder := make([]string, 0, len(userCollectionItemSlice))
+	queryArgs := make([]interface{}, 0, len(userCollectionItemSlice)*6)
+	for _, userCollectionItem := range userCollectionItemSlice {
+		placeholder = append(placeholder, "(?, ?, ?, ?, ?, ?)")
+		queryArgs = append(queryArgs, userCollectionItem.UserID, userCollectionItem.CollectionItemID, userCollectionItem.Count,
+			userCollectionItem.Level, userCollectionItem.FirstAcquiredAt, userCollectionItem.LastAcquiredAt)
 	}
 
-	query := fmt.Sprintf("INSERT INTO user_collection_item (user_id, collection_item_id) VALUES %s", strings.Join(placeholder, ", "))
+	query := fmt.Sprintf("INSERT INTO user_collection_item (user_id, collection_item_id, count, level, first_acquired_at, last_acquired_at) VALUES %s "+
+		"ON DUPLICATE KEY UPDATE count = VALUES(count), level = VALUES(level), last_acquired_at = VALUES(last_acquired_at)", strings.Join(placeholder, ", "))
 	stmt, err := tx.Prepare(query)
 	if err != nil {
 		return err
 	}
 
 	_, err = stmt.Exec(queryArgs...)
+	return err
+}
+
+// DeleteUserCollectionItemsByPrimaryKeys ユーザIDとコレクションアイテムIDを条件に所持アイテムを削除する(トレードで全て渡した場合)
+func (r *UserCollectionItemRepository) DeleteUserCollectionItemsByPrimaryKeys(tx *sql.Tx, userID string, collectionItemIDs []string) error {
+	placeholder := make([]string, 0, len(collectionItemIDs))
+	queryArgs := make([]interface{}, 0, len(collectionItemIDs)+1)
+	queryArgs = append(queryArgs, userID)
+	for _, collectionItemID := range collectionItemIDs {
+		placeholder = append(placeholder, "?")
+		queryArgs = append(queryArgs, collectionItemID)
+	}
+
+	query := fmt.Sprintf("DELETE FROM user_collection_item WHERE user_id = ? AND collection_item_id IN (%s)", strings.Join(placeholder, ", "))
+	stmt, err := tx.Prepare(query)
 	if err != nil {
 		return err
 	}
 
+	_, err = stmt.Exec(queryArgs...)
 	return err
 }
 
@@ -80,7 +116,8 @@ func convertToUserCollectionItems(rows *sql.Rows) ([]*UserCollectionItem, error)
 
 	for rows.Next() {
 		userCollectionItem := UserCollectionItem{}
-		if err = rows.Scan(&userCollectionItem.UserID, &userCollectionItem.CollectionItemID); err != nil {
+		if err = rows.Scan(&userCollectionItem.UserID, &userCollectionItem.CollectionItemID, &userCollectionItem.Count,
+			&userCollectionItem.Level, &userCollectionItem.FirstAcquiredAt, &userCollectionItem.LastAcquiredAt); err != nil {
 			if err == sql.ErrNoRows {
 				return nil, nil
 			}
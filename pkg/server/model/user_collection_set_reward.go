@@ -0,0 +1,82 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=./mock_$GOPACKAGE/mock_$GOFILE
+
+package model
+
+import (
+	"database/sql"
+	"log"
+	"time"
+)
+
+// UserCollectionSetReward user_collection_set_rewardテーブルデータ
+type UserCollectionSetReward struct {
+	UserID          string
+	CollectionSetID string
+	RewardCoin      int
+	RewardTicket    int
+	ClaimedAt       time.Time
+}
+
+type UserCollectionSetRewardRepository struct {
+	Conn *sql.DB
+}
+
+func NewUserCollectionSetRewardRepository(conn *sql.DB) *UserCollectionSetRewardRepository {
+	return &UserCollectionSetRewardRepository{
+		Conn: conn,
+	}
+}
+
+type UserCollectionSetRewardRepositoryInterface interface {
+	SelectUserCollectionSetRewardsByUserID(userID string) ([]*UserCollectionSetReward, error)
+	SelectUserCollectionSetRewardByPrimaryKey(tx *sql.Tx, userID string, collectionSetID string) (*UserCollectionSetReward, error)
+	InsertUserCollectionSetReward(tx *sql.Tx, record *UserCollectionSetReward) error
+}
+
+var _ UserCollectionSetRewardRepositoryInterface = (*UserCollectionSetRewardRepository)(nil)
+
+// SelectUserCollectionSetRewardsByUserID ユーザIDを条件にコンプリート報酬の受け取り記録を取得する
+func (r *UserCollectionSetRewardRepository) SelectUserCollectionSetRewardsByUserID(userID string) ([]*UserCollectionSetReward, error) {
+	rows, err := r.Conn.Query("SELECT * FROM user_collection_set_reward WHERE user_id = ?", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userCollectionSetRewards []*UserCollectionSetReward
+	for rows.Next() {
+		userCollectionSetReward := UserCollectionSetReward{}
+		if err := rows.Scan(&userCollectionSetReward.UserID, &userCollectionSetReward.CollectionSetID, &userCollectionSetReward.RewardCoin,
+			&userCollectionSetReward.RewardTicket, &userCollectionSetReward.ClaimedAt); err != nil {
+			log.Println(err)
+			return nil, err
+		}
+		userCollectionSetRewards = append(userCollectionSetRewards, &userCollectionSetReward)
+	}
+	return userCollectionSetRewards, rows.Err()
+}
+
+// SelectUserCollectionSetRewardByPrimaryKey 主キーを条件にコンプリート報酬の受け取り記録を取得する
+func (r *UserCollectionSetRewardRepository) SelectUserCollectionSetRewardByPrimaryKey(tx *sql.Tx, userID string, collectionSetID string) (*UserCollectionSetReward, error) {
+	row := tx.QueryRow("SELECT * FROM user_collection_set_reward WHERE user_id = ? AND collection_set_id = ?", userID, collectionSetID)
+	userCollectionSetReward := UserCollectionSetReward{}
+	if err := row.Scan(&userCollectionSetReward.UserID, &userCollectionSetReward.CollectionSetID, &userCollectionSetReward.RewardCoin,
+		&userCollectionSetReward.RewardTicket, &userCollectionSetReward.ClaimedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return &userCollectionSetReward, nil
+}
+
+// InsertUserCollectionSetReward コンプリート報酬の受け取りを記録する. 主キーの重複により同じセットの報酬は1回しか記録できない
+func (r *UserCollectionSetRewardRepository) InsertUserCollectionSetReward(tx *sql.Tx, record *UserCollectionSetReward) error {
+	stmt, err := tx.Prepare("INSERT INTO user_collection_set_reward(user_id, collection_set_id, reward_coin, reward_ticket, claimed_at) VALUES(?, ?, ?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec(record.UserID, record.CollectionSetID, record.RewardCoin, record.RewardTicket, record.ClaimedAt)
+	return err
+}
@@ -0,0 +1,104 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=./mock_$GOPACKAGE/mock_$GOFILE
+
+package model
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// UserGachaBoxItem user_gacha_box_itemテーブルデータ
+type UserGachaBoxItem struct {
+	UserID           string
+	GachaID          string
+	CollectionItemID string
+	DrawnCount       int
+}
+
+type UserGachaBoxItemRepository struct {
+	Conn *sql.DB
+}
+
+func NewUserGachaBoxItemRepository(conn *sql.DB) *UserGachaBoxItemRepository {
+	return &UserGachaBoxItemRepository{
+		Conn: conn,
+	}
+}
+
+type UserGachaBoxItemRepositoryInterface interface {
+	SelectUserGachaBoxItemsByUserIDAndGachaIDForUpdate(tx *sql.Tx, userID string, gachaID string) ([]*UserGachaBoxItem, error)
+	BulkUpsertUserGachaBoxItem(tx *sql.Tx, userGachaBoxItemSlice []*UserGachaBoxItem) error
+	DeleteUserGachaBoxItemsByUserIDAndGachaID(userID string, gachaID string) error
+}
+
+var _ UserGachaBoxItemRepositoryInterface = (*UserGachaBoxItemRepository)(nil)
+
+// SelectUserGachaBoxItemsByUserIDAndGachaIDForUpdate ユーザIDとガチャIDを条件に排他ロックでボックスの排出済み個数を取得する
+func (r *UserGachaBoxItemRepository) SelectUserGachaBoxItemsByUserIDAndGachaIDForUpdate(tx *sql.Tx, userID string, gachaID string) ([]*UserGachaBoxItem, error) {
+	stmt, err := tx.Prepare("SELECT * FROM user_gacha_box_item WHERE user_id = ? AND gacha_id = ? FOR UPDATE")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := stmt.Query(userID, gachaID)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertToUserGachaBoxItems(rows)
+}
+
+// BulkUpsertUserGachaBoxItem ボックスの排出済み個数を登録または更新する
+func (r *UserGachaBoxItemRepository) BulkUpsertUserGachaBoxItem(tx *sql.Tx, userGachaBoxItemSlice []*UserGachaBoxItem) error {
+
+	placeholder := make([]string, 0, len(userGachaBoxItemSlice))
+	queryArgs := make([]interface{}, 0, len(userGachaBoxItemSlice)*4)
+	for _, userGachaBoxItem := range userGachaBoxItemSlice {
+		placeholder = append(placeholder, "(?, ?, ?, ?)")
+		queryArgs = append(queryArgs, userGachaBoxItem.UserID, userGachaBoxItem.GachaID, userGachaBoxItem.CollectionItemID, userGachaBoxItem.DrawnCount)
+	}
+
+	query := fmt.Sprintf("INSERT INTO user_gacha_box_item (user_id, gacha_id, collection_item_id, drawn_count) VALUES %s ON DUPLICATE KEY UPDATE drawn_count = VALUES(drawn_count)", strings.Join(placeholder, ", "))
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(queryArgs...)
+	return err
+}
+
+// DeleteUserGachaBoxItemsByUserIDAndGachaID ユーザIDとガチャIDを条件にボックスの排出済み個数を削除する(ボックスのリセット)
+func (r *UserGachaBoxItemRepository) DeleteUserGachaBoxItemsByUserIDAndGachaID(userID string, gachaID string) error {
+	stmt, err := r.Conn.Prepare("DELETE FROM user_gacha_box_item WHERE user_id = ? AND gacha_id = ?")
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec(userID, gachaID)
+	return err
+}
+
+// convertToUserGachaBoxItems rowsデータをUserGachaBoxItemのスライスへ変換する
+func convertToUserGachaBoxItems(rows *sql.Rows) ([]*UserGachaBoxItem, error) {
+	defer rows.Close()
+
+	var (
+		userGachaBoxItems []*UserGachaBoxItem
+		err               error
+	)
+
+	for rows.Next() {
+		userGachaBoxItem := UserGachaBoxItem{}
+		if err = rows.Scan(&userGachaBoxItem.UserID, &userGachaBoxItem.GachaID, &userGachaBoxItem.CollectionItemID, &userGachaBoxItem.DrawnCount); err != nil {
+			if err == sql.ErrNoRows {
+				return nil, nil
+			}
+			log.Println(err)
+			return nil, err
+		}
+		userGachaBoxItems = append(userGachaBoxItems, &userGachaBoxItem)
+	}
+	return userGachaBoxItems, err
+}
@@ -0,0 +1,62 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=./mock_$GOPACKAGE/mock_$GOFILE
+
+package model
+
+import (
+	"database/sql"
+	"log"
+	"time"
+)
+
+// UserGachaFreeDraw user_gacha_free_drawテーブルデータ
+type UserGachaFreeDraw struct {
+	UserID     string
+	LastDrawAt time.Time
+}
+
+type UserGachaFreeDrawRepository struct {
+	Conn *sql.DB
+}
+
+func NewUserGachaFreeDrawRepository(conn *sql.DB) *UserGachaFreeDrawRepository {
+	return &UserGachaFreeDrawRepository{
+		Conn: conn,
+	}
+}
+
+type UserGachaFreeDrawRepositoryInterface interface {
+	SelectUserGachaFreeDrawByPrimaryKeyForUpdate(tx *sql.Tx, userID string) (*UserGachaFreeDraw, error)
+	UpsertUserGachaFreeDraw(tx *sql.Tx, record *UserGachaFreeDraw) error
+}
+
+var _ UserGachaFreeDrawRepositoryInterface = (*UserGachaFreeDrawRepository)(nil)
+
+// SelectUserGachaFreeDrawByPrimaryKeyForUpdate 主キーを条件に排他ロックで無料ガチャの最終実行日時を取得する
+func (r *UserGachaFreeDrawRepository) SelectUserGachaFreeDrawByPrimaryKeyForUpdate(tx *sql.Tx, userID string) (*UserGachaFreeDraw, error) {
+	row := tx.QueryRow("SELECT * FROM user_gacha_free_draw WHERE user_id = ? FOR UPDATE", userID)
+	return convertToUserGachaFreeDraw(row)
+}
+
+// UpsertUserGachaFreeDraw 無料ガチャの最終実行日時を登録または更新する
+func (r *UserGachaFreeDrawRepository) UpsertUserGachaFreeDraw(tx *sql.Tx, record *UserGachaFreeDraw) error {
+	stmt, err := tx.Prepare("INSERT INTO user_gacha_free_draw(user_id, last_draw_at) VALUES(?, ?) ON DUPLICATE KEY UPDATE last_draw_at = VALUES(last_draw_at)")
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec(record.UserID, record.LastDrawAt)
+	return err
+}
+
+// convertToUserGachaFreeDraw rowデータをUserGachaFreeDrawデータへ変換する
+func convertToUserGachaFreeDraw(row *sql.Row) (*UserGachaFreeDraw, error) {
+	userGachaFreeDraw := UserGachaFreeDraw{}
+	err := row.Scan(&userGachaFreeDraw.UserID, &userGachaFreeDraw.LastDrawAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return &userGachaFreeDraw, nil
+}
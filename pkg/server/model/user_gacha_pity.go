@@ -0,0 +1,62 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=./mock_$GOPACKAGE/mock_$GOFILE
+
+package model
+
+import (
+	"database/sql"
+	"log"
+)
+
+// UserGachaPity user_gacha_pityテーブルデータ
+type UserGachaPity struct {
+	UserID  string
+	GachaID string
+	Count   int
+}
+
+type UserGachaPityRepository struct {
+	Conn *sql.DB
+}
+
+func NewUserGachaPityRepository(conn *sql.DB) *UserGachaPityRepository {
+	return &UserGachaPityRepository{
+		Conn: conn,
+	}
+}
+
+type UserGachaPityRepositoryInterface interface {
+	SelectUserGachaPityByPrimaryKeyForUpdate(tx *sql.Tx, userID string, gachaID string) (*UserGachaPity, error)
+	UpsertUserGachaPity(tx *sql.Tx, record *UserGachaPity) error
+}
+
+var _ UserGachaPityRepositoryInterface = (*UserGachaPityRepository)(nil)
+
+// SelectUserGachaPityByPrimaryKeyForUpdate 主キーを条件に排他ロックで天井カウントを取得する
+func (r *UserGachaPityRepository) SelectUserGachaPityByPrimaryKeyForUpdate(tx *sql.Tx, userID string, gachaID string) (*UserGachaPity, error) {
+	row := tx.QueryRow("SELECT * FROM user_gacha_pity WHERE user_id = ? AND gacha_id = ? FOR UPDATE", userID, gachaID)
+	return convertToUserGachaPity(row)
+}
+
+// UpsertUserGachaPity 天井カウントを登録または更新する
+func (r *UserGachaPityRepository) UpsertUserGachaPity(tx *sql.Tx, record *UserGachaPity) error {
+	stmt, err := tx.Prepare("INSERT INTO user_gacha_pity(user_id, gacha_id, count) VALUES(?, ?, ?) ON DUPLICATE KEY UPDATE count = VALUES(count)")
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec(record.UserID, record.GachaID, record.Count)
+	return err
+}
+
+// convertToUserGachaPity rowデータをUserGachaPityデータへ変換する
+func convertToUserGachaPity(row *sql.Row) (*UserGachaPity, error) {
+	userGachaPity := UserGachaPity{}
+	err := row.Scan(&userGachaPity.UserID, &userGachaPity.GachaID, &userGachaPity.Count)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return &userGachaPity, nil
+}
@@ -0,0 +1,62 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=./mock_$GOPACKAGE/mock_$GOFILE
+
+package model
+
+import (
+	"database/sql"
+	"log"
+)
+
+// UserGachaStep user_gacha_stepテーブルデータ
+type UserGachaStep struct {
+	UserID  string
+	GachaID string
+	Step    int
+}
+
+type UserGachaStepRepository struct {
+	Conn *sql.DB
+}
+
+func NewUserGachaStepRepository(conn *sql.DB) *UserGachaStepRepository {
+	return &UserGachaStepRepository{
+		Conn: conn,
+	}
+}
+
+type UserGachaStepRepositoryInterface interface {
+	SelectUserGachaStepByPrimaryKeyForUpdate(tx *sql.Tx, userID string, gachaID string) (*UserGachaStep, error)
+	UpsertUserGachaStep(tx *sql.Tx, record *UserGachaStep) error
+}
+
+var _ UserGachaStepRepositoryInterface = (*UserGachaStepRepository)(nil)
+
+// SelectUserGachaStepByPrimaryKeyForUpdate 主キーを条件に排他ロックでステップアップガチャの進捗を取得する
+func (r *UserGachaStepRepository) SelectUserGachaStepByPrimaryKeyForUpdate(tx *sql.Tx, userID string, gachaID string) (*UserGachaStep, error) {
+	row := tx.QueryRow("SELECT * FROM user_gacha_step WHERE user_id = ? AND gacha_id = ? FOR UPDATE", userID, gachaID)
+	return convertToUserGachaStep(row)
+}
+
+// UpsertUserGachaStep ステップアップガチャの進捗を登録または更新する
+func (r *UserGachaStepRepository) UpsertUserGachaStep(tx *sql.Tx, record *UserGachaStep) error {
+	stmt, err := tx.Prepare("INSERT INTO user_gacha_step(user_id, gacha_id, step) VALUES(?, ?, ?) ON DUPLICATE KEY UPDATE step = VALUES(step)")
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec(record.UserID, record.GachaID, record.Step)
+	return err
+}
+
+// convertToUserGachaStep rowデータをUserGachaStepデータへ変換する
+func convertToUserGachaStep(row *sql.Row) (*UserGachaStep, error) {
+	userGachaStep := UserGachaStep{}
+	err := row.Scan(&userGachaStep.UserID, &userGachaStep.GachaID, &userGachaStep.Step)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return &userGachaStep, nil
+}
@@ -0,0 +1,61 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=./mock_$GOPACKAGE/mock_$GOFILE
+
+package model
+
+import (
+	"database/sql"
+	"log"
+)
+
+// UserGachaTicket user_gacha_ticketテーブルデータ
+type UserGachaTicket struct {
+	UserID string
+	Count  int
+}
+
+type UserGachaTicketRepository struct {
+	Conn *sql.DB
+}
+
+func NewUserGachaTicketRepository(conn *sql.DB) *UserGachaTicketRepository {
+	return &UserGachaTicketRepository{
+		Conn: conn,
+	}
+}
+
+type UserGachaTicketRepositoryInterface interface {
+	SelectUserGachaTicketByPrimaryKeyForUpdate(tx *sql.Tx, userID string) (*UserGachaTicket, error)
+	UpsertUserGachaTicket(tx *sql.Tx, record *UserGachaTicket) error
+}
+
+var _ UserGachaTicketRepositoryInterface = (*UserGachaTicketRepository)(nil)
+
+// SelectUserGachaTicketByPrimaryKeyForUpdate 主キーを条件に排他ロックでガチャチケットの所持数を取得する
+func (r *UserGachaTicketRepository) SelectUserGachaTicketByPrimaryKeyForUpdate(tx *sql.Tx, userID string) (*UserGachaTicket, error) {
+	row := tx.QueryRow("SELECT * FROM user_gacha_ticket WHERE user_id = ? FOR UPDATE", userID)
+	return convertToUserGachaTicket(row)
+}
+
+// UpsertUserGachaTicket ガチャチケットの所持数を登録または更新する
+func (r *UserGachaTicketRepository) UpsertUserGachaTicket(tx *sql.Tx, record *UserGachaTicket) error {
+	stmt, err := tx.Prepare("INSERT INTO user_gacha_ticket(user_id, count) VALUES(?, ?) ON DUPLICATE KEY UPDATE count = VALUES(count)")
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec(record.UserID, record.Count)
+	return err
+}
+
+// convertToUserGachaTicket rowデータをUserGachaTicketデータへ変換する
+func convertToUserGachaTicket(row *sql.Row) (*UserGachaTicket, error) {
+	userGachaTicket := UserGachaTicket{}
+	err := row.Scan(&userGachaTicket.UserID, &userGachaTicket.Count)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return &userGachaTicket, nil
+}
@@ -82,6 +82,58 @@ func TestRankingListIntegration(t *testing.T) {
 						}`,
 			},
 		},
+		{
+			name: "正常:同点は先にハイスコアを達成したユーザが上位",
+			before: func() {
+				// シードの作成
+				query := `INSERT INTO user(id, auth_token, name, high_score, coin, high_score_updated_at) VALUES ("id1", "token1", "name1", 1000, 10000000, "2020-01-02 00:00:00"), ("id2", "token2", "name2", 1000, 10000000, "2020-01-01 00:00:00"), ("id3", "token3", "name3", 100, 10000000, "2020-01-01 00:00:00")`
+				_, err := testUserRepository.Conn.Exec(query)
+				if err != nil {
+					t.Errorf("db.TestConn.Exec failed %s", err)
+					return
+				}
+			},
+			request: request{
+				method:  "GET",
+				pattern: "/test/ranking/list?start=1&mode=dense",
+				token:   "token1",
+			},
+			after: func(res *http.Response) {
+				// シードの削除
+				query := `DELETE FROM user WHERE id in ("id1", "id2", "id3")`
+				_, err := testUserRepository.Conn.Exec(query)
+				if err != nil {
+					t.Errorf("db.TestConn.Exec failed %s", err)
+					return
+				}
+				defer res.Body.Close()
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body: `{
+						  "ranks": [
+							{
+							  "userId": "id2",
+							  "userName": "name2",
+							  "rank": 1,
+							  "score": 1000
+							},
+							{
+							  "userId": "id1",
+							  "userName": "name1",
+							  "rank": 1,
+							  "score": 1000
+							},
+							{
+							  "userId": "id3",
+							  "userName": "name3",
+							  "rank": 2,
+							  "score": 100
+							}
+						  ]
+						}`,
+			},
+		},
 		{
 			name: "異常:無効なトークン",
 			before: func() {
@@ -155,7 +207,7 @@ func TestRankingListIntegration(t *testing.T) {
 			},
 			request: request{
 				method:  "GET",
-				pattern: "/test/ranking/list?notstart=1",
+				pattern: "/test/ranking/list?start=first",
 				token:   "token1",
 			},
 			after: func(res *http.Response) {
@@ -230,6 +282,11 @@ func TestRankingListIntegration(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.before() // シード作成
+			// シードをランキングへ反映
+			if err := testRankingService.RebuildLeaderboard(); err != nil {
+				t.Errorf("RebuildLeaderboard failed: %v", err)
+				return
+			}
 
 			// リクエスト
 			req, err := http.NewRequest(tt.request.method, server.URL+tt.request.pattern, nil)
@@ -4,11 +4,12 @@ import (
 	"20dojo-online/pkg/db"
 	"20dojo-online/pkg/http/middleware"
 	"20dojo-online/pkg/http/response"
+	"20dojo-online/pkg/leaderboard"
+	"20dojo-online/pkg/random"
 	"20dojo-online/pkg/server/service"
+	"20dojo-online/pkg/session"
 	"log"
-	"math/rand"
 	"net/http"
-	"time"
 
 	"20dojo-online/pkg/server/handler"
 	"20dojo-online/pkg/server/model"
@@ -20,14 +21,36 @@ var (
 	userRepository = model.NewUserRepository(db.Conn)
 	authMiddleware = middleware.NewMiddleware(httpResponse, userRepository)
 
-	gachaProbabilityRepository   = model.NewGachaRepositoryRepository(db.Conn)
-	userCollectionItemRepository = model.NewUserCollectionItemRepository(db.Conn)
-	collectionItemRepository     = model.NewCollectionItemRepository(db.Conn)
-
-	gameService       = service.NewGameService(userRepository)
-	gachaService      = service.NewGachaService(userRepository, gachaProbabilityRepository, userCollectionItemRepository, collectionItemRepository)
-	rankingService    = service.NewRankingService(userRepository)
-	collectionService = service.NewCollectionService(userCollectionItemRepository, collectionItemRepository)
+	gachaRepository                   = model.NewGachaRepository(db.Conn)
+	gachaProbabilityRepository        = model.NewGachaRepositoryRepository(db.Conn)
+	userCollectionItemRepository      = model.NewUserCollectionItemRepository(db.Conn)
+	collectionItemRepository          = model.NewCollectionItemRepository(db.Conn)
+	collectionSetRepository           = model.NewCollectionSetRepository(db.Conn)
+	userCollectionSetRewardRepository = model.NewUserCollectionSetRewardRepository(db.Conn)
+	userGachaPityRepository           = model.NewUserGachaPityRepository(db.Conn)
+	gachaDrawHistoryRepository        = model.NewGachaDrawHistoryRepository(db.Conn)
+	gachaStepRepository               = model.NewGachaStepRepository(db.Conn)
+	userGachaStepRepository           = model.NewUserGachaStepRepository(db.Conn)
+	userGachaBoxItemRepository        = model.NewUserGachaBoxItemRepository(db.Conn)
+	userGachaTicketRepository         = model.NewUserGachaTicketRepository(db.Conn)
+	userGachaFreeDrawRepository       = model.NewUserGachaFreeDrawRepository(db.Conn)
+	gameSessionRepository             = model.NewGameSessionRepository(db.Conn)
+	gamePlayRepository                = model.NewGamePlayRepository(db.Conn)
+	rewardEventRepository             = model.NewRewardEventRepository(db.Conn)
+	rankingSeasonRepository           = model.NewRankingSeasonRepository(db.Conn)
+	rankingSnapshotRepository         = model.NewRankingSnapshotRepository(db.Conn)
+	friendRepository                  = model.NewFriendRepository(db.Conn)
+	friendRequestRepository           = model.NewFriendRequestRepository(db.Conn)
+	tradeRepository                   = model.NewTradeRepository(db.Conn)
+
+	leaderboards = leaderboard.NewRedisFactory(leaderboard.NewRedisPool(leaderboard.RedisAddrFromEnv()))
+
+	gameService       = service.NewGameService(userRepository, gameSessionRepository, gamePlayRepository, rewardEventRepository, leaderboards, service.NewStandardRewardCalculator(), session.NewSigner(session.SecretFromEnv()))
+	gachaService      = service.NewGachaService(userRepository, gachaRepository, gachaProbabilityRepository, userCollectionItemRepository, collectionItemRepository, userGachaPityRepository, gachaDrawHistoryRepository, gachaStepRepository, userGachaStepRepository, userGachaBoxItemRepository, userGachaTicketRepository, userGachaFreeDrawRepository, random.NewCryptoSource())
+	rankingService    = service.NewRankingService(userRepository, gamePlayRepository, rankingSeasonRepository, rankingSnapshotRepository, friendRepository, leaderboards)
+	collectionService = service.NewCollectionService(userCollectionItemRepository, collectionItemRepository, collectionSetRepository, userCollectionSetRewardRepository, userRepository, userGachaTicketRepository)
+	friendService     = service.NewFriendService(userRepository, friendRepository, friendRequestRepository)
+	tradeService      = service.NewTradeService(tradeRepository, userRepository, userCollectionItemRepository, collectionItemRepository, collectionSetRepository, userCollectionSetRewardRepository)
 
 	userHandler       = handler.NewUserHandler(httpResponse, userRepository)
 	settingHandler    = handler.NewSettingHandler(httpResponse)
@@ -35,13 +58,13 @@ var (
 	gachaHandler      = handler.NewGachaHandler(httpResponse, gachaService)
 	rankingHandler    = handler.NewRankingHandler(httpResponse, rankingService)
 	collectionHandler = handler.NewCollectionHandler(httpResponse, collectionService)
+	friendHandler     = handler.NewFriendHandler(httpResponse, friendService)
+	tradeHandler      = handler.NewTradeHandler(httpResponse, tradeService)
 )
 
 // Serve HTTPサーバを起動する
 func Serve(addr string) {
 
-	rand.Seed(time.Now().UnixNano())
-
 	/* ===== URLマッピングを行う ===== */
 	http.HandleFunc("/setting/get", get(settingHandler.HandleSettingGet))
 	http.HandleFunc("/user/create", post(userHandler.HandleUserCreate))
@@ -50,14 +73,34 @@ func Serve(addr string) {
 	http.HandleFunc("/user/update",
 		post(authMiddleware.Authenticate(userHandler.HandleUserUpdate)))
 
+	http.HandleFunc("/game/start", post(authMiddleware.Authenticate(gameHandler.HandleGameStart)))
 	http.HandleFunc("/game/finish", post(authMiddleware.Authenticate(gameHandler.HandleGameFinish)))
+	http.HandleFunc("/game/history", get(authMiddleware.Authenticate(gameHandler.HandleGameHistory)))
 
 	http.HandleFunc("/gacha/draw", post(authMiddleware.Authenticate(gachaHandler.HandleGachaDraw)))
+	http.HandleFunc("/gacha/list", get(authMiddleware.Authenticate(gachaHandler.HandleGachaList)))
+	http.HandleFunc("/gacha/history", get(authMiddleware.Authenticate(gachaHandler.HandleGachaHistory)))
+	http.HandleFunc("/gacha/rates", get(gachaHandler.HandleGachaRates))
+	http.HandleFunc("/gacha/box/reset", post(authMiddleware.Authenticate(gachaHandler.HandleGachaBoxReset)))
 
 	http.HandleFunc("/ranking/list", get(authMiddleware.Authenticate(rankingHandler.HandleRankingList)))
+	http.HandleFunc("/ranking/me", get(authMiddleware.Authenticate(rankingHandler.HandleRankingMe)))
+	http.HandleFunc("/ranking/friends", get(authMiddleware.Authenticate(rankingHandler.HandleRankingFriends)))
 
 	http.HandleFunc("/collection/list", get(authMiddleware.Authenticate(collectionHandler.HandleUserCollectionList)))
-  
+	http.HandleFunc("/collection/set/claim", post(authMiddleware.Authenticate(collectionHandler.HandleCollectionSetClaim)))
+
+	http.HandleFunc("/friend/list", get(authMiddleware.Authenticate(friendHandler.HandleFriendList)))
+	http.HandleFunc("/friend/request", post(authMiddleware.Authenticate(friendHandler.HandleFriendRequest)))
+	http.HandleFunc("/friend/accept", post(authMiddleware.Authenticate(friendHandler.HandleFriendAccept)))
+	http.HandleFunc("/friend/remove", post(authMiddleware.Authenticate(friendHandler.HandleFriendRemove)))
+
+	http.HandleFunc("/trade/list", get(authMiddleware.Authenticate(tradeHandler.HandleTradeList)))
+	http.HandleFunc("/trade/propose", post(authMiddleware.Authenticate(tradeHandler.HandleTradePropose)))
+	http.HandleFunc("/trade/accept", post(authMiddleware.Authenticate(tradeHandler.HandleTradeAccept)))
+	http.HandleFunc("/trade/decline", post(authMiddleware.Authenticate(tradeHandler.HandleTradeDecline)))
+	http.HandleFunc("/trade/cancel", post(authMiddleware.Authenticate(tradeHandler.HandleTradeCancel)))
+
 	/* ===== サーバの起動 ===== */
 	log.Println("Server running...")
 	err := http.ListenAndServe(addr, nil)
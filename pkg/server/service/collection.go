@@ -2,44 +2,171 @@
 
 package service
 
-import "20dojo-online/pkg/server/model"
+import (
+	"20dojo-online/pkg/constant"
+	"20dojo-online/pkg/cursor"
+	"20dojo-online/pkg/db"
+	"20dojo-online/pkg/myerror"
+	"20dojo-online/pkg/server/model"
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
 
 type GetUserCollectionListRequest struct {
-	UserID string
+	UserID    string
+	Limit     int
+	Cursor    string // 前のページのNextCursor. 空の場合は先頭から取得する
+	Rarity    int    // 0の場合はレアリティで絞り込まない
+	Ownership string // 所持状態. 空の場合は絞り込まない
+	Keyword   string // アイテム名の部分一致. 空の場合は絞り込まない
+	Sort      string // 並び順. 空の場合はID順
 }
 
 type GetUserCollectionListResponse struct {
 	CollectionItems []*CollectionItem
+	NextCursor      string // 次のページがない場合は空
+	TotalCount      int    // 絞り込み条件に一致するアイテム数
+	Sets            []*CollectionSetInfo
 }
 
 type CollectionItem struct {
-	CollectionID string
-	Name         string
-	Rarity       int
-	HasItem      bool
+	CollectionID    string
+	Name            string
+	Rarity          int
+	HasItem         bool
+	Count           int       // 獲得数(未所持の場合は0)
+	Level           int       // アイテムレベル(未所持の場合は0)
+	FirstAcquiredAt time.Time // 初回獲得日時(未所持の場合はゼロ値)
+	LastAcquiredAt  time.Time // 最終獲得日時(未所持の場合はゼロ値)
+}
+
+// CollectionSetInfo コレクションセットの達成状況
+type CollectionSetInfo struct {
+	CollectionSetID string
+	Name            string
+	OwnedCount      int
+	TotalCount      int
+	CompletionRate  float64 // 達成率(%)
+	Completed       bool
+	Claimed         bool // コンプリート報酬を受け取り済みか
+	RewardCoin      int
+	RewardTicket    int
+}
+
+type ClaimCollectionSetRewardRequest struct {
+	UserID          string
+	CollectionSetID string
+}
+
+type ClaimCollectionSetRewardResponse struct {
+	RewardCoin   int
+	RewardTicket int
+	Coin         int // 受け取り後の所持コイン
+	Ticket       int // 受け取り後のガチャチケット所持数
+}
+
+// collectionCursor コレクションアイテム一覧のカーソル. 前のページの最後のアイテムの並び順のキーを保持する
+type collectionCursor struct {
+	Sort         string `json:"o"`
+	Rarity       int    `json:"r,omitempty"`
+	AcquiredAt   int64  `json:"t,omitempty"` // 初回獲得日時(UNIX時間)
+	CollectionID string `json:"i"`
+}
+
+// newCollectionCursor コレクションアイテムの並び順のキーを作成する
+func newCollectionCursor(sortBy string, collectionItem *CollectionItem) *collectionCursor {
+	key := &collectionCursor{
+		Sort:         sortBy,
+		CollectionID: collectionItem.CollectionID,
+	}
+	switch sortBy {
+	case constant.CollectionSortRarity:
+		key.Rarity = collectionItem.Rarity
+	case constant.CollectionSortAcquired:
+		if collectionItem.HasItem {
+			key.AcquiredAt = collectionItem.FirstAcquiredAt.Unix()
+		}
+	}
+	return key
+}
+
+// less 並び順でaがbより前であればtrue
+func (a *collectionCursor) less(b *collectionCursor) bool {
+	if a.Rarity != b.Rarity {
+		return a.Rarity > b.Rarity
+	}
+	if a.AcquiredAt != b.AcquiredAt {
+		return a.AcquiredAt > b.AcquiredAt
+	}
+	return a.CollectionID < b.CollectionID
 }
 
 type CollectionService struct {
-	UserCollectionItemRepository model.UserCollectionItemRepositoryInterface
-	CollectionItemRepository     model.CollectionItemRepositoryInterface
+	UserCollectionItemRepository      model.UserCollectionItemRepositoryInterface
+	CollectionItemRepository          model.CollectionItemRepositoryInterface
+	CollectionSetRepository           model.CollectionSetRepositoryInterface
+	UserCollectionSetRewardRepository model.UserCollectionSetRewardRepositoryInterface
+	UserRepository                    model.UserRepositoryInterface
+	UserGachaTicketRepository         model.UserGachaTicketRepositoryInterface
 }
 
-func NewCollectionService(userCollectionItemRepository model.UserCollectionItemRepositoryInterface, collectionItemRepository model.CollectionItemRepositoryInterface) *CollectionService {
+func NewCollectionService(
+	userCollectionItemRepository model.UserCollectionItemRepositoryInterface,
+	collectionItemRepository model.CollectionItemRepositoryInterface,
+	collectionSetRepository model.CollectionSetRepositoryInterface,
+	userCollectionSetRewardRepository model.UserCollectionSetRewardRepositoryInterface,
+	userRepository model.UserRepositoryInterface,
+	userGachaTicketRepository model.UserGachaTicketRepositoryInterface,
+) *CollectionService {
 	return &CollectionService{
-		UserCollectionItemRepository: userCollectionItemRepository,
-		CollectionItemRepository:     collectionItemRepository,
+		UserCollectionItemRepository:      userCollectionItemRepository,
+		CollectionItemRepository:          collectionItemRepository,
+		CollectionSetRepository:           collectionSetRepository,
+		UserCollectionSetRewardRepository: userCollectionSetRewardRepository,
+		UserRepository:                    userRepository,
+		UserGachaTicketRepository:         userGachaTicketRepository,
 	}
 }
 
 type CollectionServiceInterface interface {
 	GetUserCollectionList(serviceRequest *GetUserCollectionListRequest) (*GetUserCollectionListResponse, error)
+	ClaimCollectionSetReward(serviceRequest *ClaimCollectionSetRewardRequest) (*ClaimCollectionSetRewardResponse, error)
 }
 
 var _ CollectionServiceInterface = (*CollectionService)(nil)
 
 // GetCollectionList ユーザのコレクションアイテム一覧情報取得のロジック
 func (s *CollectionService) GetUserCollectionList(serviceRequest *GetUserCollectionListRequest) (*GetUserCollectionListResponse, error) {
-	// コレクションアイテムを全件取得
+	sortBy := serviceRequest.Sort
+	if sortBy == "" {
+		sortBy = constant.CollectionSortID
+	}
+
+	// カーソルの指定がある場合は、前のページの最後のアイテムの次から取得する. 並び順が異なるカーソルは使えない
+	var after *collectionCursor
+	if serviceRequest.Cursor != "" {
+		after = &collectionCursor{}
+		if err := cursor.Decode(serviceRequest.Cursor, after); err != nil {
+			return nil, myerror.ApplicationError{
+				Message:       "failed to decode collection cursor",
+				OriginalError: err,
+				Code:          http.StatusBadRequest,
+			}
+		}
+		if after.Sort != sortBy {
+			return nil, myerror.ApplicationError{
+				Message: fmt.Sprintf("collection cursor is for another sort. cursor sort=%s, sort=%s", after.Sort, sortBy),
+				Code:    http.StatusBadRequest,
+			}
+		}
+	}
+
+	// コレクションアイテムを全取得. コレクションセットの集計にも利用する
 	collectionItems, err := s.CollectionItemRepository.SelectCollectionItemAll()
 	if err != nil {
 		return nil, err
@@ -52,12 +179,13 @@ func (s *CollectionService) GetUserCollectionList(serviceRequest *GetUserCollect
 	}
 
 	// ユーザの所持アイテムをマップに変換
-	userCollectionItemsMap := make(map[string]struct{}, len(userCollectionItems))
+	userCollectionItemsMap := make(map[string]*model.UserCollectionItem, len(userCollectionItems))
 	for _, userCollectionItem := range userCollectionItems {
-		userCollectionItemsMap[userCollectionItem.CollectionItemID] = struct{}{}
+		userCollectionItemsMap[userCollectionItem.CollectionItemID] = userCollectionItem
 	}
 
-	// ユーザの所持アイテムをチェック
+	// ユーザの所持アイテムをチェックし、絞り込み条件に一致するアイテムのみ残す
+	keyword := strings.ToLower(serviceRequest.Keyword)
 	collectionItemList := make([]*CollectionItem, 0, len(collectionItems))
 	for _, collectionItem := range collectionItems {
 		userCollectionItem := &CollectionItem{
@@ -65,11 +193,290 @@ func (s *CollectionService) GetUserCollectionList(serviceRequest *GetUserCollect
 			Name:         collectionItem.Name,
 			Rarity:       collectionItem.Rarity,
 		}
-		if _, ok := userCollectionItemsMap[collectionItem.ID]; ok {
+		if ownedItem, ok := userCollectionItemsMap[collectionItem.ID]; ok {
 			userCollectionItem.HasItem = true
+			userCollectionItem.Count = ownedItem.Count
+			userCollectionItem.Level = ownedItem.Level
+			userCollectionItem.FirstAcquiredAt = ownedItem.FirstAcquiredAt
+			userCollectionItem.LastAcquiredAt = ownedItem.LastAcquiredAt
+		}
+		if serviceRequest.Rarity > 0 && userCollectionItem.Rarity != serviceRequest.Rarity {
+			continue
+		}
+		if (serviceRequest.Ownership == constant.CollectionOwnershipOwned && !userCollectionItem.HasItem) ||
+			(serviceRequest.Ownership == constant.CollectionOwnershipUnowned && userCollectionItem.HasItem) {
+			continue
+		}
+		if keyword != "" && !strings.Contains(strings.ToLower(userCollectionItem.Name), keyword) {
+			continue
 		}
 		collectionItemList = append(collectionItemList, userCollectionItem)
 	}
 
-	return &GetUserCollectionListResponse{CollectionItems: collectionItemList}, err
+	// 並び順のキーでソートし、カーソルの次から指定件数を取得する
+	sort.Slice(collectionItemList, func(i, j int) bool {
+		return newCollectionCursor(sortBy, collectionItemList[i]).less(newCollectionCursor(sortBy, collectionItemList[j]))
+	})
+	from := 0
+	if after != nil {
+		from = sort.Search(len(collectionItemList), func(i int) bool {
+			return after.less(newCollectionCursor(sortBy, collectionItemList[i]))
+		})
+	}
+	page := collectionItemList[from:]
+	var nextCursor string
+	if len(page) > serviceRequest.Limit {
+		page = page[:serviceRequest.Limit]
+		if nextCursor, err = cursor.Encode(newCollectionCursor(sortBy, page[len(page)-1])); err != nil {
+			return nil, err
+		}
+	}
+
+	// コレクションセットの達成状況を取得
+	sets, err := s.getCollectionSetInfoList(serviceRequest.UserID, collectionItems, userCollectionItemsMap)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetUserCollectionListResponse{
+		CollectionItems: page,
+		NextCursor:      nextCursor,
+		TotalCount:      len(collectionItemList),
+		Sets:            sets,
+	}, nil
+}
+
+// ClaimCollectionSetReward コンプリートしたコレクションセットの報酬受け取りのロジック
+func (s *CollectionService) ClaimCollectionSetReward(serviceRequest *ClaimCollectionSetRewardRequest) (*ClaimCollectionSetRewardResponse, error) {
+	collectionSet, err := s.CollectionSetRepository.SelectCollectionSetByPrimaryKey(serviceRequest.CollectionSetID)
+	if err != nil {
+		return nil, err
+	}
+	if collectionSet == nil {
+		return nil, myerror.ApplicationError{
+			Message: fmt.Sprintf("collection set not found. collectionSetID=%s", serviceRequest.CollectionSetID),
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	setItemIDs, err := s.selectCollectionSetItemIDs(collectionSet)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.claimCollectionSetReward(tx, serviceRequest, collectionSet, setItemIDs, time.Now())
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			log.Println(fmt.Sprintf("Rollback Error in claiming collection set reward: %s", rollbackErr))
+		}
+		return nil, err
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return nil, commitErr
+	}
+	return res, nil
+}
+
+// claimCollectionSetReward トランザクション内でコンプリートを確認して報酬の受け取りを記録し、コインとガチャチケットを付与する
+func (s *CollectionService) claimCollectionSetReward(tx *sql.Tx, serviceRequest *ClaimCollectionSetRewardRequest, collectionSet *model.CollectionSet, setItemIDs []string, now time.Time) (*ClaimCollectionSetRewardResponse, error) {
+	user, err := s.UserRepository.SelectUserByPrimaryKeyForUpdate(tx, serviceRequest.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, myerror.ApplicationError{
+			Message: fmt.Sprintf("user not found. userID=%s", serviceRequest.UserID),
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	// トレードでアイテムが減るため、所持アイテムをロックしてからコンプリートしているかをチェックする
+	userCollectionItems, err := s.UserCollectionItemRepository.SelectUserCollectionItemsByUserIDForUpdate(tx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	userCollectionItemsMap := make(map[string]*model.UserCollectionItem, len(userCollectionItems))
+	for _, userCollectionItem := range userCollectionItems {
+		userCollectionItemsMap[userCollectionItem.CollectionItemID] = userCollectionItem
+	}
+	if owned := countOwnedItems(setItemIDs, userCollectionItemsMap); len(setItemIDs) == 0 || owned < len(setItemIDs) {
+		return nil, myerror.ApplicationError{
+			Message: fmt.Sprintf("collection set is not completed. collectionSetID=%s, owned=%d, total=%d", collectionSet.ID, owned, len(setItemIDs)),
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	// ユーザ情報のロック後に確認することで、同時に受け取っても二重に付与しない
+	userCollectionSetReward, err := s.UserCollectionSetRewardRepository.SelectUserCollectionSetRewardByPrimaryKey(tx, user.ID, collectionSet.ID)
+	if err != nil {
+		return nil, err
+	}
+	if userCollectionSetReward != nil {
+		return nil, myerror.ApplicationError{
+			Message: fmt.Sprintf("collection set reward has already been claimed. collectionSetID=%s", collectionSet.ID),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	if err = s.UserCollectionSetRewardRepository.InsertUserCollectionSetReward(tx, &model.UserCollectionSetReward{
+		UserID:          user.ID,
+		CollectionSetID: collectionSet.ID,
+		RewardCoin:      collectionSet.RewardCoin,
+		RewardTicket:    collectionSet.RewardTicket,
+		ClaimedAt:       now,
+	}); err != nil {
+		return nil, err
+	}
+
+	coin := user.Coin + collectionSet.RewardCoin
+	if err = s.UserRepository.UpdateUserCoinByPrimaryKey(tx, user.ID, coin); err != nil {
+		return nil, err
+	}
+
+	userGachaTicket, err := s.UserGachaTicketRepository.SelectUserGachaTicketByPrimaryKeyForUpdate(tx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if userGachaTicket == nil {
+		userGachaTicket = &model.UserGachaTicket{UserID: user.ID}
+	}
+	if collectionSet.RewardTicket > 0 {
+		userGachaTicket.Count += collectionSet.RewardTicket
+		if err = s.UserGachaTicketRepository.UpsertUserGachaTicket(tx, userGachaTicket); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ClaimCollectionSetRewardResponse{
+		RewardCoin:   collectionSet.RewardCoin,
+		RewardTicket: collectionSet.RewardTicket,
+		Coin:         coin,
+		Ticket:       userGachaTicket.Count,
+	}, nil
+}
+
+// getCollectionSetInfoList 全コレクションセットについてユーザの達成状況を集計する
+func (s *CollectionService) getCollectionSetInfoList(userID string, collectionItems []*model.CollectionItem, userCollectionItemsMap map[string]*model.UserCollectionItem) ([]*CollectionSetInfo, error) {
+	collectionSets, err := s.CollectionSetRepository.SelectCollectionSetAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(collectionSets) == 0 {
+		return []*CollectionSetInfo{}, nil
+	}
+
+	collectionSetItems, err := s.CollectionSetRepository.SelectCollectionSetItemAll()
+	if err != nil {
+		return nil, err
+	}
+	setItemIDs := collectionSetItemIDsMap(collectionSets, collectionItems, collectionSetItems)
+
+	// 報酬の受け取り記録を取得
+	userCollectionSetRewards, err := s.UserCollectionSetRewardRepository.SelectUserCollectionSetRewardsByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	claimed := make(map[string]struct{}, len(userCollectionSetRewards))
+	for _, userCollectionSetReward := range userCollectionSetRewards {
+		claimed[userCollectionSetReward.CollectionSetID] = struct{}{}
+	}
+
+	sets := make([]*CollectionSetInfo, 0, len(collectionSets))
+	for _, collectionSet := range collectionSets {
+		itemIDs := setItemIDs[collectionSet.ID]
+		set := &CollectionSetInfo{
+			CollectionSetID: collectionSet.ID,
+			Name:            collectionSet.Name,
+			OwnedCount:      countOwnedItems(itemIDs, userCollectionItemsMap),
+			TotalCount:      len(itemIDs),
+			RewardCoin:      collectionSet.RewardCoin,
+			RewardTicket:    collectionSet.RewardTicket,
+		}
+		if set.TotalCount > 0 {
+			set.CompletionRate = ratioToPercentage(set.OwnedCount, set.TotalCount)
+			set.Completed = set.OwnedCount == set.TotalCount
+		}
+		if _, ok := claimed[collectionSet.ID]; ok {
+			set.Claimed = true
+		}
+		sets = append(sets, set)
+	}
+	return sets, nil
+}
+
+// selectCollectionSetItemIDs コレクションセットの対象アイテムのIDを取得する
+func (s *CollectionService) selectCollectionSetItemIDs(collectionSet *model.CollectionSet) ([]string, error) {
+	var itemIDs []string
+	if collectionSet.Rarity > 0 {
+		collectionItems, err := s.CollectionItemRepository.SelectCollectionItemAll()
+		if err != nil {
+			return nil, err
+		}
+		for _, collectionItem := range collectionItems {
+			if collectionItem.Rarity == collectionSet.Rarity {
+				itemIDs = append(itemIDs, collectionItem.ID)
+			}
+		}
+		return itemIDs, nil
+	}
+
+	collectionSetItems, err := s.CollectionSetRepository.SelectCollectionSetItemsByCollectionSetID(collectionSet.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, collectionSetItem := range collectionSetItems {
+		itemIDs = append(itemIDs, collectionSetItem.CollectionItemID)
+	}
+	return itemIDs, nil
+}
+
+// collectionSetItemIDsMap コレクションセットごとの対象アイテムのIDをまとめる
+// レアリティ指定のセットはそのレアリティの全アイテム、それ以外はセットに登録されたアイテムを対象とする
+func collectionSetItemIDsMap(collectionSets []*model.CollectionSet, collectionItems []*model.CollectionItem, collectionSetItems []*model.CollectionSetItem) map[string][]string {
+	rarityItemIDs := make(map[int][]string)
+	for _, collectionItem := range collectionItems {
+		rarityItemIDs[collectionItem.Rarity] = append(rarityItemIDs[collectionItem.Rarity], collectionItem.ID)
+	}
+	registeredItemIDs := make(map[string][]string)
+	for _, collectionSetItem := range collectionSetItems {
+		registeredItemIDs[collectionSetItem.CollectionSetID] = append(registeredItemIDs[collectionSetItem.CollectionSetID], collectionSetItem.CollectionItemID)
+	}
+
+	setItemIDs := make(map[string][]string, len(collectionSets))
+	for _, collectionSet := range collectionSets {
+		if collectionSet.Rarity > 0 {
+			setItemIDs[collectionSet.ID] = rarityItemIDs[collectionSet.Rarity]
+			continue
+		}
+		setItemIDs[collectionSet.ID] = registeredItemIDs[collectionSet.ID]
+	}
+	return setItemIDs
+}
+
+// collectionItemLevel 獲得数に応じたアイテムレベルを返す
+func collectionItemLevel(count int) int {
+	level := 0
+	for _, levelCount := range constant.CollectionItemLevelCounts {
+		if count < levelCount {
+			break
+		}
+		level++
+	}
+	return level
+}
+
+// countOwnedItems 対象アイテムのうちユーザが所持している数を数える
+func countOwnedItems(itemIDs []string, userCollectionItemsMap map[string]*model.UserCollectionItem) int {
+	owned := 0
+	for _, itemID := range itemIDs {
+		if _, ok := userCollectionItemsMap[itemID]; ok {
+			owned++
+		}
+	}
+	return owned
 }
@@ -0,0 +1,513 @@
+package service
+
+import (
+	"20dojo-online/pkg/constant"
+	"20dojo-online/pkg/cursor"
+	"20dojo-online/pkg/server/model"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+// testCollectionCursor テスト用のコレクションアイテム一覧のカーソルを作成する
+func testCollectionCursor(t *testing.T, key *collectionCursor) string {
+	token, err := cursor.Encode(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestCollectionService_GetUserCollectionList(t *testing.T) {
+	firstAcquiredAt := time.Date(2020, 8, 1, 12, 0, 0, 0, time.Local)
+	secondAcquiredAt := time.Date(2020, 8, 2, 12, 0, 0, 0, time.Local)
+	lastAcquiredAt := time.Date(2020, 8, 3, 12, 0, 0, 0, time.Local)
+
+	collectionItems := []*model.CollectionItem{
+		{ID: "1001", Name: "ゴリラ01", Rarity: 1},
+		{ID: "1002", Name: "ゴリラ02", Rarity: 1},
+		{ID: "2001", Name: "スゴリラ01", Rarity: 2},
+		{ID: "3001", Name: "超スゴリラ01", Rarity: 3},
+	}
+	userCollectionItems := []*model.UserCollectionItem{
+		{UserID: "UserId1", CollectionItemID: "1002", Count: 3, Level: 2, FirstAcquiredAt: firstAcquiredAt, LastAcquiredAt: lastAcquiredAt},
+		{UserID: "UserId1", CollectionItemID: "2001", Count: 1, Level: 1, FirstAcquiredAt: secondAcquiredAt, LastAcquiredAt: secondAcquiredAt},
+	}
+	var (
+		item1001 = &CollectionItem{CollectionID: "1001", Name: "ゴリラ01", Rarity: 1}
+		item1002 = &CollectionItem{CollectionID: "1002", Name: "ゴリラ02", Rarity: 1, HasItem: true, Count: 3, Level: 2, FirstAcquiredAt: firstAcquiredAt, LastAcquiredAt: lastAcquiredAt}
+		item2001 = &CollectionItem{CollectionID: "2001", Name: "スゴリラ01", Rarity: 2, HasItem: true, Count: 1, Level: 1, FirstAcquiredAt: secondAcquiredAt, LastAcquiredAt: secondAcquiredAt}
+		item3001 = &CollectionItem{CollectionID: "3001", Name: "超スゴリラ01", Rarity: 3}
+	)
+	// 一覧の取得に必要なデータを返す. コレクションセットは登録なしとする
+	expectList := func(mock *mockRepository) {
+		mock.collectionItemRepository.EXPECT().SelectCollectionItemAll().Return(collectionItems, nil)
+		mock.userCollectionItemRepository.EXPECT().SelectUserCollectionItemsByUserID("UserId1").Return(userCollectionItems, nil)
+		mock.collectionSetRepository.EXPECT().SelectCollectionSetAll().Return(nil, nil)
+	}
+
+	type args struct {
+		serviceRequest *GetUserCollectionListRequest
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		before  func(mock *mockRepository, args args)
+		want    *GetUserCollectionListResponse
+		wantErr bool
+	}{
+		{
+			name: "正常:ID順で次のページあり",
+			args: args{
+				serviceRequest: &GetUserCollectionListRequest{UserID: "UserId1", Limit: 2},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.collectionItemRepository.EXPECT().SelectCollectionItemAll().Return(collectionItems, nil)
+				mock.userCollectionItemRepository.EXPECT().SelectUserCollectionItemsByUserID("UserId1").Return(userCollectionItems, nil)
+				mock.collectionSetRepository.EXPECT().SelectCollectionSetAll().Return([]*model.CollectionSet{
+					{ID: "1", Name: "ゴリラコンプリート", Rarity: 1, RewardCoin: 1000, RewardTicket: 1},
+					{ID: "2", Name: "スゴリラコンプリート", Rarity: 2, RewardCoin: 3000, RewardTicket: 3},
+					{ID: "3", Name: "ゴリラ兄弟", Rarity: 0, RewardCoin: 500, RewardTicket: 0},
+					{ID: "4", Name: "空のセット", Rarity: 0, RewardCoin: 100, RewardTicket: 0},
+				}, nil)
+				mock.collectionSetRepository.EXPECT().SelectCollectionSetItemAll().Return([]*model.CollectionSetItem{
+					{CollectionSetID: "3", CollectionItemID: "1001"},
+					{CollectionSetID: "3", CollectionItemID: "1002"},
+					{CollectionSetID: "3", CollectionItemID: "2001"},
+					{CollectionSetID: "3", CollectionItemID: "3001"},
+				}, nil)
+				mock.userCollectionSetRewardRepository.EXPECT().SelectUserCollectionSetRewardsByUserID("UserId1").Return([]*model.UserCollectionSetReward{
+					{UserID: "UserId1", CollectionSetID: "2", RewardCoin: 3000, RewardTicket: 3},
+				}, nil)
+			},
+			want: &GetUserCollectionListResponse{
+				CollectionItems: []*CollectionItem{item1001, item1002},
+				NextCursor:      testCollectionCursor(t, &collectionCursor{Sort: constant.CollectionSortID, CollectionID: "1002"}),
+				TotalCount:      4,
+				Sets: []*CollectionSetInfo{
+					{CollectionSetID: "1", Name: "ゴリラコンプリート", OwnedCount: 1, TotalCount: 2, CompletionRate: 50, RewardCoin: 1000, RewardTicket: 1},
+					{CollectionSetID: "2", Name: "スゴリラコンプリート", OwnedCount: 1, TotalCount: 1, CompletionRate: 100, Completed: true, Claimed: true, RewardCoin: 3000, RewardTicket: 3},
+					{CollectionSetID: "3", Name: "ゴリラ兄弟", OwnedCount: 2, TotalCount: 4, CompletionRate: 50, RewardCoin: 500},
+					{CollectionSetID: "4", Name: "空のセット", OwnedCount: 0, TotalCount: 0, CompletionRate: 0, RewardCoin: 100},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常:カーソルの次から取得",
+			args: args{
+				serviceRequest: &GetUserCollectionListRequest{
+					UserID: "UserId1",
+					Limit:  2,
+					Cursor: testCollectionCursor(t, &collectionCursor{Sort: constant.CollectionSortID, CollectionID: "1002"}),
+				},
+			},
+			before: func(mock *mockRepository, args args) { expectList(mock) },
+			want: &GetUserCollectionListResponse{
+				CollectionItems: []*CollectionItem{item2001, item3001},
+				TotalCount:      4,
+				Sets:            []*CollectionSetInfo{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常:レアリティ順",
+			args: args{
+				serviceRequest: &GetUserCollectionListRequest{UserID: "UserId1", Limit: 3, Sort: constant.CollectionSortRarity},
+			},
+			before: func(mock *mockRepository, args args) { expectList(mock) },
+			want: &GetUserCollectionListResponse{
+				CollectionItems: []*CollectionItem{item3001, item2001, item1001},
+				NextCursor:      testCollectionCursor(t, &collectionCursor{Sort: constant.CollectionSortRarity, Rarity: 1, CollectionID: "1001"}),
+				TotalCount:      4,
+				Sets:            []*CollectionSetInfo{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常:レアリティ順のカーソルの次から取得",
+			args: args{
+				serviceRequest: &GetUserCollectionListRequest{
+					UserID: "UserId1",
+					Limit:  3,
+					Sort:   constant.CollectionSortRarity,
+					Cursor: testCollectionCursor(t, &collectionCursor{Sort: constant.CollectionSortRarity, Rarity: 1, CollectionID: "1001"}),
+				},
+			},
+			before: func(mock *mockRepository, args args) { expectList(mock) },
+			want: &GetUserCollectionListResponse{
+				CollectionItems: []*CollectionItem{item1002},
+				TotalCount:      4,
+				Sets:            []*CollectionSetInfo{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常:獲得日時順は未所持のアイテムが最後",
+			args: args{
+				serviceRequest: &GetUserCollectionListRequest{UserID: "UserId1", Limit: 10, Sort: constant.CollectionSortAcquired},
+			},
+			before: func(mock *mockRepository, args args) { expectList(mock) },
+			want: &GetUserCollectionListResponse{
+				CollectionItems: []*CollectionItem{item2001, item1002, item1001, item3001},
+				TotalCount:      4,
+				Sets:            []*CollectionSetInfo{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常:レアリティと所持状態で絞り込み",
+			args: args{
+				serviceRequest: &GetUserCollectionListRequest{UserID: "UserId1", Limit: 10, Rarity: 1, Ownership: constant.CollectionOwnershipOwned},
+			},
+			before: func(mock *mockRepository, args args) { expectList(mock) },
+			want: &GetUserCollectionListResponse{
+				CollectionItems: []*CollectionItem{item1002},
+				TotalCount:      1,
+				Sets:            []*CollectionSetInfo{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常:未所持のアイテムを名前で検索",
+			args: args{
+				serviceRequest: &GetUserCollectionListRequest{UserID: "UserId1", Limit: 10, Ownership: constant.CollectionOwnershipUnowned, Keyword: "スゴリラ"},
+			},
+			before: func(mock *mockRepository, args args) { expectList(mock) },
+			want: &GetUserCollectionListResponse{
+				CollectionItems: []*CollectionItem{item3001},
+				TotalCount:      1,
+				Sets:            []*CollectionSetInfo{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常:カーソルの形式が不正",
+			args: args{
+				serviceRequest: &GetUserCollectionListRequest{UserID: "UserId1", Limit: 2, Cursor: "invalid"},
+			},
+			before:  func(mock *mockRepository, args args) {},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "異常:並び順が異なるカーソル",
+			args: args{
+				serviceRequest: &GetUserCollectionListRequest{
+					UserID: "UserId1",
+					Limit:  2,
+					Sort:   constant.CollectionSortAcquired,
+					Cursor: testCollectionCursor(t, &collectionCursor{Sort: constant.CollectionSortID, CollectionID: "1002"}),
+				},
+			},
+			before:  func(mock *mockRepository, args args) {},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "異常:コレクションアイテム取得エラー",
+			args: args{
+				serviceRequest: &GetUserCollectionListRequest{UserID: "UserId1", Limit: 2},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.collectionItemRepository.EXPECT().SelectCollectionItemAll().Return(nil, errors.New("SelectCollectionItemAll failed"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "異常:コレクションセット取得エラー",
+			args: args{
+				serviceRequest: &GetUserCollectionListRequest{UserID: "UserId1", Limit: 2},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.collectionItemRepository.EXPECT().SelectCollectionItemAll().Return(collectionItems, nil)
+				mock.userCollectionItemRepository.EXPECT().SelectUserCollectionItemsByUserID("UserId1").Return(userCollectionItems, nil)
+				mock.collectionSetRepository.EXPECT().SelectCollectionSetAll().Return(nil, errors.New("SelectCollectionSetAll failed"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMockRepository(ctrl)
+			tt.before(mock, tt.args)
+			s := NewCollectionService(mock.userCollectionItemRepository, mock.collectionItemRepository, mock.collectionSetRepository, mock.userCollectionSetRewardRepository, mock.userRepository, nil)
+			got, err := s.GetUserCollectionList(tt.args.serviceRequest)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetUserCollectionList() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetUserCollectionList() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// ClaimCollectionSetRewardのトランザクションの開始はDBを利用するため、トランザクション開始前の検証のみテストする
+func TestCollectionService_ClaimCollectionSetReward(t *testing.T) {
+	type args struct {
+		serviceRequest *ClaimCollectionSetRewardRequest
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		before  func(mock *mockRepository, args args)
+		wantErr string
+	}{
+		{
+			name: "異常:コレクションセットが存在しない",
+			args: args{
+				serviceRequest: &ClaimCollectionSetRewardRequest{UserID: "UserId1", CollectionSetID: "99"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.collectionSetRepository.EXPECT().SelectCollectionSetByPrimaryKey("99").Return(nil, nil)
+			},
+			wantErr: "collection set not found. collectionSetID=99",
+		},
+		{
+			name: "異常:対象アイテム取得エラー",
+			args: args{
+				serviceRequest: &ClaimCollectionSetRewardRequest{UserID: "UserId1", CollectionSetID: "4"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.collectionSetRepository.EXPECT().SelectCollectionSetByPrimaryKey("4").Return(&model.CollectionSet{ID: "4", RewardCoin: 500}, nil)
+				mock.collectionSetRepository.EXPECT().SelectCollectionSetItemsByCollectionSetID("4").Return(nil, errors.New("SelectCollectionSetItemsByCollectionSetID failed"))
+			},
+			wantErr: "SelectCollectionSetItemsByCollectionSetID failed",
+		},
+		{
+			name: "異常:コレクションセット取得エラー",
+			args: args{
+				serviceRequest: &ClaimCollectionSetRewardRequest{UserID: "UserId1", CollectionSetID: "1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.collectionSetRepository.EXPECT().SelectCollectionSetByPrimaryKey("1").Return(nil, errors.New("SelectCollectionSetByPrimaryKey failed"))
+			},
+			wantErr: "SelectCollectionSetByPrimaryKey failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMockRepository(ctrl)
+			tt.before(mock, tt.args)
+			s := NewCollectionService(mock.userCollectionItemRepository, mock.collectionItemRepository, mock.collectionSetRepository, mock.userCollectionSetRewardRepository, mock.userRepository, nil)
+			got, err := s.ClaimCollectionSetReward(tt.args.serviceRequest)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("ClaimCollectionSetReward() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != nil {
+				t.Errorf("ClaimCollectionSetReward() got = %v, want nil", got)
+			}
+		})
+	}
+}
+
+// トランザクションはモックのリポジトリでは利用しないためnilを渡す
+func TestCollectionService_claimCollectionSetReward(t *testing.T) {
+	now := time.Date(2020, 8, 1, 12, 0, 0, 0, time.Local)
+
+	type args struct {
+		serviceRequest *ClaimCollectionSetRewardRequest
+		collectionSet  *model.CollectionSet
+		setItemIDs     []string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		before  func(mock *mockRepository, args args)
+		wantErr string
+	}{
+		{
+			name: "異常:ユーザが存在しない",
+			args: args{
+				serviceRequest: &ClaimCollectionSetRewardRequest{UserID: "UserId1", CollectionSetID: "1"},
+				collectionSet:  &model.CollectionSet{ID: "1", Rarity: 1, RewardCoin: 1000},
+				setItemIDs:     []string{"1001", "1002"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.userRepository.EXPECT().SelectUserByPrimaryKeyForUpdate(nil, "UserId1").Return(nil, nil)
+			},
+			wantErr: "user not found. userID=UserId1",
+		},
+		{
+			name: "異常:未コンプリート",
+			args: args{
+				serviceRequest: &ClaimCollectionSetRewardRequest{UserID: "UserId1", CollectionSetID: "1"},
+				collectionSet:  &model.CollectionSet{ID: "1", Rarity: 1, RewardCoin: 1000},
+				setItemIDs:     []string{"1001", "1002"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.userRepository.EXPECT().SelectUserByPrimaryKeyForUpdate(nil, "UserId1").Return(&model.User{ID: "UserId1", Coin: 100}, nil)
+				mock.userCollectionItemRepository.EXPECT().SelectUserCollectionItemsByUserIDForUpdate(nil, "UserId1").Return([]*model.UserCollectionItem{
+					{UserID: "UserId1", CollectionItemID: "1001", Count: 1},
+					{UserID: "UserId1", CollectionItemID: "2001", Count: 1},
+				}, nil)
+			},
+			wantErr: "collection set is not completed. collectionSetID=1, owned=1, total=2",
+		},
+		{
+			name: "異常:対象アイテムのないセットはコンプリートとしない",
+			args: args{
+				serviceRequest: &ClaimCollectionSetRewardRequest{UserID: "UserId1", CollectionSetID: "5"},
+				collectionSet:  &model.CollectionSet{ID: "5"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.userRepository.EXPECT().SelectUserByPrimaryKeyForUpdate(nil, "UserId1").Return(&model.User{ID: "UserId1"}, nil)
+				mock.userCollectionItemRepository.EXPECT().SelectUserCollectionItemsByUserIDForUpdate(nil, "UserId1").Return(nil, nil)
+			},
+			wantErr: "collection set is not completed. collectionSetID=5, owned=0, total=0",
+		},
+		{
+			name: "異常:受け取り済み",
+			args: args{
+				serviceRequest: &ClaimCollectionSetRewardRequest{UserID: "UserId1", CollectionSetID: "4"},
+				collectionSet:  &model.CollectionSet{ID: "4", RewardCoin: 500},
+				setItemIDs:     []string{"1001"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.userRepository.EXPECT().SelectUserByPrimaryKeyForUpdate(nil, "UserId1").Return(&model.User{ID: "UserId1"}, nil)
+				mock.userCollectionItemRepository.EXPECT().SelectUserCollectionItemsByUserIDForUpdate(nil, "UserId1").Return([]*model.UserCollectionItem{
+					{UserID: "UserId1", CollectionItemID: "1001", Count: 1},
+				}, nil)
+				mock.userCollectionSetRewardRepository.EXPECT().SelectUserCollectionSetRewardByPrimaryKey(nil, "UserId1", "4").Return(&model.UserCollectionSetReward{
+					UserID:          "UserId1",
+					CollectionSetID: "4",
+				}, nil)
+			},
+			wantErr: "collection set reward has already been claimed. collectionSetID=4",
+		},
+		{
+			name: "異常:所持アイテム取得エラー",
+			args: args{
+				serviceRequest: &ClaimCollectionSetRewardRequest{UserID: "UserId1", CollectionSetID: "4"},
+				collectionSet:  &model.CollectionSet{ID: "4", RewardCoin: 500},
+				setItemIDs:     []string{"1001"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.userRepository.EXPECT().SelectUserByPrimaryKeyForUpdate(nil, "UserId1").Return(&model.User{ID: "UserId1"}, nil)
+				mock.userCollectionItemRepository.EXPECT().SelectUserCollectionItemsByUserIDForUpdate(nil, "UserId1").Return(nil, errors.New("SelectUserCollectionItemsByUserIDForUpdate failed"))
+			},
+			wantErr: "SelectUserCollectionItemsByUserIDForUpdate failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMockRepository(ctrl)
+			tt.before(mock, tt.args)
+			s := NewCollectionService(mock.userCollectionItemRepository, mock.collectionItemRepository, mock.collectionSetRepository, mock.userCollectionSetRewardRepository, mock.userRepository, nil)
+			got, err := s.claimCollectionSetReward(nil, tt.args.serviceRequest, tt.args.collectionSet, tt.args.setItemIDs, now)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("claimCollectionSetReward() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != nil {
+				t.Errorf("claimCollectionSetReward() got = %v, want nil", got)
+			}
+		})
+	}
+}
+
+// コンプリートしたセットのアイテムをトレードで渡した後は、報酬を受け取れない
+func TestCollectionService_claimCollectionSetRewardAfterTrade(t *testing.T) {
+	now := time.Date(2020, 8, 1, 12, 0, 0, 0, time.Local)
+	ctrl := gomock.NewController(t)
+	mock := newMockRepository(ctrl)
+
+	// 所持アイテムはトレードの受け渡しを反映するメモリ上のデータで返す
+	possessed := map[string]map[string]*model.UserCollectionItem{
+		"UserId1": {
+			"1001": {UserID: "UserId1", CollectionItemID: "1001", Count: 1, Level: 1},
+			"1002": {UserID: "UserId1", CollectionItemID: "1002", Count: 1, Level: 1},
+		},
+		"UserId2": {},
+	}
+	mock.userCollectionItemRepository.EXPECT().SelectUserCollectionItemsByUserIDForUpdate(nil, gomock.Any()).DoAndReturn(
+		func(tx *sql.Tx, userID string) ([]*model.UserCollectionItem, error) {
+			var userCollectionItems []*model.UserCollectionItem
+			for _, userCollectionItem := range possessed[userID] {
+				copied := *userCollectionItem
+				userCollectionItems = append(userCollectionItems, &copied)
+			}
+			return userCollectionItems, nil
+		}).AnyTimes()
+	mock.userCollectionItemRepository.EXPECT().BulkUpsertUserCollectionItem(nil, gomock.Any()).DoAndReturn(
+		func(tx *sql.Tx, userCollectionItemSlice []*model.UserCollectionItem) error {
+			for _, userCollectionItem := range userCollectionItemSlice {
+				possessed[userCollectionItem.UserID][userCollectionItem.CollectionItemID] = userCollectionItem
+			}
+			return nil
+		}).AnyTimes()
+	mock.userCollectionItemRepository.EXPECT().DeleteUserCollectionItemsByPrimaryKeys(nil, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(tx *sql.Tx, userID string, collectionItemIDs []string) error {
+			for _, collectionItemID := range collectionItemIDs {
+				delete(possessed[userID], collectionItemID)
+			}
+			return nil
+		}).AnyTimes()
+	mock.userRepository.EXPECT().SelectUserByPrimaryKeyForUpdate(nil, gomock.Any()).DoAndReturn(
+		func(tx *sql.Tx, userID string) (*model.User, error) {
+			return &model.User{ID: userID}, nil
+		}).AnyTimes()
+	mock.userCollectionSetRewardRepository.EXPECT().SelectUserCollectionSetRewardsByUserID(gomock.Any()).Return(nil, nil).AnyTimes()
+
+	// UserId1はセットをコンプリートした状態で、報酬を受け取る前にアイテムをUserId2に渡す
+	trade := &model.Trade{
+		ID:             "TradeId1",
+		ProposerUserID: "UserId1",
+		TargetUserID:   "UserId2",
+		Status:         constant.TradeStatusPending,
+		ExpiresAt:      now.Add(constant.TradeExpiration),
+	}
+	tradeItems := []*model.TradeItem{{TradeID: "TradeId1", UserID: "UserId1", CollectionItemID: "1002", Count: 1}}
+	mock.tradeRepository.EXPECT().SelectTradeByPrimaryKeyForUpdate(nil, "TradeId1").Return(trade, nil)
+	mock.tradeRepository.EXPECT().UpdateTradeStatusByPrimaryKey(nil, "TradeId1", constant.TradeStatusAccepted, now).Return(nil)
+	tradeService := NewTradeService(mock.tradeRepository, mock.userRepository, mock.userCollectionItemRepository, mock.collectionItemRepository, mock.collectionSetRepository, mock.userCollectionSetRewardRepository)
+	if err := tradeService.acceptTrade(nil, trade, tradeItems, now); err != nil {
+		t.Fatalf("acceptTrade() error = %v", err)
+	}
+
+	collectionService := NewCollectionService(mock.userCollectionItemRepository, mock.collectionItemRepository, mock.collectionSetRepository, mock.userCollectionSetRewardRepository, mock.userRepository, nil)
+	got, err := collectionService.claimCollectionSetReward(nil, &ClaimCollectionSetRewardRequest{UserID: "UserId1", CollectionSetID: "4"},
+		&model.CollectionSet{ID: "4", RewardCoin: 500}, []string{"1001", "1002"}, now)
+	wantErr := "collection set is not completed. collectionSetID=4, owned=1, total=2"
+	if err == nil || err.Error() != wantErr {
+		t.Errorf("claimCollectionSetReward() error = %v, wantErr %v", err, wantErr)
+	}
+	if got != nil {
+		t.Errorf("claimCollectionSetReward() got = %v, want nil", got)
+	}
+}
+
+func Test_collectionItemLevel(t *testing.T) {
+	tests := []struct {
+		name  string
+		count int
+		want  int
+	}{
+		{name: "未所持", count: 0, want: 0},
+		{name: "初回獲得", count: 1, want: 1},
+		{name: "レベル2の獲得数", count: 2, want: 2},
+		{name: "レベル3の獲得数の手前", count: 3, want: 2},
+		{name: "最大レベルの獲得数", count: 11, want: 5},
+		{name: "最大レベルを超える獲得数", count: 100, want: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := collectionItemLevel(tt.count); got != tt.want {
+				t.Errorf("collectionItemLevel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"20dojo-online/pkg/constant"
+	"time"
+)
+
+// dailyResetTime 指定日時の直前に日次の回数・上限がリセットされた日時を返す
+func dailyResetTime(now time.Time) time.Time {
+	resetTime := time.Date(now.Year(), now.Month(), now.Day(), constant.DailyResetHour, 0, 0, 0, now.Location())
+	if now.Before(resetTime) {
+		resetTime = resetTime.AddDate(0, 0, -1)
+	}
+	return resetTime
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDailyResetTime(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "正常:リセット時刻以降は当日のリセット時刻",
+			now:  time.Date(2020, 8, 24, 12, 0, 0, 0, jst),
+			want: time.Date(2020, 8, 24, 4, 0, 0, 0, jst),
+		},
+		{
+			name: "正常:リセット時刻ちょうどは当日のリセット時刻",
+			now:  time.Date(2020, 8, 24, 4, 0, 0, 0, jst),
+			want: time.Date(2020, 8, 24, 4, 0, 0, 0, jst),
+		},
+		{
+			name: "正常:リセット時刻より前は前日のリセット時刻",
+			now:  time.Date(2020, 8, 24, 3, 59, 59, 0, jst),
+			want: time.Date(2020, 8, 23, 4, 0, 0, 0, jst),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dailyResetTime(tt.now); !got.Equal(tt.want) {
+				t.Errorf("dailyResetTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,340 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=./mock_$GOPACKAGE/mock_$GOFILE
+
+package service
+
+import (
+	"20dojo-online/pkg/constant"
+	"20dojo-online/pkg/db"
+	"20dojo-online/pkg/myerror"
+	"20dojo-online/pkg/server/model"
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+)
+
+type RequestFriendRequest struct {
+	UserID       string
+	TargetUserID string
+}
+
+type RequestFriendResponse struct {
+	Accepted bool // 相手からの申請が承認待ちだったため、申請せずにフレンドになった場合はtrue
+}
+
+type AcceptFriendRequest struct {
+	UserID          string
+	RequesterUserID string
+}
+
+type RemoveFriendRequest struct {
+	UserID       string
+	FriendUserID string
+}
+
+type GetFriendListRequest struct {
+	UserID string
+}
+
+type GetFriendListResponse struct {
+	Friends  []*FriendInfo // フレンド
+	Requests []*FriendInfo // 自分宛ての承認待ちの申請
+}
+
+// FriendInfo フレンド情報
+type FriendInfo struct {
+	UserID    string
+	UserName  string
+	HighScore int
+	CreatedAt time.Time // フレンドになった日時または申請日時
+}
+
+type FriendService struct {
+	UserRepository          model.UserRepositoryInterface
+	FriendRepository        model.FriendRepositoryInterface
+	FriendRequestRepository model.FriendRequestRepositoryInterface
+}
+
+func NewFriendService(
+	userRepository model.UserRepositoryInterface,
+	friendRepository model.FriendRepositoryInterface,
+	friendRequestRepository model.FriendRequestRepositoryInterface,
+) *FriendService {
+	return &FriendService{
+		UserRepository:          userRepository,
+		FriendRepository:        friendRepository,
+		FriendRequestRepository: friendRequestRepository,
+	}
+}
+
+type FriendServiceInterface interface {
+	RequestFriend(serviceRequest *RequestFriendRequest) (*RequestFriendResponse, error)
+	AcceptFriend(serviceRequest *AcceptFriendRequest) error
+	RemoveFriend(serviceRequest *RemoveFriendRequest) error
+	GetFriendList(serviceRequest *GetFriendListRequest) (*GetFriendListResponse, error)
+}
+
+var _ FriendServiceInterface = (*FriendService)(nil)
+
+// RequestFriend フレンド申請のロジック
+// 相手から承認待ちの申請が届いている場合は、その申請を承認してフレンドになる
+func (s *FriendService) RequestFriend(serviceRequest *RequestFriendRequest) (*RequestFriendResponse, error) {
+	if serviceRequest.UserID == serviceRequest.TargetUserID {
+		return nil, myerror.ApplicationError{
+			Message: fmt.Sprintf("cannot send friend request to yourself. userID=%s", serviceRequest.UserID),
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	accepted, err := s.requestFriend(tx, serviceRequest, time.Now())
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			log.Println(fmt.Sprintf("Rollback Error in requesting friend: %s", rollbackErr))
+		}
+		return nil, err
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return nil, commitErr
+	}
+
+	return &RequestFriendResponse{Accepted: accepted}, nil
+}
+
+// requestFriend トランザクション内でフレンド申請を登録する. 相手の申請を承認した場合はtrueを返す
+func (s *FriendService) requestFriend(tx *sql.Tx, serviceRequest *RequestFriendRequest, now time.Time) (bool, error) {
+	if err := s.lockUsers(tx, serviceRequest.UserID, serviceRequest.TargetUserID); err != nil {
+		return false, err
+	}
+
+	friend, err := s.FriendRepository.SelectFriendByPrimaryKey(tx, serviceRequest.UserID, serviceRequest.TargetUserID)
+	if err != nil {
+		return false, err
+	}
+	if friend != nil {
+		return false, myerror.ApplicationError{
+			Message: fmt.Sprintf("already friends. userID=%s, targetUserID=%s", serviceRequest.UserID, serviceRequest.TargetUserID),
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	// 相手からの申請が届いていれば承認する
+	reverseRequest, err := s.FriendRequestRepository.SelectFriendRequestByPrimaryKey(tx, serviceRequest.TargetUserID, serviceRequest.UserID)
+	if err != nil {
+		return false, err
+	}
+	if reverseRequest != nil {
+		return true, s.makeFriends(tx, serviceRequest.UserID, serviceRequest.TargetUserID, now)
+	}
+
+	// 申請済みの場合は何もしない
+	friendRequest, err := s.FriendRequestRepository.SelectFriendRequestByPrimaryKey(tx, serviceRequest.UserID, serviceRequest.TargetUserID)
+	if err != nil {
+		return false, err
+	}
+	if friendRequest != nil {
+		return false, nil
+	}
+
+	if err = s.validateFriendLimit(tx, serviceRequest.UserID); err != nil {
+		return false, err
+	}
+	return false, s.FriendRequestRepository.InsertFriendRequest(tx, &model.FriendRequest{
+		UserID:       serviceRequest.UserID,
+		TargetUserID: serviceRequest.TargetUserID,
+		CreatedAt:    now,
+	})
+}
+
+// AcceptFriend フレンド申請承認のロジック
+func (s *FriendService) AcceptFriend(serviceRequest *AcceptFriendRequest) error {
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	if err = s.acceptFriend(tx, serviceRequest, time.Now()); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			log.Println(fmt.Sprintf("Rollback Error in accepting friend: %s", rollbackErr))
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// acceptFriend トランザクション内で申請を承認してフレンドを登録する
+func (s *FriendService) acceptFriend(tx *sql.Tx, serviceRequest *AcceptFriendRequest, now time.Time) error {
+	if err := s.lockUsers(tx, serviceRequest.UserID, serviceRequest.RequesterUserID); err != nil {
+		return err
+	}
+
+	friendRequest, err := s.FriendRequestRepository.SelectFriendRequestByPrimaryKey(tx, serviceRequest.RequesterUserID, serviceRequest.UserID)
+	if err != nil {
+		return err
+	}
+	if friendRequest == nil {
+		return myerror.ApplicationError{
+			Message: fmt.Sprintf("friend request not found. userID=%s, requesterUserID=%s", serviceRequest.UserID, serviceRequest.RequesterUserID),
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	return s.makeFriends(tx, serviceRequest.UserID, serviceRequest.RequesterUserID, now)
+}
+
+// RemoveFriend フレンド解除のロジック
+// 承認待ちの申請がある場合は申請の取り消し・拒否として削除する
+func (s *FriendService) RemoveFriend(serviceRequest *RemoveFriendRequest) error {
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	if err = s.removeFriend(tx, serviceRequest); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			log.Println(fmt.Sprintf("Rollback Error in removing friend: %s", rollbackErr))
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// removeFriend トランザクション内で2人のユーザ間のフレンドと申請を削除する
+func (s *FriendService) removeFriend(tx *sql.Tx, serviceRequest *RemoveFriendRequest) error {
+	deletedFriends, err := s.FriendRepository.DeleteFriendsBetween(tx, serviceRequest.UserID, serviceRequest.FriendUserID)
+	if err != nil {
+		return err
+	}
+	deletedRequests, err := s.FriendRequestRepository.DeleteFriendRequestsBetween(tx, serviceRequest.UserID, serviceRequest.FriendUserID)
+	if err != nil {
+		return err
+	}
+	if deletedFriends == 0 && deletedRequests == 0 {
+		return myerror.ApplicationError{
+			Message: fmt.Sprintf("friend not found. userID=%s, friendUserID=%s", serviceRequest.UserID, serviceRequest.FriendUserID),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	return nil
+}
+
+// GetFriendList フレンド一覧取得のロジック
+func (s *FriendService) GetFriendList(serviceRequest *GetFriendListRequest) (*GetFriendListResponse, error) {
+	friends, err := s.FriendRepository.SelectFriendsByUserID(serviceRequest.UserID)
+	if err != nil {
+		return nil, err
+	}
+	friendRequests, err := s.FriendRequestRepository.SelectFriendRequestsByTargetUserID(serviceRequest.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// ユーザ名とハイスコアはユーザ情報から取得する
+	userIDs := make([]string, 0, len(friends)+len(friendRequests))
+	for _, friend := range friends {
+		userIDs = append(userIDs, friend.FriendUserID)
+	}
+	for _, friendRequest := range friendRequests {
+		userIDs = append(userIDs, friendRequest.UserID)
+	}
+	users, err := s.UserRepository.SelectUsersByPrimaryKeys(userIDs)
+	if err != nil {
+		return nil, err
+	}
+	userMap := make(map[string]*model.User, len(users))
+	for _, user := range users {
+		userMap[user.ID] = user
+	}
+
+	friendInfoList := make([]*FriendInfo, 0, len(friends))
+	for _, friend := range friends {
+		user, ok := userMap[friend.FriendUserID]
+		if !ok {
+			return nil, fmt.Errorf("friend user not found. userID=%s", friend.FriendUserID)
+		}
+		friendInfoList = append(friendInfoList, newFriendInfo(user, friend.CreatedAt))
+	}
+	requestInfoList := make([]*FriendInfo, 0, len(friendRequests))
+	for _, friendRequest := range friendRequests {
+		user, ok := userMap[friendRequest.UserID]
+		if !ok {
+			return nil, fmt.Errorf("requester user not found. userID=%s", friendRequest.UserID)
+		}
+		requestInfoList = append(requestInfoList, newFriendInfo(user, friendRequest.CreatedAt))
+	}
+
+	return &GetFriendListResponse{
+		Friends:  friendInfoList,
+		Requests: requestInfoList,
+	}, nil
+}
+
+// lockUsers 2人のユーザ情報を排他ロックする. 同時に申請し合った場合のデッドロックを防ぐためユーザIDの順にロックする
+func (s *FriendService) lockUsers(tx *sql.Tx, userIDs ...string) error {
+	sortedUserIDs := append([]string{}, userIDs...)
+	sort.Strings(sortedUserIDs)
+	for _, userID := range sortedUserIDs {
+		user, err := s.UserRepository.SelectUserByPrimaryKeyForUpdate(tx, userID)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return myerror.ApplicationError{
+				Message: fmt.Sprintf("user not found. userID=%s", userID),
+				Code:    http.StatusBadRequest,
+			}
+		}
+	}
+	return nil
+}
+
+// validateFriendLimit フレンド数が上限に達している場合はエラーを返す
+func (s *FriendService) validateFriendLimit(tx *sql.Tx, userID string) error {
+	count, err := s.FriendRepository.SelectFriendCountByUserID(tx, userID)
+	if err != nil {
+		return err
+	}
+	if count >= constant.FriendLimit {
+		return myerror.ApplicationError{
+			Message: fmt.Sprintf("friend limit exceeded. userID=%s, count=%d", userID, count),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	return nil
+}
+
+// makeFriends 2人のユーザを双方向のフレンドとして登録し、間の申請を削除する
+func (s *FriendService) makeFriends(tx *sql.Tx, userID string, otherUserID string, now time.Time) error {
+	for _, id := range []string{userID, otherUserID} {
+		if err := s.validateFriendLimit(tx, id); err != nil {
+			return err
+		}
+	}
+	if err := s.FriendRepository.BulkInsertFriend(tx, []*model.Friend{
+		{UserID: userID, FriendUserID: otherUserID, CreatedAt: now},
+		{UserID: otherUserID, FriendUserID: userID, CreatedAt: now},
+	}); err != nil {
+		return err
+	}
+	_, err := s.FriendRequestRepository.DeleteFriendRequestsBetween(tx, userID, otherUserID)
+	return err
+}
+
+// newFriendInfo ユーザ情報からフレンド情報を作成する
+func newFriendInfo(user *model.User, createdAt time.Time) *FriendInfo {
+	return &FriendInfo{
+		UserID:    user.ID,
+		UserName:  user.Name,
+		HighScore: user.HighScore,
+		CreatedAt: createdAt,
+	}
+}
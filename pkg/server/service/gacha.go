@@ -6,21 +6,28 @@ import (
 	"20dojo-online/pkg/constant"
 	"20dojo-online/pkg/db"
 	"20dojo-online/pkg/myerror"
+	"20dojo-online/pkg/random"
 	"20dojo-online/pkg/server/model"
+	"database/sql"
+	"encoding/json"
 	"fmt"
 	"log"
-	"math/rand"
 	"net/http"
+	"sort"
 	"strconv"
+	"time"
 )
 
 type DrawGachaRequest struct {
-	Times  int
-	UserID string
+	GachaID string
+	Times   int
+	Payment string
+	UserID  string
 }
 
 type DrawGachaResponse struct {
 	GachaResults []*GachaResult
+	Seed         int64
 }
 
 type GachaResult struct {
@@ -28,30 +35,129 @@ type GachaResult struct {
 	Name         string
 	Rarity       int
 	IsNew        bool
+	Shard        int
+	Count        int // 排出後の獲得数
+	Level        int // 排出後のアイテムレベル
+}
+
+type GetGachaListResponse struct {
+	Gachas []*GachaInfo
+}
+
+type ResetGachaBoxRequest struct {
+	GachaID string
+	UserID  string
+}
+
+type GetGachaHistoryRequest struct {
+	UserID string
+	Limit  int
+	Offset int
+}
+
+type GetGachaHistoryResponse struct {
+	GachaHistories []*GachaHistory
+}
+
+// GachaHistory ガチャ実行履歴
+type GachaHistory struct {
+	GachaID         string
+	CollectionID    string
+	Name            string
+	Rarity          int
+	CoinConsumption int
+	Payment         string
+	DrawnAt         time.Time
+}
+
+type GetGachaRatesRequest struct {
+	GachaID string
+}
+
+type GetGachaRatesResponse struct {
+	ItemRates   []*GachaItemRate
+	RarityRates []*GachaRarityRate
+}
+
+// GachaItemRate アイテムごとの排出確率(%)
+type GachaItemRate struct {
+	CollectionID string
+	Name         string
+	Rarity       int
+	Rate         float64
+}
+
+// GachaRarityRate レアリティごとの排出確率(%)
+type GachaRarityRate struct {
+	Rarity int
+	Rate   float64
+}
+
+// GachaInfo 開催中のガチャ情報
+type GachaInfo struct {
+	GachaID         string
+	Name            string
+	CoinConsumption int
+	Mode            string
+	StartAt         time.Time
+	EndAt           time.Time
 }
 
 type GachaService struct {
 	UserRepository               model.UserRepositoryInterface
+	GachaRepository              model.GachaRepositoryInterface
 	GachaProbabilityRepository   model.GachaProbabilityRepositoryInterface
 	UserCollectionItemRepository model.UserCollectionItemRepositoryInterface
 	CollectionItemRepository     model.CollectionItemRepositoryInterface
+	UserGachaPityRepository      model.UserGachaPityRepositoryInterface
+	GachaDrawHistoryRepository   model.GachaDrawHistoryRepositoryInterface
+	GachaStepRepository          model.GachaStepRepositoryInterface
+	UserGachaStepRepository      model.UserGachaStepRepositoryInterface
+	UserGachaBoxItemRepository   model.UserGachaBoxItemRepositoryInterface
+	UserGachaTicketRepository    model.UserGachaTicketRepositoryInterface
+	UserGachaFreeDrawRepository  model.UserGachaFreeDrawRepositoryInterface
+	SeedSource                   random.SeedSource
+	lotteryCache                 *gachaLotteryCache
 }
 
 func NewGachaService(userRepository model.UserRepositoryInterface,
+	gachaRepository model.GachaRepositoryInterface,
 	gachaProbabilityRepository model.GachaProbabilityRepositoryInterface,
 	userCollectionItemRepository model.UserCollectionItemRepositoryInterface,
-	collectionItemRepository model.CollectionItemRepositoryInterface) *GachaService {
+	collectionItemRepository model.CollectionItemRepositoryInterface,
+	userGachaPityRepository model.UserGachaPityRepositoryInterface,
+	gachaDrawHistoryRepository model.GachaDrawHistoryRepositoryInterface,
+	gachaStepRepository model.GachaStepRepositoryInterface,
+	userGachaStepRepository model.UserGachaStepRepositoryInterface,
+	userGachaBoxItemRepository model.UserGachaBoxItemRepositoryInterface,
+	userGachaTicketRepository model.UserGachaTicketRepositoryInterface,
+	userGachaFreeDrawRepository model.UserGachaFreeDrawRepositoryInterface,
+	seedSource random.SeedSource) *GachaService {
 
 	return &GachaService{
 		UserRepository:               userRepository,
+		GachaRepository:              gachaRepository,
 		GachaProbabilityRepository:   gachaProbabilityRepository,
 		UserCollectionItemRepository: userCollectionItemRepository,
 		CollectionItemRepository:     collectionItemRepository,
+		UserGachaPityRepository:      userGachaPityRepository,
+		GachaDrawHistoryRepository:   gachaDrawHistoryRepository,
+		GachaStepRepository:          gachaStepRepository,
+		UserGachaStepRepository:      userGachaStepRepository,
+		UserGachaBoxItemRepository:   userGachaBoxItemRepository,
+		UserGachaTicketRepository:    userGachaTicketRepository,
+		UserGachaFreeDrawRepository:  userGachaFreeDrawRepository,
+		SeedSource:                   seedSource,
+		lotteryCache:                 newGachaLotteryCache(),
 	}
 }
 
 type GachaServiceInterface interface {
 	DrawGacha(serviceRequest *DrawGachaRequest) (*DrawGachaResponse, error)
+	GetGachaList() (*GetGachaListResponse, error)
+	GetGachaHistory(serviceRequest *GetGachaHistoryRequest) (*GetGachaHistoryResponse, error)
+	GetGachaRates(serviceRequest *GetGachaRatesRequest) (*GetGachaRatesResponse, error)
+	ResetGachaBox(serviceRequest *ResetGachaBoxRequest) error
 }
 
 var _ GachaServiceInterface = (*GachaService)(nil)
@@ -59,123 +165,690 @@ var _ GachaServiceInterface = (*GachaService)(nil)
 // DrawGacha ガチャ実行時のロジック
 func (s *GachaService) DrawGacha(serviceRequest *DrawGachaRequest) (*DrawGachaResponse, error) {
 
-	// ガチャ排出確率情報からratioの合計を計算
-	gachaProbabilities, err := s.GachaProbabilityRepository.SelectGachaProbabilityAll()
+	// 実行回数の上限を超えるとアイテムの一括登録が巨大になるため、ハンドラを経由しない呼び出しでも弾く
+	if serviceRequest.Times < 1 || constant.GachaMaxDrawTimes < serviceRequest.Times {
+		return nil, myerror.ApplicationError{
+			Message: fmt.Sprintf("gacha draw times is out of range. times=%d", serviceRequest.Times),
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	// 実行するガチャの取得
+	gacha, err := s.GachaRepository.SelectGachaByPrimaryKey(serviceRequest.GachaID)
 	if err != nil {
 		return nil, err
 	}
-	var gachaProbabilitySum int // ratioの合計
-	for _, gachaProbability := range gachaProbabilities {
-		gachaProbabilitySum += gachaProbability.Ratio
-	}
-
-	// 排出アイテムの決定
-	gottenCollectionItemIDSlice := make([]string, 0, serviceRequest.Times) // 排出アイテムのidを入れるスライス
-	for i := 0; i < serviceRequest.Times; i++ {
-		randomNum := rand.Intn(gachaProbabilitySum) // 0からratioの合計までの整数で乱数を生成
-		gachaProbabilityThreshold := 0              // 閾値
-		var gottenCollectionItemID string
-		for _, gachaProbability := range gachaProbabilities {
-			gachaProbabilityThreshold += gachaProbability.Ratio
-			if randomNum < gachaProbabilityThreshold {
-				gottenCollectionItemID = gachaProbability.CollectionItemId
-				break
+	if gacha == nil {
+		return nil, myerror.ApplicationError{
+			Message: fmt.Sprintf("gacha not found. gachaID=%s", serviceRequest.GachaID),
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	// 開催期間外のガチャは実行できない
+	now := time.Now()
+	if now.Before(gacha.StartAt) || !now.Before(gacha.EndAt) {
+		return nil, myerror.ApplicationError{
+			Message: fmt.Sprintf("gacha is not in session. gachaID=%s", gacha.ID),
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	// 支払い方法のバリデーション
+	switch serviceRequest.Payment {
+	case constant.GachaPaymentCoin, constant.GachaPaymentTicket:
+	case constant.GachaPaymentFree:
+		if gacha.Mode != constant.GachaModeNormal || serviceRequest.Times != 1 {
+			return nil, myerror.ApplicationError{
+				Message: fmt.Sprintf("free draw is only available for single draw of normal gacha. gachaID=%s, times=%d", gacha.ID, serviceRequest.Times),
+				Code:    http.StatusBadRequest,
 			}
 		}
-		gottenCollectionItemIDSlice = append(gottenCollectionItemIDSlice, gottenCollectionItemID)
+	default:
+		return nil, myerror.ApplicationError{
+			Message: fmt.Sprintf("invalid payment. payment=%s", serviceRequest.Payment),
+			Code:    http.StatusBadRequest,
+		}
 	}
 
-	// ユーザの全所持アイテムを取得
-	userCollectionItems, err := s.UserCollectionItemRepository.SelectUserCollectionItemsByUserID(serviceRequest.UserID)
+	// 排出対象の取得
+	lottery, err := s.getGachaLottery(gacha)
 	if err != nil {
 		return nil, err
 	}
-	userCollectionItemIDMap := make(map[string]struct{}, len(userCollectionItems)+serviceRequest.Times) // ユーザの所持アイテムのidを入れるマップ
-	for _, userCollectionItem := range userCollectionItems {
-		userCollectionItemIDMap[userCollectionItem.CollectionItemID] = struct{}{}
+	if lottery.normalPool.ratioSum <= 0 {
+		return nil, fmt.Errorf("gacha probability is not registered. gachaID=%s", gacha.ID)
 	}
 
-	newUserCollectionItemSlice := make([]*model.UserCollectionItem, 0, serviceRequest.Times) // Newアイテムを入れるスライス
-	allCollectionItems, err := s.CollectionItemRepository.SelectCollectionItemAll()          // 全アイテムのスライス
+	// トランザクション開始
+	tx, err := db.Conn.Begin()
 	if err != nil {
 		return nil, err
 	}
-	allCollectionItemMap := make(map[string]*model.CollectionItem, len(allCollectionItems)) // idをキーにした全アイテムのマップ
-	for _, collectionItem := range allCollectionItems {
-		allCollectionItemMap[collectionItem.ID] = collectionItem
+
+	res, err := s.drawGacha(tx, serviceRequest, gacha, lottery, now)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			log.Println(fmt.Sprintf("Rollback Error in drawing gacha: %s", rollbackErr))
+		}
+		return nil, err
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return nil, commitErr
+	}
+	return res, nil
+}
+
+// drawGacha トランザクション内で排出アイテムを決定し、支払い・獲得アイテム・実行履歴・進捗を更新する
+func (s *GachaService) drawGacha(tx *sql.Tx, serviceRequest *DrawGachaRequest, gacha *model.Gacha, lottery *gachaLottery, now time.Time) (*DrawGachaResponse, error) {
+	// ユーザ情報を排他ロック
+	user, err := s.UserRepository.SelectUserByPrimaryKeyForUpdate(tx, serviceRequest.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, myerror.ApplicationError{
+			Message: fmt.Sprintf("user not found. userID=%s", serviceRequest.UserID),
+			Code:    http.StatusBadRequest,
+		}
 	}
-	var results []*GachaResult
-	// 排出アイテムと所持アイテムを比較してNewアイテムをマップに格納
-	for _, gottenCollectionItemID := range gottenCollectionItemIDSlice {
+
+	// 乱数シードの生成
+	// シードとガチャの進捗状態が同じであれば同じ排出結果を再現できる
+	seed, err := s.SeedSource.Seed()
+	if err != nil {
+		return nil, err
+	}
+
+	// ガチャの種類に応じて排出アイテムを決定
+	var outcome *gachaDrawOutcome
+	switch gacha.Mode {
+	case constant.GachaModeBox:
+		outcome, err = s.drawBoxGacha(tx, serviceRequest, gacha, lottery, seed)
+	case constant.GachaModeStepUp:
+		outcome, err = s.drawStepUpGacha(tx, serviceRequest, gacha, lottery, seed)
+	default:
+		outcome, err = s.drawNormalGacha(tx, serviceRequest, gacha, lottery, seed)
+	}
+	if err != nil {
+		return nil, err
+	}
+	gottenCollectionItemIDSlice := outcome.collectionItemIDs
+	initialStep, boxDrawnCounts, err := encodeGachaDrawState(outcome.initialState)
+	if err != nil {
+		return nil, err
+	}
+
+	// 支払い方法に応じた消費. チケット・無料ガチャの場合はコインを消費しない
+	var (
+		gachaCoinConsumptionSum int                      // 消費コインの合計
+		userGachaTicket         *model.UserGachaTicket   // 更新後のチケット所持数(チケット払い以外はnil)
+		userGachaFreeDraw       *model.UserGachaFreeDraw // 更新後の無料ガチャ実行日時(無料ガチャ以外はnil)
+	)
+	switch serviceRequest.Payment {
+	case constant.GachaPaymentTicket:
+		userGachaTicket, err = s.consumeGachaTicket(tx, serviceRequest.UserID, len(gottenCollectionItemIDSlice))
+	case constant.GachaPaymentFree:
+		userGachaFreeDraw, err = s.consumeGachaFreeDraw(tx, serviceRequest.UserID, now)
+	default:
+		gachaCoinConsumptionSum = outcome.coinConsumption
+		// 所持コインが足りない場合のバリデーション
+		if user.Coin < gachaCoinConsumptionSum {
+			err = myerror.ApplicationError{
+				Message: fmt.Sprintf("your coin is not enought. your coin=%s", strconv.Itoa(user.Coin)),
+				Code:    http.StatusBadRequest,
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	coinResult := user.Coin - gachaCoinConsumptionSum // ガチャ実行後の所持コイン
+
+	// ユーザの全所持アイテムを排他ロックで取得
+	userCollectionItems, err := s.UserCollectionItemRepository.SelectUserCollectionItemsByUserIDForUpdate(tx, serviceRequest.UserID)
+	if err != nil {
+		return nil, err
+	}
+	userCollectionItemMap := make(map[string]*model.UserCollectionItem, len(userCollectionItems)+len(gottenCollectionItemIDSlice)) // ユーザの所持アイテムを入れるマップ
+	for _, userCollectionItem := range userCollectionItems {
+		userCollectionItemMap[userCollectionItem.CollectionItemID] = userCollectionItem
+	}
+
+	upsertUserCollectionItemSlice := make([]*model.UserCollectionItem, 0, len(gottenCollectionItemIDSlice)) // 獲得数を更新するアイテムを入れるスライス
+	upsertedCollectionItemIDMap := make(map[string]struct{}, len(gottenCollectionItemIDSlice))              // 更新対象に加えたアイテムのidを入れるマップ
+	gachaDrawHistorySlice := make([]*model.GachaDrawHistory, 0, len(gottenCollectionItemIDSlice))           // ガチャ実行履歴を入れるスライス
+	var (
+		results     []*GachaResult
+		shardResult = user.Shard // ガチャ実行後の所持シャード
+	)
+	// 排出アイテムと所持アイテムを比較して獲得数を更新
+	for i, gottenCollectionItemID := range gottenCollectionItemIDSlice {
+		collectionItem := lottery.collectionItemMap[gottenCollectionItemID]
 		isNew := false
-		if _, ok := userCollectionItemIDMap[gottenCollectionItemID]; !ok { // 既出アイテムかを確認
+		shard := 0
+		userCollectionItem, ok := userCollectionItemMap[gottenCollectionItemID]
+		if !ok { // 既出アイテムかを確認
 			isNew = true
-			userCollectionItemIDMap[gottenCollectionItemID] = struct{}{}
-			newUserCollectionItem := &model.UserCollectionItem{
+			userCollectionItem = &model.UserCollectionItem{
 				UserID:           serviceRequest.UserID,
 				CollectionItemID: gottenCollectionItemID,
+				FirstAcquiredAt:  now,
 			}
-			newUserCollectionItemSlice = append(newUserCollectionItemSlice, newUserCollectionItem)
+			userCollectionItemMap[gottenCollectionItemID] = userCollectionItem
+		} else {
+			// 重複アイテムはレアリティに応じてシャードへ変換
+			shard = constant.DuplicateShardConversion[collectionItem.Rarity]
+			shardResult += shard
+		}
+		// 同じアイテムが複数回排出された場合も更新対象には1回のみ加える
+		if _, ok := upsertedCollectionItemIDMap[gottenCollectionItemID]; !ok {
+			upsertedCollectionItemIDMap[gottenCollectionItemID] = struct{}{}
+			upsertUserCollectionItemSlice = append(upsertUserCollectionItemSlice, userCollectionItem)
 		}
+		userCollectionItem.Count++
+		userCollectionItem.Level = collectionItemLevel(userCollectionItem.Count)
+		userCollectionItem.LastAcquiredAt = now
 		// レスポンスデータを整形
-		collectionItem := allCollectionItemMap[gottenCollectionItemID]
 		gachaResult := &GachaResult{
 			CollectionID: collectionItem.ID,
 			Name:         collectionItem.Name,
 			Rarity:       collectionItem.Rarity,
 			IsNew:        isNew,
+			Shard:        shard,
+			Count:        userCollectionItem.Count,
+			Level:        userCollectionItem.Level,
 		}
 		results = append(results, gachaResult)
+
+		gachaDrawHistorySlice = append(gachaDrawHistorySlice, &model.GachaDrawHistory{
+			UserID:           serviceRequest.UserID,
+			GachaID:          gacha.ID,
+			CollectionItemID: collectionItem.ID,
+			Rarity:           collectionItem.Rarity,
+			CoinConsumption:  splitCoinConsumption(gachaCoinConsumptionSum, len(gottenCollectionItemIDSlice), i),
+			Payment:          serviceRequest.Payment,
+			Seed:             seed,
+			DrawIndex:        i,
+			PityCount:        outcome.initialState.pityCount,
+			Step:             initialStep,
+			BoxDrawnCounts:   boxDrawnCounts,
+			CreatedAt:        now,
+		})
 	}
 
-	// トランザクション開始
-	tx, err := db.Conn.Begin()
+	// 獲得アイテムの獲得数・レベルを更新
+	if len(upsertUserCollectionItemSlice) >= 1 {
+		if err = s.UserCollectionItemRepository.BulkUpsertUserCollectionItem(tx, upsertUserCollectionItemSlice); err != nil {
+			return nil, err
+		}
+	}
+
+	// ガチャ実行履歴の登録
+	if err = s.GachaDrawHistoryRepository.BulkInsertGachaDrawHistory(tx, gachaDrawHistorySlice); err != nil {
+		return nil, err
+	}
+
+	// 天井カウントの更新
+	if outcome.userGachaPity != nil {
+		if err = s.UserGachaPityRepository.UpsertUserGachaPity(tx, outcome.userGachaPity); err != nil {
+			return nil, err
+		}
+	}
+
+	// ステップアップガチャの進捗の更新
+	if outcome.userGachaStep != nil {
+		if err = s.UserGachaStepRepository.UpsertUserGachaStep(tx, outcome.userGachaStep); err != nil {
+			return nil, err
+		}
+	}
+
+	// ボックスガチャの排出済み個数の更新
+	if len(outcome.userGachaBoxItems) >= 1 {
+		if err = s.UserGachaBoxItemRepository.BulkUpsertUserGachaBoxItem(tx, outcome.userGachaBoxItems); err != nil {
+			return nil, err
+		}
+	}
+
+	// ガチャチケット所持数の更新
+	if userGachaTicket != nil {
+		if err = s.UserGachaTicketRepository.UpsertUserGachaTicket(tx, userGachaTicket); err != nil {
+			return nil, err
+		}
+	}
+
+	// 無料ガチャ実行日時の更新
+	if userGachaFreeDraw != nil {
+		if err = s.UserGachaFreeDrawRepository.UpsertUserGachaFreeDraw(tx, userGachaFreeDraw); err != nil {
+			return nil, err
+		}
+	}
+
+	// コインの消費と重複アイテム分のシャードの付与
+	if err = s.UserRepository.UpdateUserCoinAndShardByPrimaryKey(tx, serviceRequest.UserID, coinResult, shardResult); err != nil {
+		return nil, err
+	}
+
+	return &DrawGachaResponse{GachaResults: results, Seed: seed}, nil
+}
+
+// gachaDrawOutcome ガチャの種類ごとの排出結果と更新後の進捗
+type gachaDrawOutcome struct {
+	collectionItemIDs []string
+	coinConsumption   int                       // 消費コインの合計
+	userGachaPity     *model.UserGachaPity      // 更新後の天井カウント(更新しない場合はnil)
+	userGachaStep     *model.UserGachaStep      // 更新後のステップ(ステップアップガチャ以外はnil)
+	userGachaBoxItems []*model.UserGachaBoxItem // 更新後の排出済み個数(ボックスガチャ以外はnil)
+	initialState      *gachaDrawState           // 実行前の進捗状態
+}
+
+// drawNormalGacha 通常ガチャの排出アイテムを決定する
+func (s *GachaService) drawNormalGacha(tx *sql.Tx, serviceRequest *DrawGachaRequest, gacha *model.Gacha, lottery *gachaLottery, seed int64) (*gachaDrawOutcome, error) {
+	userGachaPity, err := s.selectUserGachaPity(tx, serviceRequest.UserID, gacha.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	// ユーザ情報を排他ロック
-	user, err := s.UserRepository.SelectUserByPrimaryKeyForUpdate(tx, serviceRequest.UserID)
+	log.Println(fmt.Sprintf("draw gacha. userID=%s, gachaID=%s, mode=%s, times=%d, pityCount=%d, seed=%d",
+		serviceRequest.UserID, gacha.ID, gacha.Mode, serviceRequest.Times, userGachaPity.Count, seed))
+	initialState := &gachaDrawState{pityCount: userGachaPity.Count}
+	gottenCollectionItemIDSlice, pityCount := lottery.draw(random.New(seed), serviceRequest.Times, userGachaPity.Count)
+	userGachaPity.Count = pityCount
+
+	return &gachaDrawOutcome{
+		collectionItemIDs: gottenCollectionItemIDSlice,
+		coinConsumption:   gacha.CoinConsumption * serviceRequest.Times,
+		userGachaPity:     userGachaPity,
+		initialState:      initialState,
+	}, nil
+}
+
+// drawStepUpGacha ステップアップガチャの排出アイテムを決定する
+// 実行回数・消費コイン・保証レアリティは現在のステップの設定に従い、最終ステップの次は最初のステップに戻る
+func (s *GachaService) drawStepUpGacha(tx *sql.Tx, serviceRequest *DrawGachaRequest, gacha *model.Gacha, lottery *gachaLottery, seed int64) (*gachaDrawOutcome, error) {
+	gachaSteps, err := s.GachaStepRepository.SelectGachaStepsByGachaID(gacha.ID)
 	if err != nil {
 		return nil, err
 	}
-	// 消費コインの計算
-	gachaCoinConsumptionSum := constant.GachaCoinConsumption * serviceRequest.Times
-	coinResult := user.Coin - gachaCoinConsumptionSum // ガチャ実行後の所持コイン
+	if len(gachaSteps) == 0 {
+		return nil, fmt.Errorf("gacha step is not registered. gachaID=%s", gacha.ID)
+	}
+
+	userGachaStep, err := s.UserGachaStepRepository.SelectUserGachaStepByPrimaryKeyForUpdate(tx, serviceRequest.UserID, gacha.ID)
+	if err != nil {
+		return nil, err
+	}
+	if userGachaStep == nil || userGachaStep.Step < 1 || len(gachaSteps) < userGachaStep.Step {
+		userGachaStep = &model.UserGachaStep{
+			UserID:  serviceRequest.UserID,
+			GachaID: gacha.ID,
+			Step:    1,
+		}
+	}
+	gachaStep := gachaSteps[userGachaStep.Step-1]
 
-	// 所持コインが足りない場合のバリデーション
-	if user.Coin < gachaCoinConsumptionSum {
-		coinShortageErr := myerror.ApplicationError{
-			Message: fmt.Sprintf("your coin is not enought. your coin=%s", strconv.Itoa(user.Coin)),
+	// 想定外のコイン消費を防ぐため、実行回数は現在のステップの回数と一致している必要がある
+	if serviceRequest.Times != gachaStep.Times {
+		return nil, myerror.ApplicationError{
+			Message: fmt.Sprintf("gacha draw times does not match the step. step=%d, times=%d, want=%d", gachaStep.Step, serviceRequest.Times, gachaStep.Times),
 			Code:    http.StatusBadRequest,
 		}
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			log.Println(fmt.Sprintf("Rollback Error in validating possessed coin: %s", rollbackErr))
+	}
+
+	userGachaPity, err := s.selectUserGachaPity(tx, serviceRequest.UserID, gacha.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Println(fmt.Sprintf("draw gacha. userID=%s, gachaID=%s, mode=%s, step=%d, times=%d, pityCount=%d, seed=%d",
+		serviceRequest.UserID, gacha.ID, gacha.Mode, gachaStep.Step, gachaStep.Times, userGachaPity.Count, seed))
+	initialState := &gachaDrawState{pityCount: userGachaPity.Count, step: gachaStep}
+	gottenCollectionItemIDSlice, pityCount := lottery.drawStepUp(random.New(seed), gachaStep.Times, userGachaPity.Count, gachaStep.GuaranteedRarity)
+	userGachaPity.Count = pityCount
+
+	// 次のステップへ進める
+	userGachaStep.Step = userGachaStep.Step%len(gachaSteps) + 1
+
+	return &gachaDrawOutcome{
+		collectionItemIDs: gottenCollectionItemIDSlice,
+		coinConsumption:   gachaStep.CoinConsumption,
+		userGachaPity:     userGachaPity,
+		userGachaStep:     userGachaStep,
+		initialState:      initialState,
+	}, nil
+}
+
+// drawBoxGacha ボックスガチャの排出アイテムを決定する
+// ボックスの残り個数を超えて引くことはできない
+func (s *GachaService) drawBoxGacha(tx *sql.Tx, serviceRequest *DrawGachaRequest, gacha *model.Gacha, lottery *gachaLottery, seed int64) (*gachaDrawOutcome, error) {
+	userGachaBoxItems, err := s.UserGachaBoxItemRepository.SelectUserGachaBoxItemsByUserIDAndGachaIDForUpdate(tx, serviceRequest.UserID, gacha.ID)
+	if err != nil {
+		return nil, err
+	}
+	drawnCountMap := make(map[string]int, len(userGachaBoxItems)) // アイテムごとの排出済み個数
+	for _, userGachaBoxItem := range userGachaBoxItems {
+		drawnCountMap[userGachaBoxItem.CollectionItemID] = userGachaBoxItem.DrawnCount
+	}
+
+	if remaining := lottery.boxRemaining(drawnCountMap); remaining < serviceRequest.Times {
+		return nil, myerror.ApplicationError{
+			Message: fmt.Sprintf("gacha box does not have enough items. remaining=%d, times=%d", remaining, serviceRequest.Times),
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	log.Println(fmt.Sprintf("draw gacha. userID=%s, gachaID=%s, mode=%s, times=%d, seed=%d",
+		serviceRequest.UserID, gacha.ID, gacha.Mode, serviceRequest.Times, seed))
+	// drawBoxは排出済み個数を更新するため、実行前の状態は複製して残す
+	initialState := &gachaDrawState{boxDrawnCounts: make(map[string]int, len(drawnCountMap))}
+	for collectionItemID, drawnCount := range drawnCountMap {
+		initialState.boxDrawnCounts[collectionItemID] = drawnCount
+	}
+	gottenCollectionItemIDSlice := lottery.drawBox(random.New(seed), serviceRequest.Times, drawnCountMap)
+
+	updatedBoxItems := make([]*model.UserGachaBoxItem, 0, len(drawnCountMap))
+	for collectionItemID, drawnCount := range drawnCountMap {
+		updatedBoxItems = append(updatedBoxItems, &model.UserGachaBoxItem{
+			UserID:           serviceRequest.UserID,
+			GachaID:          gacha.ID,
+			CollectionItemID: collectionItemID,
+			DrawnCount:       drawnCount,
+		})
+	}
+
+	return &gachaDrawOutcome{
+		collectionItemIDs: gottenCollectionItemIDSlice,
+		coinConsumption:   gacha.CoinConsumption * serviceRequest.Times,
+		userGachaBoxItems: updatedBoxItems,
+		initialState:      initialState,
+	}, nil
+}
+
+// selectUserGachaPity 排他ロックで天井カウントを取得する. 未登録の場合は0回として扱う
+func (s *GachaService) selectUserGachaPity(tx *sql.Tx, userID string, gachaID string) (*model.UserGachaPity, error) {
+	userGachaPity, err := s.UserGachaPityRepository.SelectUserGachaPityByPrimaryKeyForUpdate(tx, userID, gachaID)
+	if err != nil {
+		return nil, err
+	}
+	if userGachaPity == nil {
+		userGachaPity = &model.UserGachaPity{
+			UserID:  userID,
+			GachaID: gachaID,
+		}
+	}
+	return userGachaPity, nil
+}
+
+// consumeGachaTicket 排他ロックでガチャチケットの所持数を取得し、消費後の所持数を返す
+func (s *GachaService) consumeGachaTicket(tx *sql.Tx, userID string, count int) (*model.UserGachaTicket, error) {
+	userGachaTicket, err := s.UserGachaTicketRepository.SelectUserGachaTicketByPrimaryKeyForUpdate(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if userGachaTicket == nil || userGachaTicket.Count < count {
+		possessed := 0
+		if userGachaTicket != nil {
+			possessed = userGachaTicket.Count
 		}
-		return nil, coinShortageErr
+		return nil, myerror.ApplicationError{
+			Message: fmt.Sprintf("your gacha ticket is not enough. your ticket=%d", possessed),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	userGachaTicket.Count -= count
+	return userGachaTicket, nil
+}
+
+// consumeGachaFreeDraw 排他ロックで無料ガチャの最終実行日時を取得し、本日分が未実行であれば実行日時を更新して返す
+func (s *GachaService) consumeGachaFreeDraw(tx *sql.Tx, userID string, now time.Time) (*model.UserGachaFreeDraw, error) {
+	userGachaFreeDraw, err := s.UserGachaFreeDrawRepository.SelectUserGachaFreeDrawByPrimaryKeyForUpdate(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if userGachaFreeDraw != nil && !userGachaFreeDraw.LastDrawAt.Before(dailyResetTime(now)) {
+		return nil, myerror.ApplicationError{
+			Message: fmt.Sprintf("free draw has already been used today. lastDrawAt=%s", userGachaFreeDraw.LastDrawAt.Format(time.RFC3339)),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	return &model.UserGachaFreeDraw{
+		UserID:     userID,
+		LastDrawAt: now,
+	}, nil
+}
+
+// encodeGachaDrawState 実行前の進捗状態をガチャ実行履歴に記録する形式に変換する
+func encodeGachaDrawState(state *gachaDrawState) (int, string, error) {
+	step := 0
+	if state.step != nil {
+		step = state.step.Step
+	}
+	if state.boxDrawnCounts == nil {
+		return step, "", nil
+	}
+	boxDrawnCounts, err := json.Marshal(state.boxDrawnCounts)
+	if err != nil {
+		return 0, "", err
+	}
+	return step, string(boxDrawnCounts), nil
+}
+
+// ReplayGachaDraw ガチャ実行履歴に記録したシードと実行前の進捗状態から排出アイテムを再現する
+// 排出確率などのマスタデータは現在のものを利用するため、実行後にマスタデータが更新された場合は再現できない
+func (s *GachaService) ReplayGachaDraw(gachaDrawHistory *model.GachaDrawHistory) (string, error) {
+	gacha, err := s.GachaRepository.SelectGachaByPrimaryKey(gachaDrawHistory.GachaID)
+	if err != nil {
+		return "", err
+	}
+	if gacha == nil {
+		return "", fmt.Errorf("gacha not found. gachaID=%s", gachaDrawHistory.GachaID)
+	}
+	lottery, err := s.getGachaLottery(gacha)
+	if err != nil {
+		return "", err
 	}
 
-	// Newアイテムがある時のみ登録
-	if len(newUserCollectionItemSlice) >= 1 {
-		if err = s.UserCollectionItemRepository.BulkInsertUserCollectionItem(tx, newUserCollectionItemSlice); err != nil {
-			if rollbackErr := tx.Rollback(); rollbackErr != nil {
-				log.Println(fmt.Sprintf("Rollback Error in inserting to user_collection_item table: %s", rollbackErr))
+	state := &gachaDrawState{pityCount: gachaDrawHistory.PityCount}
+	switch gacha.Mode {
+	case constant.GachaModeStepUp:
+		gachaSteps, err := s.GachaStepRepository.SelectGachaStepsByGachaID(gacha.ID)
+		if err != nil {
+			return "", err
+		}
+		for _, gachaStep := range gachaSteps {
+			if gachaStep.Step == gachaDrawHistory.Step {
+				state.step = gachaStep
 			}
-			return nil, err
+		}
+	case constant.GachaModeBox:
+		if err = json.Unmarshal([]byte(gachaDrawHistory.BoxDrawnCounts), &state.boxDrawnCounts); err != nil {
+			return "", err
 		}
 	}
 
-	// コインの消費
-	if err := s.UserRepository.UpdateUserCoinByPrimaryKey(tx, serviceRequest.UserID, coinResult); err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			log.Println(fmt.Sprintf("Rollback Error in updating user coin: %s", rollbackErr))
+	collectionItemID, ok := lottery.replay(gacha.Mode, gachaDrawHistory.Seed, state, gachaDrawHistory.DrawIndex)
+	if !ok {
+		return "", fmt.Errorf("gacha draw cannot be replayed. gachaDrawHistoryID=%d", gachaDrawHistory.ID)
+	}
+	return collectionItemID, nil
+}
+
+// splitCoinConsumption 消費コインの合計を排出アイテムごとに按分する. 端数は先頭のアイテムに加える
+func splitCoinConsumption(coinConsumptionSum int, count int, index int) int {
+	coinConsumption := coinConsumptionSum / count
+	if index == 0 {
+		coinConsumption += coinConsumptionSum % count
+	}
+	return coinConsumption
+}
+
+// ResetGachaBox ボックスガチャのリセットのロジック
+func (s *GachaService) ResetGachaBox(serviceRequest *ResetGachaBoxRequest) error {
+	gacha, err := s.GachaRepository.SelectGachaByPrimaryKey(serviceRequest.GachaID)
+	if err != nil {
+		return err
+	}
+	if gacha == nil || gacha.Mode != constant.GachaModeBox {
+		return myerror.ApplicationError{
+			Message: fmt.Sprintf("box gacha not found. gachaID=%s", serviceRequest.GachaID),
+			Code:    http.StatusBadRequest,
 		}
+	}
+
+	// 排出済み個数を削除するとボックスの中身が初期状態に戻る
+	return s.UserGachaBoxItemRepository.DeleteUserGachaBoxItemsByUserIDAndGachaID(serviceRequest.UserID, gacha.ID)
+}
+
+// GetGachaHistory ガチャ実行履歴取得のロジック
+func (s *GachaService) GetGachaHistory(serviceRequest *GetGachaHistoryRequest) (*GetGachaHistoryResponse, error) {
+	// 新しい順に指定位置から指定件数の履歴を取得
+	gachaDrawHistories, err := s.GachaDrawHistoryRepository.SelectGachaDrawHistoriesByUserID(serviceRequest.UserID, serviceRequest.Limit, serviceRequest.Offset)
+	if err != nil {
 		return nil, err
 	}
 
-	if commitErr := tx.Commit(); commitErr != nil {
-		return nil, commitErr
+	allCollectionItems, err := s.CollectionItemRepository.SelectCollectionItemAll()
+	if err != nil {
+		return nil, err
+	}
+	allCollectionItemMap := make(map[string]*model.CollectionItem, len(allCollectionItems)) // idをキーにした全アイテムのマップ
+	for _, collectionItem := range allCollectionItems {
+		allCollectionItemMap[collectionItem.ID] = collectionItem
+	}
+
+	gachaHistories := make([]*GachaHistory, 0, len(gachaDrawHistories))
+	for _, gachaDrawHistory := range gachaDrawHistories {
+		gachaHistory := &GachaHistory{
+			GachaID:         gachaDrawHistory.GachaID,
+			CollectionID:    gachaDrawHistory.CollectionItemID,
+			Rarity:          gachaDrawHistory.Rarity,
+			CoinConsumption: gachaDrawHistory.CoinConsumption,
+			Payment:         gachaDrawHistory.Payment,
+			DrawnAt:         gachaDrawHistory.CreatedAt,
+		}
+		if collectionItem, ok := allCollectionItemMap[gachaDrawHistory.CollectionItemID]; ok {
+			gachaHistory.Name = collectionItem.Name
+		}
+		gachaHistories = append(gachaHistories, gachaHistory)
+	}
+
+	return &GetGachaHistoryResponse{GachaHistories: gachaHistories}, nil
+}
+
+// GetGachaRates ガチャ排出確率取得のロジック
+func (s *GachaService) GetGachaRates(serviceRequest *GetGachaRatesRequest) (*GetGachaRatesResponse, error) {
+	gacha, err := s.GachaRepository.SelectGachaByPrimaryKey(serviceRequest.GachaID)
+	if err != nil {
+		return nil, err
+	}
+	if gacha == nil {
+		return nil, myerror.ApplicationError{
+			Message: fmt.Sprintf("gacha not found. gachaID=%s", serviceRequest.GachaID),
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	gachaProbabilities, err := s.GachaProbabilityRepository.SelectGachaProbabilitiesByGachaID(gacha.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	allCollectionItems, err := s.CollectionItemRepository.SelectCollectionItemAll()
+	if err != nil {
+		return nil, err
+	}
+	allCollectionItemMap := make(map[string]*model.CollectionItem, len(allCollectionItems)) // idをキーにした全アイテムのマップ
+	for _, collectionItem := range allCollectionItems {
+		allCollectionItemMap[collectionItem.ID] = collectionItem
+	}
+
+	// 排出確率はratio/全体のratio合計で計算する
+	var ratioSum int                    // ratioの合計
+	rarityRatioMap := make(map[int]int) // レアリティごとのratio合計
+	targetProbabilities := make([]*model.GachaProbability, 0, len(gachaProbabilities))
+	for _, gachaProbability := range gachaProbabilities {
+		collectionItem, ok := allCollectionItemMap[gachaProbability.CollectionItemId]
+		if !ok {
+			continue
+		}
+		ratioSum += gachaProbability.Ratio
+		rarityRatioMap[collectionItem.Rarity] += gachaProbability.Ratio
+		targetProbabilities = append(targetProbabilities, gachaProbability)
+	}
+	if ratioSum <= 0 {
+		return nil, fmt.Errorf("gacha probability is not registered. gachaID=%s", gacha.ID)
+	}
+
+	itemRates := make([]*GachaItemRate, 0, len(targetProbabilities))
+	for _, gachaProbability := range targetProbabilities {
+		collectionItem := allCollectionItemMap[gachaProbability.CollectionItemId]
+		itemRates = append(itemRates, &GachaItemRate{
+			CollectionID: collectionItem.ID,
+			Name:         collectionItem.Name,
+			Rarity:       collectionItem.Rarity,
+			Rate:         ratioToPercentage(gachaProbability.Ratio, ratioSum),
+		})
+	}
+
+	rarityRates := make([]*GachaRarityRate, 0, len(rarityRatioMap))
+	for rarity, ratio := range rarityRatioMap {
+		rarityRates = append(rarityRates, &GachaRarityRate{
+			Rarity: rarity,
+			Rate:   ratioToPercentage(ratio, ratioSum),
+		})
+	}
+	sort.Slice(rarityRates, func(i, j int) bool {
+		return rarityRates[i].Rarity < rarityRates[j].Rarity
+	})
+
+	return &GetGachaRatesResponse{ItemRates: itemRates, RarityRates: rarityRates}, nil
+}
+
+// ratioToPercentage 重みを百分率に変換する
+func ratioToPercentage(ratio int, ratioSum int) float64 {
+	return float64(ratio) * 100 / float64(ratioSum)
+}
+
+// getGachaLottery ガチャの排出対象を取得する
+// ガチャの更新日時が変わっていなければキャッシュを利用し、変わっていればマスタデータから作り直す
+func (s *GachaService) getGachaLottery(gacha *model.Gacha) (*gachaLottery, error) {
+	if lottery, ok := s.lotteryCache.get(gacha); ok {
+		return lottery, nil
+	}
+
+	gachaProbabilities, err := s.GachaProbabilityRepository.SelectGachaProbabilitiesByGachaID(gacha.ID)
+	if err != nil {
+		return nil, err
+	}
+	allCollectionItems, err := s.CollectionItemRepository.SelectCollectionItemAll()
+	if err != nil {
+		return nil, err
+	}
+
+	lottery := newGachaLottery(gacha, gachaProbabilities, allCollectionItems)
+	s.lotteryCache.set(gacha.ID, lottery)
+	return lottery, nil
+}
+
+// GetGachaList 開催中のガチャ一覧取得のロジック
+func (s *GachaService) GetGachaList() (*GetGachaListResponse, error) {
+	gachas, err := s.GachaRepository.SelectGachasInSession(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	gachaInfoList := make([]*GachaInfo, 0, len(gachas))
+	for _, gacha := range gachas {
+		gachaInfoList = append(gachaInfoList, &GachaInfo{
+			GachaID:         gacha.ID,
+			Name:            gacha.Name,
+			CoinConsumption: gacha.CoinConsumption,
+			Mode:            gacha.Mode,
+			StartAt:         gacha.StartAt,
+			EndAt:           gacha.EndAt,
+		})
 	}
 
-	return &DrawGachaResponse{GachaResults: results}, err
+	return &GetGachaListResponse{Gachas: gachaInfoList}, nil
 }
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"20dojo-online/pkg/constant"
+	"20dojo-online/pkg/random"
+	"20dojo-online/pkg/server/model"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// gachaPool 排出対象のアイテムと重みの累積和
+type gachaPool struct {
+	collectionItemIDs []string
+	cumulativeRatios  []int // cumulativeRatios[i] = 先頭からi番目までのratioの合計
+	ratioSum          int
+}
+
+// newGachaPool 指定レアリティ以上のアイテムで排出対象を作成する
+func newGachaPool(gachaProbabilities []*model.GachaProbability, collectionItemMap map[string]*model.CollectionItem, minRarity int) *gachaPool {
+	pool := &gachaPool{
+		collectionItemIDs: make([]string, 0, len(gachaProbabilities)),
+		cumulativeRatios:  make([]int, 0, len(gachaProbabilities)),
+	}
+	for _, gachaProbability := range gachaProbabilities {
+		collectionItem, ok := collectionItemMap[gachaProbability.CollectionItemId]
+		if !ok || collectionItem.Rarity < minRarity || gachaProbability.Ratio <= 0 {
+			continue
+		}
+		pool.ratioSum += gachaProbability.Ratio
+		pool.collectionItemIDs = append(pool.collectionItemIDs, gachaProbability.CollectionItemId)
+		pool.cumulativeRatios = append(pool.cumulativeRatios, pool.ratioSum)
+	}
+	return pool
+}
+
+// draw 重みに従ってアイテムを1つ決定する
+// 累積和を二分探索するため、アイテム数nに対してO(log n)で決定できる
+func (p *gachaPool) draw(rnd *rand.Rand) string {
+	randomNum := rnd.Intn(p.ratioSum) // 0からratioの合計までの整数で乱数を生成
+	// 累積和がrandomNumを超える最初のアイテムが排出アイテム
+	index := sort.SearchInts(p.cumulativeRatios, randomNum+1)
+	return p.collectionItemIDs[index]
+}
+
+// gachaLottery ガチャごとの排出対象. マスタデータから作成してメモリにキャッシュする
+type gachaLottery struct {
+	updatedAt          time.Time // 作成元のガチャの更新日時
+	collectionItemMap  map[string]*model.CollectionItem
+	gachaProbabilities []*model.GachaProbability // 排出対象のアイテムの排出確率情報
+	normalPool         *gachaPool                // 通常排出
+	pityPool           *gachaPool                // 天井
+	guaranteedPool     *gachaPool                // 10連保証
+}
+
+// newGachaLottery ガチャ排出確率情報と全アイテムから排出対象を作成する
+func newGachaLottery(gacha *model.Gacha, gachaProbabilities []*model.GachaProbability, collectionItems []*model.CollectionItem) *gachaLottery {
+	collectionItemMap := make(map[string]*model.CollectionItem, len(collectionItems)) // idをキーにした全アイテムのマップ
+	for _, collectionItem := range collectionItems {
+		collectionItemMap[collectionItem.ID] = collectionItem
+	}
+	targetProbabilities := make([]*model.GachaProbability, 0, len(gachaProbabilities))
+	for _, gachaProbability := range gachaProbabilities {
+		if _, ok := collectionItemMap[gachaProbability.CollectionItemId]; ok && gachaProbability.Ratio > 0 {
+			targetProbabilities = append(targetProbabilities, gachaProbability)
+		}
+	}
+	return &gachaLottery{
+		updatedAt:          gacha.UpdatedAt,
+		collectionItemMap:  collectionItemMap,
+		gachaProbabilities: targetProbabilities,
+		normalPool:         newGachaPool(gachaProbabilities, collectionItemMap, 0),
+		pityPool:           newGachaPool(gachaProbabilities, collectionItemMap, constant.GachaPityRarity),
+		guaranteedPool:     newGachaPool(gachaProbabilities, collectionItemMap, constant.GachaGuaranteedRarity),
+	}
+}
+
+// draw 天井と10連保証を考慮して排出アイテムを決定し、更新後の天井カウントと合わせて返す
+func (l *gachaLottery) draw(rnd *rand.Rand, times int, pityCount int) ([]string, int) {
+	gottenCollectionItemIDSlice := make([]string, 0, times) // 排出アイテムのidを入れるスライス
+	hasGuaranteedRarity := false                            // 10連の中で保証レアリティ以上が排出済みか
+	for i := 0; i < times; i++ {
+		if i%constant.GachaGuaranteedTimes == 0 {
+			hasGuaranteedRarity = false
+		}
+
+		pool := l.normalPool
+		switch {
+		case pityCount >= constant.GachaPityThreshold && l.pityPool.ratioSum > 0:
+			// 天井に到達したら最高レアリティを確定で排出
+			pool = l.pityPool
+		case i%constant.GachaGuaranteedTimes == constant.GachaGuaranteedTimes-1 && !hasGuaranteedRarity && l.guaranteedPool.ratioSum > 0:
+			// 10連の最後まで保証レアリティ以上が出ていなければ確定で排出
+			pool = l.guaranteedPool
+		}
+		gottenCollectionItemID := pool.draw(rnd)
+
+		rarity := l.collectionItemMap[gottenCollectionItemID].Rarity
+		if rarity >= constant.GachaGuaranteedRarity {
+			hasGuaranteedRarity = true
+		}
+		if rarity >= constant.GachaPityRarity {
+			pityCount = 0
+		} else {
+			pityCount++
+		}
+		gottenCollectionItemIDSlice = append(gottenCollectionItemIDSlice, gottenCollectionItemID)
+	}
+	return gottenCollectionItemIDSlice, pityCount
+}
+
+// drawStepUp ステップアップガチャの排出アイテムを決定する
+// 通常の排出に加えて、ステップで指定されたレアリティ以上が出なかった場合は最後の1つを確定で排出する
+func (l *gachaLottery) drawStepUp(rnd *rand.Rand, times int, pityCount int, guaranteedRarity int) ([]string, int) {
+	initialPityCount := pityCount
+	gottenCollectionItemIDSlice, pityCount := l.draw(rnd, times, pityCount)
+	if guaranteedRarity <= 0 || len(gottenCollectionItemIDSlice) == 0 {
+		return gottenCollectionItemIDSlice, pityCount
+	}
+
+	for _, gottenCollectionItemID := range gottenCollectionItemIDSlice {
+		if l.collectionItemMap[gottenCollectionItemID].Rarity >= guaranteedRarity {
+			return gottenCollectionItemIDSlice, pityCount
+		}
+	}
+	stepPool := newGachaPool(l.gachaProbabilities, l.collectionItemMap, guaranteedRarity)
+	if stepPool.ratioSum <= 0 {
+		return gottenCollectionItemIDSlice, pityCount
+	}
+	gottenCollectionItemIDSlice[len(gottenCollectionItemIDSlice)-1] = stepPool.draw(rnd)
+
+	// 最後の1つを差し替えたので天井カウントを計算し直す
+	pityCount = initialPityCount
+	for _, gottenCollectionItemID := range gottenCollectionItemIDSlice {
+		if l.collectionItemMap[gottenCollectionItemID].Rarity >= constant.GachaPityRarity {
+			pityCount = 0
+		} else {
+			pityCount++
+		}
+	}
+	return gottenCollectionItemIDSlice, pityCount
+}
+
+// boxRemaining ボックスの残り個数を返す. ratioをボックス内の個数として扱う
+func (l *gachaLottery) boxRemaining(drawnCountMap map[string]int) int {
+	remaining := 0
+	for _, gachaProbability := range l.gachaProbabilities {
+		if count := gachaProbability.Ratio - drawnCountMap[gachaProbability.CollectionItemId]; count > 0 {
+			remaining += count
+		}
+	}
+	return remaining
+}
+
+// drawBox ボックスガチャの排出アイテムを決定する. ボックスの残り個数を重みとして非復元抽出し、drawnCountMapを更新する
+func (l *gachaLottery) drawBox(rnd *rand.Rand, times int, drawnCountMap map[string]int) []string {
+	gottenCollectionItemIDSlice := make([]string, 0, times) // 排出アイテムのidを入れるスライス
+	remaining := l.boxRemaining(drawnCountMap)
+	for i := 0; i < times && remaining > 0; i++ {
+		randomNum := rnd.Intn(remaining)
+		threshold := 0 // 閾値
+		for _, gachaProbability := range l.gachaProbabilities {
+			count := gachaProbability.Ratio - drawnCountMap[gachaProbability.CollectionItemId]
+			if count <= 0 {
+				continue
+			}
+			threshold += count
+			if randomNum < threshold {
+				drawnCountMap[gachaProbability.CollectionItemId]++
+				remaining--
+				gottenCollectionItemIDSlice = append(gottenCollectionItemIDSlice, gachaProbability.CollectionItemId)
+				break
+			}
+		}
+	}
+	return gottenCollectionItemIDSlice
+}
+
+// gachaDrawState ガチャ実行前の進捗状態. 乱数シードと合わせて履歴に記録し、排出結果の再現に利用する
+type gachaDrawState struct {
+	pityCount      int              // 天井カウント
+	step           *model.GachaStep // 実行したステップ(ステップアップガチャ以外はnil)
+	boxDrawnCounts map[string]int   // ボックスの排出済み個数(ボックスガチャ以外はnil)
+}
+
+// replay 実行前の進捗状態とシードから、同じ実行のdrawIndex番目(0始まり)の排出アイテムを再現する
+// 各排出はそれより後の排出に依存しないため、先頭からdrawIndex番目まで排出し直せばよい
+func (l *gachaLottery) replay(mode string, seed int64, state *gachaDrawState, drawIndex int) (string, bool) {
+	var gottenCollectionItemIDSlice []string
+	switch mode {
+	case constant.GachaModeBox:
+		drawnCountMap := make(map[string]int, len(state.boxDrawnCounts))
+		for collectionItemID, drawnCount := range state.boxDrawnCounts {
+			drawnCountMap[collectionItemID] = drawnCount
+		}
+		gottenCollectionItemIDSlice = l.drawBox(random.New(seed), drawIndex+1, drawnCountMap)
+	case constant.GachaModeStepUp:
+		// ステップの保証は最後の1つを差し替えるため、ステップの回数分排出し直す
+		if state.step == nil {
+			return "", false
+		}
+		gottenCollectionItemIDSlice, _ = l.drawStepUp(random.New(seed), state.step.Times, state.pityCount, state.step.GuaranteedRarity)
+	default:
+		gottenCollectionItemIDSlice, _ = l.draw(random.New(seed), drawIndex+1, state.pityCount)
+	}
+	if drawIndex < 0 || len(gottenCollectionItemIDSlice) <= drawIndex {
+		return "", false
+	}
+	return gottenCollectionItemIDSlice[drawIndex], true
+}
+
+// gachaLotteryCache ガチャIDをキーにした排出対象のキャッシュ
+type gachaLotteryCache struct {
+	mu        sync.RWMutex
+	lotteries map[string]*gachaLottery
+}
+
+func newGachaLotteryCache() *gachaLotteryCache {
+	return &gachaLotteryCache{
+		lotteries: make(map[string]*gachaLottery),
+	}
+}
+
+// get ガチャの更新日時が一致する排出対象を取得する. 更新されていればキャッシュは無効
+func (c *gachaLotteryCache) get(gacha *model.Gacha) (*gachaLottery, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	lottery, ok := c.lotteries[gacha.ID]
+	if !ok || !lottery.updatedAt.Equal(gacha.UpdatedAt) {
+		return nil, false
+	}
+	return lottery, true
+}
+
+// set 排出対象をキャッシュする
+func (c *gachaLotteryCache) set(gachaID string, lottery *gachaLottery) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lotteries[gachaID] = lottery
+}
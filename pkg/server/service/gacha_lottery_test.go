@@ -0,0 +1,330 @@
+package service
+
+import (
+	"20dojo-online/pkg/constant"
+	"20dojo-online/pkg/random"
+	"20dojo-online/pkg/server/model"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGachaLottery_Draw(t *testing.T) {
+	gacha := &model.Gacha{ID: "1", UpdatedAt: time.Unix(1598227200, 0)}
+	collectionItems := []*model.CollectionItem{
+		{ID: "1001", Name: "スゴリラ01", Rarity: 1},
+		{ID: "2001", Name: "レアスゴリラ01", Rarity: 2},
+		{ID: "3001", Name: "超スゴリラ01", Rarity: 3},
+	}
+	// 通常排出ではほぼ確実にレアリティ1が排出される重み
+	gachaProbabilities := []*model.GachaProbability{
+		{GachaID: "1", CollectionItemId: "1001", Ratio: 100000000},
+		{GachaID: "1", CollectionItemId: "2001", Ratio: 1},
+		{GachaID: "1", CollectionItemId: "3001", Ratio: 1},
+	}
+	lottery := newGachaLottery(gacha, gachaProbabilities, collectionItems)
+
+	type args struct {
+		times     int
+		pityCount int
+	}
+	tests := []struct {
+		name  string
+		args  args
+		check func(t *testing.T, gotIDs []string, gotPityCount int)
+	}{
+		{
+			name: "正常:天井到達で最高レアリティ確定",
+			args: args{
+				times:     1,
+				pityCount: constant.GachaPityThreshold,
+			},
+			check: func(t *testing.T, gotIDs []string, gotPityCount int) {
+				if gotIDs[0] != "3001" {
+					t.Errorf("draw() got = %v, want 3001", gotIDs[0])
+				}
+				if gotPityCount != 0 {
+					t.Errorf("draw() pityCount = %d, want 0", gotPityCount)
+				}
+			},
+		},
+		{
+			name: "正常:10連でレアリティ2以上を保証",
+			args: args{
+				times:     constant.GachaGuaranteedTimes * 2,
+				pityCount: 0,
+			},
+			check: func(t *testing.T, gotIDs []string, gotPityCount int) {
+				for i := 0; i < len(gotIDs); i += constant.GachaGuaranteedTimes {
+					hasGuaranteedRarity := false
+					for _, id := range gotIDs[i : i+constant.GachaGuaranteedTimes] {
+						if lottery.collectionItemMap[id].Rarity >= constant.GachaGuaranteedRarity {
+							hasGuaranteedRarity = true
+						}
+					}
+					if !hasGuaranteedRarity {
+						t.Errorf("draw() got = %v, want rarity %d or higher in every %d draws", gotIDs, constant.GachaGuaranteedRarity, constant.GachaGuaranteedTimes)
+					}
+				}
+			},
+		},
+		{
+			name: "正常:最高レアリティが出なければ天井カウントを加算",
+			args: args{
+				times:     1,
+				pityCount: 5,
+			},
+			check: func(t *testing.T, gotIDs []string, gotPityCount int) {
+				if gotIDs[0] == "3001" {
+					if gotPityCount != 0 {
+						t.Errorf("draw() pityCount = %d, want 0", gotPityCount)
+					}
+					return
+				}
+				if gotPityCount != 6 {
+					t.Errorf("draw() pityCount = %d, want 6", gotPityCount)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotIDs, gotPityCount := lottery.draw(random.New(1), tt.args.times, tt.args.pityCount)
+			if len(gotIDs) != tt.args.times {
+				t.Errorf("draw() len = %d, want %d", len(gotIDs), tt.args.times)
+				return
+			}
+			tt.check(t, gotIDs, gotPityCount)
+		})
+	}
+}
+
+func TestGachaLottery_Replay(t *testing.T) {
+	gacha := &model.Gacha{ID: "1", UpdatedAt: time.Unix(1598227200, 0)}
+	collectionItems := []*model.CollectionItem{
+		{ID: "1001", Name: "スゴリラ01", Rarity: 1},
+		{ID: "1002", Name: "スゴリラ02", Rarity: 1},
+		{ID: "2001", Name: "レアスゴリラ01", Rarity: 2},
+		{ID: "3001", Name: "超スゴリラ01", Rarity: 3},
+	}
+	gachaProbabilities := []*model.GachaProbability{
+		{GachaID: "1", CollectionItemId: "1001", Ratio: 6},
+		{GachaID: "1", CollectionItemId: "1002", Ratio: 6},
+		{GachaID: "1", CollectionItemId: "2001", Ratio: 3},
+		{GachaID: "1", CollectionItemId: "3001", Ratio: 1},
+	}
+	lottery := newGachaLottery(gacha, gachaProbabilities, collectionItems)
+	gachaStep := &model.GachaStep{GachaID: "1", Step: 2, Times: 5, GuaranteedRarity: 3}
+
+	tests := []struct {
+		name  string
+		mode  string
+		state *gachaDrawState
+		draw  func(rnd *rand.Rand, state *gachaDrawState) []string
+	}{
+		{
+			name:  "正常:天井到達を含む通常ガチャ",
+			mode:  constant.GachaModeNormal,
+			state: &gachaDrawState{pityCount: constant.GachaPityThreshold - 2},
+			draw: func(rnd *rand.Rand, state *gachaDrawState) []string {
+				gotIDs, _ := lottery.draw(rnd, 30, state.pityCount)
+				return gotIDs
+			},
+		},
+		{
+			name:  "正常:ステップアップガチャ",
+			mode:  constant.GachaModeStepUp,
+			state: &gachaDrawState{pityCount: 3, step: gachaStep},
+			draw: func(rnd *rand.Rand, state *gachaDrawState) []string {
+				gotIDs, _ := lottery.drawStepUp(rnd, state.step.Times, state.pityCount, state.step.GuaranteedRarity)
+				return gotIDs
+			},
+		},
+		{
+			name:  "正常:排出済みのアイテムがあるボックスガチャ",
+			mode:  constant.GachaModeBox,
+			state: &gachaDrawState{boxDrawnCounts: map[string]int{"1001": 4, "2001": 1}},
+			draw: func(rnd *rand.Rand, state *gachaDrawState) []string {
+				drawnCountMap := map[string]int{}
+				for collectionItemID, drawnCount := range state.boxDrawnCounts {
+					drawnCountMap[collectionItemID] = drawnCount
+				}
+				return lottery.drawBox(rnd, 8, drawnCountMap)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seedSource := random.NewSeededSource(20200824)
+			for i := 0; i < 10; i++ {
+				seed, err := seedSource.Seed()
+				if err != nil {
+					t.Fatalf("Seed() error = %v", err)
+				}
+				gotIDs := tt.draw(random.New(seed), tt.state)
+				// 記録したシードと実行前の進捗状態から、各排出を個別に再現できる
+				for drawIndex, gotID := range gotIDs {
+					replayID, ok := lottery.replay(tt.mode, seed, tt.state, drawIndex)
+					if !ok || replayID != gotID {
+						t.Errorf("replay() seed=%d, drawIndex=%d got = %v(%v), want %v", seed, drawIndex, replayID, ok, gotID)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGachaLottery_DrawStepUp(t *testing.T) {
+	gacha := &model.Gacha{ID: "4", Mode: constant.GachaModeStepUp, UpdatedAt: time.Unix(1598227200, 0)}
+	collectionItems := []*model.CollectionItem{
+		{ID: "1001", Name: "スゴリラ01", Rarity: 1},
+		{ID: "2001", Name: "レアスゴリラ01", Rarity: 2},
+		{ID: "3001", Name: "超スゴリラ01", Rarity: 3},
+	}
+	// 通常排出ではほぼ確実にレアリティ1が排出される重み
+	gachaProbabilities := []*model.GachaProbability{
+		{GachaID: "4", CollectionItemId: "1001", Ratio: 100000000},
+		{GachaID: "4", CollectionItemId: "2001", Ratio: 1},
+		{GachaID: "4", CollectionItemId: "3001", Ratio: 1},
+	}
+	lottery := newGachaLottery(gacha, gachaProbabilities, collectionItems)
+
+	gotIDs, gotPityCount := lottery.drawStepUp(random.New(1), 5, 0, 3)
+	if len(gotIDs) != 5 {
+		t.Fatalf("drawStepUp() len = %d, want 5", len(gotIDs))
+	}
+	// ステップの保証レアリティは最後の1つで排出される
+	if gotIDs[4] != "3001" {
+		t.Errorf("drawStepUp() got = %v, want 3001 at last", gotIDs)
+	}
+	if gotPityCount != 0 {
+		t.Errorf("drawStepUp() pityCount = %d, want 0", gotPityCount)
+	}
+}
+
+func TestGachaLottery_DrawBox(t *testing.T) {
+	gacha := &model.Gacha{ID: "3", Mode: constant.GachaModeBox, UpdatedAt: time.Unix(1598227200, 0)}
+	collectionItems := []*model.CollectionItem{
+		{ID: "1001", Name: "スゴリラ01", Rarity: 1},
+		{ID: "2001", Name: "レアスゴリラ01", Rarity: 2},
+		{ID: "3001", Name: "超スゴリラ01", Rarity: 3},
+	}
+	// ボックスガチャではratioをボックス内の個数として扱う
+	gachaProbabilities := []*model.GachaProbability{
+		{GachaID: "3", CollectionItemId: "1001", Ratio: 5},
+		{GachaID: "3", CollectionItemId: "2001", Ratio: 2},
+		{GachaID: "3", CollectionItemId: "3001", Ratio: 1},
+	}
+	lottery := newGachaLottery(gacha, gachaProbabilities, collectionItems)
+
+	drawnCountMap := map[string]int{"1001": 2}
+	if remaining := lottery.boxRemaining(drawnCountMap); remaining != 6 {
+		t.Fatalf("boxRemaining() = %d, want 6", remaining)
+	}
+
+	// 残りを全て引くとボックスの中身がちょうど排出される
+	gotIDs := lottery.drawBox(random.New(1), 10, drawnCountMap)
+	if len(gotIDs) != 6 {
+		t.Errorf("drawBox() len = %d, want 6", len(gotIDs))
+	}
+	want := map[string]int{"1001": 5, "2001": 2, "3001": 1}
+	if !reflect.DeepEqual(drawnCountMap, want) {
+		t.Errorf("drawBox() drawnCountMap = %v, want %v", drawnCountMap, want)
+	}
+	if remaining := lottery.boxRemaining(drawnCountMap); remaining != 0 {
+		t.Errorf("boxRemaining() = %d, want 0", remaining)
+	}
+}
+
+func TestGachaPool_Draw(t *testing.T) {
+	collectionItemMap := map[string]*model.CollectionItem{
+		"1001": {ID: "1001", Rarity: 1},
+		"1002": {ID: "1002", Rarity: 1},
+		"1003": {ID: "1003", Rarity: 1},
+	}
+	// ratioが0のアイテムは排出されない
+	pool := newGachaPool([]*model.GachaProbability{
+		{GachaID: "1", CollectionItemId: "1001", Ratio: 1},
+		{GachaID: "1", CollectionItemId: "1002", Ratio: 0},
+		{GachaID: "1", CollectionItemId: "1003", Ratio: 3},
+	}, collectionItemMap, 0)
+
+	counts := make(map[string]int)
+	rnd := random.New(1)
+	for i := 0; i < 40000; i++ {
+		counts[pool.draw(rnd)]++
+	}
+	if counts["1002"] != 0 {
+		t.Errorf("draw() count of 1002 = %d, want 0", counts["1002"])
+	}
+	// 1:3の比率で排出される(誤差は±5%まで許容)
+	if rate := float64(counts["1001"]) / 40000; rate < 0.2 || 0.3 < rate {
+		t.Errorf("draw() rate of 1001 = %f, want about 0.25", rate)
+	}
+}
+
+func TestGachaLotteryCache(t *testing.T) {
+	gacha := &model.Gacha{ID: "1", UpdatedAt: time.Unix(1598227200, 0)}
+	cache := newGachaLotteryCache()
+	cache.set(gacha.ID, newGachaLottery(gacha, nil, nil))
+
+	if _, ok := cache.get(gacha); !ok {
+		t.Errorf("get() ok = false, want true")
+	}
+	// 排出情報が更新されてガチャの更新日時が変わるとキャッシュは無効
+	updatedGacha := &model.Gacha{ID: "1", UpdatedAt: gacha.UpdatedAt.Add(time.Second)}
+	if _, ok := cache.get(updatedGacha); ok {
+		t.Errorf("get() ok = true, want false")
+	}
+}
+
+// linearDraw 累積和を先頭から走査してアイテムを決定する(比較用の従来実装)
+func linearDraw(rnd *rand.Rand, gachaProbabilities []*model.GachaProbability, ratioSum int) string {
+	randomNum := rnd.Intn(ratioSum)
+	gachaProbabilityThreshold := 0
+	for _, gachaProbability := range gachaProbabilities {
+		gachaProbabilityThreshold += gachaProbability.Ratio
+		if randomNum < gachaProbabilityThreshold {
+			return gachaProbability.CollectionItemId
+		}
+	}
+	return ""
+}
+
+// newBenchmarkGachaData 指定件数のアイテムと排出確率情報を作成する
+func newBenchmarkGachaData(n int) ([]*model.GachaProbability, []*model.CollectionItem) {
+	gachaProbabilities := make([]*model.GachaProbability, 0, n)
+	collectionItems := make([]*model.CollectionItem, 0, n)
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("%d", i)
+		collectionItems = append(collectionItems, &model.CollectionItem{ID: id, Rarity: i%3 + 1})
+		gachaProbabilities = append(gachaProbabilities, &model.GachaProbability{GachaID: "1", CollectionItemId: id, Ratio: 3 - i%3})
+	}
+	return gachaProbabilities, collectionItems
+}
+
+func BenchmarkGachaDraw(b *testing.B) {
+	for _, n := range []int{100, 10000, 50000} {
+		gachaProbabilities, collectionItems := newBenchmarkGachaData(n)
+		var ratioSum int
+		for _, gachaProbability := range gachaProbabilities {
+			ratioSum += gachaProbability.Ratio
+		}
+		lottery := newGachaLottery(&model.Gacha{ID: "1"}, gachaProbabilities, collectionItems)
+
+		b.Run(fmt.Sprintf("linear/%d", n), func(b *testing.B) {
+			rnd := random.New(1)
+			for i := 0; i < b.N; i++ {
+				linearDraw(rnd, gachaProbabilities, ratioSum)
+			}
+		})
+		b.Run(fmt.Sprintf("prefixsum/%d", n), func(b *testing.B) {
+			rnd := random.New(1)
+			for i := 0; i < b.N; i++ {
+				lottery.normalPool.draw(rnd)
+			}
+		})
+	}
+}
@@ -0,0 +1,702 @@
+package service
+
+import (
+	"20dojo-online/pkg/constant"
+	"20dojo-online/pkg/random"
+	"20dojo-online/pkg/server/model"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+// newTestGachaLottery テスト用の排出対象. 通常排出ではほぼ確実にレアリティ1が排出される重み
+func newTestGachaLottery(gacha *model.Gacha) *gachaLottery {
+	return newGachaLottery(gacha, []*model.GachaProbability{
+		{GachaID: gacha.ID, CollectionItemId: "1001", Ratio: 100000000},
+		{GachaID: gacha.ID, CollectionItemId: "2001", Ratio: 1},
+		{GachaID: gacha.ID, CollectionItemId: "3001", Ratio: 1},
+	}, []*model.CollectionItem{
+		{ID: "1001", Name: "スゴリラ01", Rarity: 1},
+		{ID: "2001", Name: "レアスゴリラ01", Rarity: 2},
+		{ID: "3001", Name: "超スゴリラ01", Rarity: 3},
+	})
+}
+
+// トランザクションはモックのリポジトリでは利用しないためnilを渡す
+func TestGachaService_drawNormalGacha(t *testing.T) {
+	gacha := &model.Gacha{ID: "1", Mode: constant.GachaModeNormal, CoinConsumption: 100, UpdatedAt: time.Unix(1598227200, 0)}
+	lottery := newTestGachaLottery(gacha)
+
+	type args struct {
+		serviceRequest *DrawGachaRequest
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		before  func(mock *mockRepository, args args)
+		want    *gachaDrawOutcome
+		wantErr bool
+	}{
+		{
+			name: "正常:天井到達で最高レアリティを排出して天井カウントをリセット",
+			args: args{
+				serviceRequest: &DrawGachaRequest{GachaID: "1", Times: 1, Payment: constant.GachaPaymentCoin, UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.userGachaPityRepository.EXPECT().SelectUserGachaPityByPrimaryKeyForUpdate(nil, "UserId1", "1").Return(&model.UserGachaPity{
+					UserID:  "UserId1",
+					GachaID: "1",
+					Count:   constant.GachaPityThreshold,
+				}, nil)
+			},
+			want: &gachaDrawOutcome{
+				collectionItemIDs: []string{"3001"},
+				coinConsumption:   100,
+				userGachaPity:     &model.UserGachaPity{UserID: "UserId1", GachaID: "1", Count: 0},
+				initialState:      &gachaDrawState{pityCount: constant.GachaPityThreshold},
+			},
+		},
+		{
+			name: "正常:天井カウント未登録は0回から加算",
+			args: args{
+				serviceRequest: &DrawGachaRequest{GachaID: "1", Times: 2, Payment: constant.GachaPaymentCoin, UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.userGachaPityRepository.EXPECT().SelectUserGachaPityByPrimaryKeyForUpdate(nil, "UserId1", "1").Return(nil, nil)
+			},
+			want: &gachaDrawOutcome{
+				collectionItemIDs: []string{"1001", "1001"},
+				coinConsumption:   200,
+				userGachaPity:     &model.UserGachaPity{UserID: "UserId1", GachaID: "1", Count: 2},
+				initialState:      &gachaDrawState{pityCount: 0},
+			},
+		},
+		{
+			name: "異常:天井カウント取得エラー",
+			args: args{
+				serviceRequest: &DrawGachaRequest{GachaID: "1", Times: 1, Payment: constant.GachaPaymentCoin, UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.userGachaPityRepository.EXPECT().SelectUserGachaPityByPrimaryKeyForUpdate(nil, "UserId1", "1").Return(nil, errors.New("SelectUserGachaPityByPrimaryKeyForUpdate failed"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMockRepository(ctrl)
+			tt.before(mock, tt.args)
+			s := &GachaService{
+				UserGachaPityRepository: mock.userGachaPityRepository,
+			}
+			got, err := s.drawNormalGacha(nil, tt.args.serviceRequest, gacha, lottery, 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("drawNormalGacha() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("drawNormalGacha() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// トランザクションはモックのリポジトリでは利用しないためnilを渡す
+func TestGachaService_drawStepUpGacha(t *testing.T) {
+	gacha := &model.Gacha{ID: "2", Mode: constant.GachaModeStepUp, UpdatedAt: time.Unix(1598227200, 0)}
+	lottery := newTestGachaLottery(gacha)
+	gachaSteps := []*model.GachaStep{
+		{GachaID: "2", Step: 1, Times: 1, CoinConsumption: 50, GuaranteedRarity: 3},
+		{GachaID: "2", Step: 2, Times: 2, CoinConsumption: 150, GuaranteedRarity: 0},
+	}
+
+	type args struct {
+		serviceRequest *DrawGachaRequest
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		before  func(mock *mockRepository, args args)
+		want    *gachaDrawOutcome
+		wantErr bool
+	}{
+		{
+			name: "正常:進捗未登録は最初のステップの保証レアリティで排出して次のステップへ進む",
+			args: args{
+				serviceRequest: &DrawGachaRequest{GachaID: "2", Times: 1, Payment: constant.GachaPaymentCoin, UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.gachaStepRepository.EXPECT().SelectGachaStepsByGachaID("2").Return(gachaSteps, nil)
+				mock.userGachaStepRepository.EXPECT().SelectUserGachaStepByPrimaryKeyForUpdate(nil, "UserId1", "2").Return(nil, nil)
+				mock.userGachaPityRepository.EXPECT().SelectUserGachaPityByPrimaryKeyForUpdate(nil, "UserId1", "2").Return(&model.UserGachaPity{
+					UserID: "UserId1", GachaID: "2", Count: 5,
+				}, nil)
+			},
+			want: &gachaDrawOutcome{
+				collectionItemIDs: []string{"3001"},
+				coinConsumption:   50,
+				userGachaPity:     &model.UserGachaPity{UserID: "UserId1", GachaID: "2", Count: 0},
+				userGachaStep:     &model.UserGachaStep{UserID: "UserId1", GachaID: "2", Step: 2},
+				initialState:      &gachaDrawState{pityCount: 5, step: gachaSteps[0]},
+			},
+		},
+		{
+			name: "正常:最終ステップの次は最初のステップに戻る",
+			args: args{
+				serviceRequest: &DrawGachaRequest{GachaID: "2", Times: 2, Payment: constant.GachaPaymentCoin, UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.gachaStepRepository.EXPECT().SelectGachaStepsByGachaID("2").Return(gachaSteps, nil)
+				mock.userGachaStepRepository.EXPECT().SelectUserGachaStepByPrimaryKeyForUpdate(nil, "UserId1", "2").Return(&model.UserGachaStep{
+					UserID: "UserId1", GachaID: "2", Step: 2,
+				}, nil)
+				mock.userGachaPityRepository.EXPECT().SelectUserGachaPityByPrimaryKeyForUpdate(nil, "UserId1", "2").Return(nil, nil)
+			},
+			want: &gachaDrawOutcome{
+				collectionItemIDs: []string{"1001", "1001"},
+				coinConsumption:   150,
+				userGachaPity:     &model.UserGachaPity{UserID: "UserId1", GachaID: "2", Count: 2},
+				userGachaStep:     &model.UserGachaStep{UserID: "UserId1", GachaID: "2", Step: 1},
+				initialState:      &gachaDrawState{pityCount: 0, step: gachaSteps[1]},
+			},
+		},
+		{
+			name: "異常:実行回数がステップの回数と一致しない",
+			args: args{
+				serviceRequest: &DrawGachaRequest{GachaID: "2", Times: 10, Payment: constant.GachaPaymentCoin, UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.gachaStepRepository.EXPECT().SelectGachaStepsByGachaID("2").Return(gachaSteps, nil)
+				mock.userGachaStepRepository.EXPECT().SelectUserGachaStepByPrimaryKeyForUpdate(nil, "UserId1", "2").Return(nil, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "異常:ステップ未登録",
+			args: args{
+				serviceRequest: &DrawGachaRequest{GachaID: "2", Times: 1, Payment: constant.GachaPaymentCoin, UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.gachaStepRepository.EXPECT().SelectGachaStepsByGachaID("2").Return(nil, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMockRepository(ctrl)
+			tt.before(mock, tt.args)
+			s := newTestGachaService(mock)
+			got, err := s.drawStepUpGacha(nil, tt.args.serviceRequest, gacha, lottery, 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("drawStepUpGacha() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("drawStepUpGacha() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// トランザクションはモックのリポジトリでは利用しないためnilを渡す
+func TestGachaService_drawBoxGacha(t *testing.T) {
+	gacha := &model.Gacha{ID: "3", Mode: constant.GachaModeBox, CoinConsumption: 10, UpdatedAt: time.Unix(1598227200, 0)}
+	// ratioをボックス内の個数として扱う
+	lottery := newGachaLottery(gacha, []*model.GachaProbability{
+		{GachaID: "3", CollectionItemId: "1001", Ratio: 2},
+		{GachaID: "3", CollectionItemId: "2001", Ratio: 1},
+	}, []*model.CollectionItem{
+		{ID: "1001", Name: "スゴリラ01", Rarity: 1},
+		{ID: "2001", Name: "レアスゴリラ01", Rarity: 2},
+	})
+
+	type args struct {
+		serviceRequest *DrawGachaRequest
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		before  func(mock *mockRepository, args args)
+		want    *gachaDrawOutcome
+		wantErr bool
+	}{
+		{
+			name: "正常:残りのアイテムを排出して排出済み個数を更新",
+			args: args{
+				serviceRequest: &DrawGachaRequest{GachaID: "3", Times: 1, Payment: constant.GachaPaymentCoin, UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.userGachaBoxItemRepository.EXPECT().SelectUserGachaBoxItemsByUserIDAndGachaIDForUpdate(nil, "UserId1", "3").Return([]*model.UserGachaBoxItem{
+					{UserID: "UserId1", GachaID: "3", CollectionItemID: "1001", DrawnCount: 2},
+				}, nil)
+			},
+			want: &gachaDrawOutcome{
+				collectionItemIDs: []string{"2001"},
+				coinConsumption:   10,
+				userGachaBoxItems: []*model.UserGachaBoxItem{
+					{UserID: "UserId1", GachaID: "3", CollectionItemID: "1001", DrawnCount: 2},
+					{UserID: "UserId1", GachaID: "3", CollectionItemID: "2001", DrawnCount: 1},
+				},
+				initialState: &gachaDrawState{boxDrawnCounts: map[string]int{"1001": 2}},
+			},
+		},
+		{
+			name: "正常:ボックスの中身をすべて排出",
+			args: args{
+				serviceRequest: &DrawGachaRequest{GachaID: "3", Times: 3, Payment: constant.GachaPaymentCoin, UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.userGachaBoxItemRepository.EXPECT().SelectUserGachaBoxItemsByUserIDAndGachaIDForUpdate(nil, "UserId1", "3").Return(nil, nil)
+			},
+			want: &gachaDrawOutcome{
+				collectionItemIDs: nil, // 排出順はシードに依存するため個数のみ確認する
+				coinConsumption:   30,
+				userGachaBoxItems: []*model.UserGachaBoxItem{
+					{UserID: "UserId1", GachaID: "3", CollectionItemID: "1001", DrawnCount: 2},
+					{UserID: "UserId1", GachaID: "3", CollectionItemID: "2001", DrawnCount: 1},
+				},
+				initialState: &gachaDrawState{boxDrawnCounts: map[string]int{}},
+			},
+		},
+		{
+			name: "異常:ボックスの残り個数不足",
+			args: args{
+				serviceRequest: &DrawGachaRequest{GachaID: "3", Times: 2, Payment: constant.GachaPaymentCoin, UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.userGachaBoxItemRepository.EXPECT().SelectUserGachaBoxItemsByUserIDAndGachaIDForUpdate(nil, "UserId1", "3").Return([]*model.UserGachaBoxItem{
+					{UserID: "UserId1", GachaID: "3", CollectionItemID: "1001", DrawnCount: 2},
+				}, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMockRepository(ctrl)
+			tt.before(mock, tt.args)
+			s := newTestGachaService(mock)
+			got, err := s.drawBoxGacha(nil, tt.args.serviceRequest, gacha, lottery, 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("drawBoxGacha() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got == nil {
+				if tt.want != nil {
+					t.Errorf("drawBoxGacha() got = nil, want %+v", tt.want)
+				}
+				return
+			}
+			if tt.want.collectionItemIDs == nil {
+				if len(got.collectionItemIDs) != tt.args.serviceRequest.Times {
+					t.Errorf("drawBoxGacha() got %d items, want %d", len(got.collectionItemIDs), tt.args.serviceRequest.Times)
+				}
+				got.collectionItemIDs = nil
+			}
+			// 排出済み個数はマップから作成するため順序を揃えて比較する
+			sort.Slice(got.userGachaBoxItems, func(i, j int) bool {
+				return got.userGachaBoxItems[i].CollectionItemID < got.userGachaBoxItems[j].CollectionItemID
+			})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("drawBoxGacha() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// newTestGachaService テスト用のGachaService. シードはrandom.NewSeededSource(1)から生成する
+func newTestGachaService(mock *mockRepository) *GachaService {
+	return &GachaService{
+		UserRepository:               mock.userRepository,
+		UserCollectionItemRepository: mock.userCollectionItemRepository,
+		UserGachaPityRepository:      mock.userGachaPityRepository,
+		GachaDrawHistoryRepository:   mock.gachaDrawHistoryRepository,
+		GachaStepRepository:          mock.gachaStepRepository,
+		UserGachaStepRepository:      mock.userGachaStepRepository,
+		UserGachaBoxItemRepository:   mock.userGachaBoxItemRepository,
+		UserGachaTicketRepository:    mock.userGachaTicketRepository,
+		UserGachaFreeDrawRepository:  mock.userGachaFreeDrawRepository,
+		SeedSource:                   random.NewSeededSource(1),
+	}
+}
+
+// testGachaSeed newTestGachaServiceで最初に生成されるシード
+func testGachaSeed(t *testing.T) int64 {
+	seed, err := random.NewSeededSource(1).Seed()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return seed
+}
+
+// トランザクションはモックのリポジトリでは利用しないためnilを渡す
+func TestGachaService_drawGacha(t *testing.T) {
+	now := time.Date(2020, 8, 1, 12, 0, 0, 0, time.Local)
+	acquiredAt := now.Add(-24 * time.Hour)
+	seed := testGachaSeed(t)
+	gacha := &model.Gacha{ID: "1", Mode: constant.GachaModeNormal, CoinConsumption: 100, UpdatedAt: time.Unix(1598227200, 0)}
+	stepUpGacha := &model.Gacha{ID: "2", Mode: constant.GachaModeStepUp, UpdatedAt: time.Unix(1598227200, 0)}
+	boxGacha := &model.Gacha{ID: "3", Mode: constant.GachaModeBox, CoinConsumption: 10, UpdatedAt: time.Unix(1598227200, 0)}
+
+	type args struct {
+		serviceRequest *DrawGachaRequest
+		gacha          *model.Gacha
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		before  func(mock *mockRepository, args args)
+		want    *DrawGachaResponse
+		wantErr string
+	}{
+		{
+			name: "正常:コイン払いで重複アイテムをシャードに変換して履歴を記録",
+			args: args{
+				gacha:          gacha,
+				serviceRequest: &DrawGachaRequest{GachaID: "1", Times: 2, Payment: constant.GachaPaymentCoin, UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.userRepository.EXPECT().SelectUserByPrimaryKeyForUpdate(nil, "UserId1").Return(&model.User{ID: "UserId1", Coin: 500, Shard: 10}, nil)
+				mock.userGachaPityRepository.EXPECT().SelectUserGachaPityByPrimaryKeyForUpdate(nil, "UserId1", "1").Return(&model.UserGachaPity{
+					UserID: "UserId1", GachaID: "1", Count: 3,
+				}, nil)
+				mock.userCollectionItemRepository.EXPECT().SelectUserCollectionItemsByUserIDForUpdate(nil, "UserId1").Return([]*model.UserCollectionItem{
+					{UserID: "UserId1", CollectionItemID: "1001", Count: 1, Level: 1, FirstAcquiredAt: acquiredAt, LastAcquiredAt: acquiredAt},
+				}, nil)
+				mock.userCollectionItemRepository.EXPECT().BulkUpsertUserCollectionItem(nil, []*model.UserCollectionItem{
+					{UserID: "UserId1", CollectionItemID: "1001", Count: 3, Level: 2, FirstAcquiredAt: acquiredAt, LastAcquiredAt: now},
+				}).Return(nil)
+				mock.gachaDrawHistoryRepository.EXPECT().BulkInsertGachaDrawHistory(nil, []*model.GachaDrawHistory{
+					{UserID: "UserId1", GachaID: "1", CollectionItemID: "1001", Rarity: 1, CoinConsumption: 100, Payment: constant.GachaPaymentCoin,
+						Seed: seed, DrawIndex: 0, PityCount: 3, CreatedAt: now},
+					{UserID: "UserId1", GachaID: "1", CollectionItemID: "1001", Rarity: 1, CoinConsumption: 100, Payment: constant.GachaPaymentCoin,
+						Seed: seed, DrawIndex: 1, PityCount: 3, CreatedAt: now},
+				}).Return(nil)
+				mock.userGachaPityRepository.EXPECT().UpsertUserGachaPity(nil, &model.UserGachaPity{UserID: "UserId1", GachaID: "1", Count: 5}).Return(nil)
+				mock.userRepository.EXPECT().UpdateUserCoinAndShardByPrimaryKey(nil, "UserId1", 300, 12).Return(nil)
+			},
+			want: &DrawGachaResponse{
+				GachaResults: []*GachaResult{
+					{CollectionID: "1001", Name: "スゴリラ01", Rarity: 1, IsNew: false, Shard: 1, Count: 2, Level: 2},
+					{CollectionID: "1001", Name: "スゴリラ01", Rarity: 1, IsNew: false, Shard: 1, Count: 3, Level: 2},
+				},
+				Seed: seed,
+			},
+		},
+		{
+			name: "正常:ステップアップガチャは実行前のステップを履歴に記録して次のステップへ進む",
+			args: args{
+				gacha:          stepUpGacha,
+				serviceRequest: &DrawGachaRequest{GachaID: "2", Times: 1, Payment: constant.GachaPaymentCoin, UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.userRepository.EXPECT().SelectUserByPrimaryKeyForUpdate(nil, "UserId1").Return(&model.User{ID: "UserId1", Coin: 100}, nil)
+				mock.gachaStepRepository.EXPECT().SelectGachaStepsByGachaID("2").Return([]*model.GachaStep{
+					{GachaID: "2", Step: 1, Times: 1, CoinConsumption: 50},
+					{GachaID: "2", Step: 2, Times: 10, CoinConsumption: 450, GuaranteedRarity: 2},
+				}, nil)
+				mock.userGachaStepRepository.EXPECT().SelectUserGachaStepByPrimaryKeyForUpdate(nil, "UserId1", "2").Return(nil, nil)
+				mock.userGachaPityRepository.EXPECT().SelectUserGachaPityByPrimaryKeyForUpdate(nil, "UserId1", "2").Return(nil, nil)
+				mock.userCollectionItemRepository.EXPECT().SelectUserCollectionItemsByUserIDForUpdate(nil, "UserId1").Return(nil, nil)
+				mock.userCollectionItemRepository.EXPECT().BulkUpsertUserCollectionItem(nil, []*model.UserCollectionItem{
+					{UserID: "UserId1", CollectionItemID: "1001", Count: 1, Level: 1, FirstAcquiredAt: now, LastAcquiredAt: now},
+				}).Return(nil)
+				mock.gachaDrawHistoryRepository.EXPECT().BulkInsertGachaDrawHistory(nil, []*model.GachaDrawHistory{
+					{UserID: "UserId1", GachaID: "2", CollectionItemID: "1001", Rarity: 1, CoinConsumption: 50, Payment: constant.GachaPaymentCoin,
+						Seed: seed, DrawIndex: 0, PityCount: 0, Step: 1, CreatedAt: now},
+				}).Return(nil)
+				mock.userGachaPityRepository.EXPECT().UpsertUserGachaPity(nil, &model.UserGachaPity{UserID: "UserId1", GachaID: "2", Count: 1}).Return(nil)
+				mock.userGachaStepRepository.EXPECT().UpsertUserGachaStep(nil, &model.UserGachaStep{UserID: "UserId1", GachaID: "2", Step: 2}).Return(nil)
+				mock.userRepository.EXPECT().UpdateUserCoinAndShardByPrimaryKey(nil, "UserId1", 50, 0).Return(nil)
+			},
+			want: &DrawGachaResponse{
+				GachaResults: []*GachaResult{
+					{CollectionID: "1001", Name: "スゴリラ01", Rarity: 1, IsNew: true, Shard: 0, Count: 1, Level: 1},
+				},
+				Seed: seed,
+			},
+		},
+		{
+			name: "正常:ボックスガチャは実行前の排出済み個数を履歴に記録して更新",
+			args: args{
+				gacha:          boxGacha,
+				serviceRequest: &DrawGachaRequest{GachaID: "3", Times: 1, Payment: constant.GachaPaymentCoin, UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.userRepository.EXPECT().SelectUserByPrimaryKeyForUpdate(nil, "UserId1").Return(&model.User{ID: "UserId1", Coin: 100}, nil)
+				mock.userGachaBoxItemRepository.EXPECT().SelectUserGachaBoxItemsByUserIDAndGachaIDForUpdate(nil, "UserId1", "3").Return([]*model.UserGachaBoxItem{
+					{UserID: "UserId1", GachaID: "3", CollectionItemID: "1001", DrawnCount: 1},
+				}, nil)
+				mock.userCollectionItemRepository.EXPECT().SelectUserCollectionItemsByUserIDForUpdate(nil, "UserId1").Return([]*model.UserCollectionItem{
+					{UserID: "UserId1", CollectionItemID: "1001", Count: 1, Level: 1, FirstAcquiredAt: acquiredAt, LastAcquiredAt: acquiredAt},
+				}, nil)
+				mock.userCollectionItemRepository.EXPECT().BulkUpsertUserCollectionItem(nil, []*model.UserCollectionItem{
+					{UserID: "UserId1", CollectionItemID: "1001", Count: 2, Level: 2, FirstAcquiredAt: acquiredAt, LastAcquiredAt: now},
+				}).Return(nil)
+				mock.gachaDrawHistoryRepository.EXPECT().BulkInsertGachaDrawHistory(nil, []*model.GachaDrawHistory{
+					{UserID: "UserId1", GachaID: "3", CollectionItemID: "1001", Rarity: 1, CoinConsumption: 10, Payment: constant.GachaPaymentCoin,
+						Seed: seed, DrawIndex: 0, BoxDrawnCounts: `{"1001":1}`, CreatedAt: now},
+				}).Return(nil)
+				mock.userGachaBoxItemRepository.EXPECT().BulkUpsertUserGachaBoxItem(nil, []*model.UserGachaBoxItem{
+					{UserID: "UserId1", GachaID: "3", CollectionItemID: "1001", DrawnCount: 2},
+				}).Return(nil)
+				mock.userRepository.EXPECT().UpdateUserCoinAndShardByPrimaryKey(nil, "UserId1", 90, 1).Return(nil)
+			},
+			want: &DrawGachaResponse{
+				GachaResults: []*GachaResult{
+					{CollectionID: "1001", Name: "スゴリラ01", Rarity: 1, IsNew: false, Shard: 1, Count: 2, Level: 2},
+				},
+				Seed: seed,
+			},
+		},
+		{
+			name: "異常:コイン不足",
+			args: args{
+				gacha:          gacha,
+				serviceRequest: &DrawGachaRequest{GachaID: "1", Times: 2, Payment: constant.GachaPaymentCoin, UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.userRepository.EXPECT().SelectUserByPrimaryKeyForUpdate(nil, "UserId1").Return(&model.User{ID: "UserId1", Coin: 199}, nil)
+				mock.userGachaPityRepository.EXPECT().SelectUserGachaPityByPrimaryKeyForUpdate(nil, "UserId1", "1").Return(nil, nil)
+			},
+			wantErr: "your coin is not enought. your coin=199",
+		},
+		{
+			name: "正常:チケット払いはコインを消費せずにチケットを消費",
+			args: args{
+				gacha:          gacha,
+				serviceRequest: &DrawGachaRequest{GachaID: "1", Times: 1, Payment: constant.GachaPaymentTicket, UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.userRepository.EXPECT().SelectUserByPrimaryKeyForUpdate(nil, "UserId1").Return(&model.User{ID: "UserId1", Coin: 0}, nil)
+				mock.userGachaPityRepository.EXPECT().SelectUserGachaPityByPrimaryKeyForUpdate(nil, "UserId1", "1").Return(nil, nil)
+				mock.userGachaTicketRepository.EXPECT().SelectUserGachaTicketByPrimaryKeyForUpdate(nil, "UserId1").Return(&model.UserGachaTicket{UserID: "UserId1", Count: 3}, nil)
+				mock.userCollectionItemRepository.EXPECT().SelectUserCollectionItemsByUserIDForUpdate(nil, "UserId1").Return(nil, nil)
+				mock.userCollectionItemRepository.EXPECT().BulkUpsertUserCollectionItem(nil, []*model.UserCollectionItem{
+					{UserID: "UserId1", CollectionItemID: "1001", Count: 1, Level: 1, FirstAcquiredAt: now, LastAcquiredAt: now},
+				}).Return(nil)
+				mock.gachaDrawHistoryRepository.EXPECT().BulkInsertGachaDrawHistory(nil, []*model.GachaDrawHistory{
+					{UserID: "UserId1", GachaID: "1", CollectionItemID: "1001", Rarity: 1, CoinConsumption: 0, Payment: constant.GachaPaymentTicket,
+						Seed: seed, DrawIndex: 0, PityCount: 0, CreatedAt: now},
+				}).Return(nil)
+				mock.userGachaPityRepository.EXPECT().UpsertUserGachaPity(nil, &model.UserGachaPity{UserID: "UserId1", GachaID: "1", Count: 1}).Return(nil)
+				mock.userGachaTicketRepository.EXPECT().UpsertUserGachaTicket(nil, &model.UserGachaTicket{UserID: "UserId1", Count: 2}).Return(nil)
+				mock.userRepository.EXPECT().UpdateUserCoinAndShardByPrimaryKey(nil, "UserId1", 0, 0).Return(nil)
+			},
+			want: &DrawGachaResponse{
+				GachaResults: []*GachaResult{
+					{CollectionID: "1001", Name: "スゴリラ01", Rarity: 1, IsNew: true, Shard: 0, Count: 1, Level: 1},
+				},
+				Seed: seed,
+			},
+		},
+		{
+			name: "異常:チケット不足",
+			args: args{
+				gacha:          gacha,
+				serviceRequest: &DrawGachaRequest{GachaID: "1", Times: 2, Payment: constant.GachaPaymentTicket, UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.userRepository.EXPECT().SelectUserByPrimaryKeyForUpdate(nil, "UserId1").Return(&model.User{ID: "UserId1", Coin: 500}, nil)
+				mock.userGachaPityRepository.EXPECT().SelectUserGachaPityByPrimaryKeyForUpdate(nil, "UserId1", "1").Return(nil, nil)
+				mock.userGachaTicketRepository.EXPECT().SelectUserGachaTicketByPrimaryKeyForUpdate(nil, "UserId1").Return(&model.UserGachaTicket{UserID: "UserId1", Count: 1}, nil)
+			},
+			wantErr: "your gacha ticket is not enough. your ticket=1",
+		},
+		{
+			name: "正常:無料ガチャは前日の実行日時を本日の実行日時に更新",
+			args: args{
+				gacha:          gacha,
+				serviceRequest: &DrawGachaRequest{GachaID: "1", Times: 1, Payment: constant.GachaPaymentFree, UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.userRepository.EXPECT().SelectUserByPrimaryKeyForUpdate(nil, "UserId1").Return(&model.User{ID: "UserId1", Coin: 0}, nil)
+				mock.userGachaPityRepository.EXPECT().SelectUserGachaPityByPrimaryKeyForUpdate(nil, "UserId1", "1").Return(nil, nil)
+				mock.userGachaFreeDrawRepository.EXPECT().SelectUserGachaFreeDrawByPrimaryKeyForUpdate(nil, "UserId1").Return(&model.UserGachaFreeDraw{
+					UserID: "UserId1", LastDrawAt: now.AddDate(0, 0, -1),
+				}, nil)
+				mock.userCollectionItemRepository.EXPECT().SelectUserCollectionItemsByUserIDForUpdate(nil, "UserId1").Return(nil, nil)
+				mock.userCollectionItemRepository.EXPECT().BulkUpsertUserCollectionItem(nil, []*model.UserCollectionItem{
+					{UserID: "UserId1", CollectionItemID: "1001", Count: 1, Level: 1, FirstAcquiredAt: now, LastAcquiredAt: now},
+				}).Return(nil)
+				mock.gachaDrawHistoryRepository.EXPECT().BulkInsertGachaDrawHistory(nil, []*model.GachaDrawHistory{
+					{UserID: "UserId1", GachaID: "1", CollectionItemID: "1001", Rarity: 1, CoinConsumption: 0, Payment: constant.GachaPaymentFree,
+						Seed: seed, DrawIndex: 0, PityCount: 0, CreatedAt: now},
+				}).Return(nil)
+				mock.userGachaPityRepository.EXPECT().UpsertUserGachaPity(nil, &model.UserGachaPity{UserID: "UserId1", GachaID: "1", Count: 1}).Return(nil)
+				mock.userGachaFreeDrawRepository.EXPECT().UpsertUserGachaFreeDraw(nil, &model.UserGachaFreeDraw{UserID: "UserId1", LastDrawAt: now}).Return(nil)
+				mock.userRepository.EXPECT().UpdateUserCoinAndShardByPrimaryKey(nil, "UserId1", 0, 0).Return(nil)
+			},
+			want: &DrawGachaResponse{
+				GachaResults: []*GachaResult{
+					{CollectionID: "1001", Name: "スゴリラ01", Rarity: 1, IsNew: true, Shard: 0, Count: 1, Level: 1},
+				},
+				Seed: seed,
+			},
+		},
+		{
+			name: "異常:本日の無料ガチャは実行済み",
+			args: args{
+				gacha:          gacha,
+				serviceRequest: &DrawGachaRequest{GachaID: "1", Times: 1, Payment: constant.GachaPaymentFree, UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.userRepository.EXPECT().SelectUserByPrimaryKeyForUpdate(nil, "UserId1").Return(&model.User{ID: "UserId1", Coin: 0}, nil)
+				mock.userGachaPityRepository.EXPECT().SelectUserGachaPityByPrimaryKeyForUpdate(nil, "UserId1", "1").Return(nil, nil)
+				mock.userGachaFreeDrawRepository.EXPECT().SelectUserGachaFreeDrawByPrimaryKeyForUpdate(nil, "UserId1").Return(&model.UserGachaFreeDraw{
+					UserID: "UserId1", LastDrawAt: now.Add(-time.Minute),
+				}, nil)
+			},
+			wantErr: "free draw has already been used today. lastDrawAt=" + now.Add(-time.Minute).Format(time.RFC3339),
+		},
+		{
+			name: "異常:存在しないユーザ",
+			args: args{
+				gacha:          gacha,
+				serviceRequest: &DrawGachaRequest{GachaID: "1", Times: 1, Payment: constant.GachaPaymentCoin, UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.userRepository.EXPECT().SelectUserByPrimaryKeyForUpdate(nil, "UserId1").Return(nil, nil)
+			},
+			wantErr: "user not found. userID=UserId1",
+		},
+		{
+			name: "異常:履歴登録エラー",
+			args: args{
+				gacha:          gacha,
+				serviceRequest: &DrawGachaRequest{GachaID: "1", Times: 1, Payment: constant.GachaPaymentCoin, UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.userRepository.EXPECT().SelectUserByPrimaryKeyForUpdate(nil, "UserId1").Return(&model.User{ID: "UserId1", Coin: 500}, nil)
+				mock.userGachaPityRepository.EXPECT().SelectUserGachaPityByPrimaryKeyForUpdate(nil, "UserId1", "1").Return(nil, nil)
+				mock.userCollectionItemRepository.EXPECT().SelectUserCollectionItemsByUserIDForUpdate(nil, "UserId1").Return(nil, nil)
+				mock.userCollectionItemRepository.EXPECT().BulkUpsertUserCollectionItem(nil, gomock.Any()).Return(nil)
+				mock.gachaDrawHistoryRepository.EXPECT().BulkInsertGachaDrawHistory(nil, gomock.Any()).Return(errors.New("BulkInsertGachaDrawHistory failed"))
+			},
+			wantErr: "BulkInsertGachaDrawHistory failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMockRepository(ctrl)
+			tt.before(mock, tt.args)
+			s := newTestGachaService(mock)
+			got, err := s.drawGacha(nil, tt.args.serviceRequest, tt.args.gacha, newTestGachaLottery(tt.args.gacha), now)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("drawGacha() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("drawGacha() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("drawGacha() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGachaService_GetGachaRates(t *testing.T) {
+	type args struct {
+		serviceRequest *GetGachaRatesRequest
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		before  func(mock *mockRepository, args args)
+		want    *GetGachaRatesResponse
+		wantErr bool
+	}{
+		{
+			name: "正常:アイテム・レアリティごとの排出確率",
+			args: args{
+				serviceRequest: &GetGachaRatesRequest{GachaID: "1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.gachaRepository.EXPECT().SelectGachaByPrimaryKey("1").Return(&model.Gacha{
+					ID:              "1",
+					Name:            "スタンダードガチャ",
+					CoinConsumption: 100,
+				}, nil)
+				mock.gachaProbabilityRepository.EXPECT().SelectGachaProbabilitiesByGachaID("1").Return([]*model.GachaProbability{
+					{GachaID: "1", CollectionItemId: "1001", Ratio: 6},
+					{GachaID: "1", CollectionItemId: "1002", Ratio: 6},
+					{GachaID: "1", CollectionItemId: "2001", Ratio: 3},
+					{GachaID: "1", CollectionItemId: "3001", Ratio: 5},
+				}, nil)
+				mock.collectionItemRepository.EXPECT().SelectCollectionItemAll().Return([]*model.CollectionItem{
+					{ID: "1001", Name: "スゴリラ01", Rarity: 1},
+					{ID: "1002", Name: "スゴリラ02", Rarity: 1},
+					{ID: "2001", Name: "レアスゴリラ01", Rarity: 2},
+					{ID: "3001", Name: "超スゴリラ01", Rarity: 3},
+				}, nil)
+			},
+			want: &GetGachaRatesResponse{
+				ItemRates: []*GachaItemRate{
+					{CollectionID: "1001", Name: "スゴリラ01", Rarity: 1, Rate: 30},
+					{CollectionID: "1002", Name: "スゴリラ02", Rarity: 1, Rate: 30},
+					{CollectionID: "2001", Name: "レアスゴリラ01", Rarity: 2, Rate: 15},
+					{CollectionID: "3001", Name: "超スゴリラ01", Rarity: 3, Rate: 25},
+				},
+				RarityRates: []*GachaRarityRate{
+					{Rarity: 1, Rate: 60},
+					{Rarity: 2, Rate: 15},
+					{Rarity: 3, Rate: 25},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常:存在しないガチャ",
+			args: args{
+				serviceRequest: &GetGachaRatesRequest{GachaID: "999"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.gachaRepository.EXPECT().SelectGachaByPrimaryKey("999").Return(nil, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMockRepository(ctrl)
+			tt.before(mock, tt.args)
+			s := &GachaService{
+				GachaRepository:            mock.gachaRepository,
+				GachaProbabilityRepository: mock.gachaProbabilityRepository,
+				CollectionItemRepository:   mock.collectionItemRepository,
+			}
+			got, err := s.GetGachaRates(tt.args.serviceRequest)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetGachaRates() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetGachaRates() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
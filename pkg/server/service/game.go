@@ -4,61 +4,334 @@ package service
 
 import (
 	"20dojo-online/pkg/constant"
+	"20dojo-online/pkg/db"
+	"20dojo-online/pkg/leaderboard"
+	"20dojo-online/pkg/myerror"
 	"20dojo-online/pkg/server/model"
+	"20dojo-online/pkg/session"
+	"database/sql"
 	"errors"
 	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
 )
 
+type StartGameRequest struct {
+	UserID string
+}
+
+type StartGameResponse struct {
+	SessionID string
+	StartedAt time.Time
+}
+
 type FinishGameRequest struct {
-	UserId string
-	Score  int
+	UserId    string
+	SessionID string
+	Score     int
 }
 
 type FinishGameResponse struct {
-	Coin int
+	Coin      int
+	Breakdown *RewardBreakdown
+}
+
+type GetGameHistoryRequest struct {
+	UserID string
+	Limit  int
+	Offset int
+}
+
+type GetGameHistoryResponse struct {
+	GamePlays []*GamePlay
+	Stats     *GamePlayStats
+}
+
+// GamePlay 1回のゲームプレイの記録
+type GamePlay struct {
+	Score      int
+	RewardCoin int
+	Duration   int
+	FinishedAt time.Time
+}
+
+// GamePlayStats 直近のゲームプレイの集計値
+type GamePlayStats struct {
+	PlayCount    int
+	AverageScore float64
+	BestScore    int
 }
 
 type GameService struct {
-	UserRepository model.UserRepositoryInterface
+	UserRepository        model.UserRepositoryInterface
+	GameSessionRepository model.GameSessionRepositoryInterface
+	GamePlayRepository    model.GamePlayRepositoryInterface
+	RewardEventRepository model.RewardEventRepositoryInterface
+	Leaderboards          leaderboard.Factory
+	RewardCalculator      RewardCalculator
+	SessionSigner         *session.Signer
 }
 
-func NewGameService(userRepository model.UserRepositoryInterface) *GameService {
+func NewGameService(
+	userRepository model.UserRepositoryInterface,
+	gameSessionRepository model.GameSessionRepositoryInterface,
+	gamePlayRepository model.GamePlayRepositoryInterface,
+	rewardEventRepository model.RewardEventRepositoryInterface,
+	leaderboards leaderboard.Factory,
+	rewardCalculator RewardCalculator,
+	sessionSigner *session.Signer,
+) *GameService {
 	return &GameService{
-		UserRepository: userRepository,
+		UserRepository:        userRepository,
+		GameSessionRepository: gameSessionRepository,
+		GamePlayRepository:    gamePlayRepository,
+		RewardEventRepository: rewardEventRepository,
+		Leaderboards:          leaderboards,
+		RewardCalculator:      rewardCalculator,
+		SessionSigner:         sessionSigner,
 	}
 }
 
 type GameServiceInterface interface {
+	StartGame(serviceRequest *StartGameRequest) (*StartGameResponse, error)
 	FinishGame(serviceRequest *FinishGameRequest) (*FinishGameResponse, error)
+	GetGameHistory(serviceRequest *GetGameHistoryRequest) (*GetGameHistoryResponse, error)
 }
 
 var _ GameServiceInterface = (*GameService)(nil)
 
+// StartGame ゲーム開始時のロジック
+func (s *GameService) StartGame(serviceRequest *StartGameRequest) (*StartGameResponse, error) {
+	// UUIDでセッションIDを生成する
+	sessionID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	// DATETIME型に合わせて秒単位で記録する
+	startedAt := time.Now().Truncate(time.Second)
+	if err = s.GameSessionRepository.InsertGameSession(&model.GameSession{
+		ID:        sessionID.String(),
+		UserID:    serviceRequest.UserID,
+		StartedAt: startedAt,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &StartGameResponse{
+		SessionID: s.SessionSigner.Sign(sessionID.String(), serviceRequest.UserID, startedAt),
+		StartedAt: startedAt,
+	}, nil
+}
+
 // GameFinish ゲーム終了時のロジック
 func (s *GameService) FinishGame(serviceRequest *FinishGameRequest) (*FinishGameResponse, error) {
-	// 報酬の計算
-	rewardCoin := int(float64(serviceRequest.Score) * constant.RewardCoinRate)
+	// セッションの検証
+	sessionID, startedAt, err := s.SessionSigner.Verify(serviceRequest.SessionID, serviceRequest.UserId)
+	if err != nil {
+		return nil, myerror.ApplicationError{
+			Message:       "failed to verify game session",
+			OriginalError: err,
+			Code:          http.StatusBadRequest,
+		}
+	}
+	now := time.Now()
+	if err = validateGameScore(serviceRequest.Score, startedAt, now); err != nil {
+		return nil, err
+	}
 
-	// ゲーム終了前のユーザ情報の取得
-	user, err := s.UserRepository.SelectUserByPrimaryKey(serviceRequest.UserId)
+	// 開催中の報酬イベントの取得
+	rewardEvents, err := s.RewardEventRepository.SelectRewardEventsInSession(now)
 	if err != nil {
 		return nil, err
 	}
-	if user == nil {
-		err = errors.New(fmt.Sprintf("user not found. userID=%s", serviceRequest.UserId))
+
+	// トランザクション開始
+	// 同時に実行されたゲーム終了・ガチャ実行で所持コインの更新が失われないよう、ユーザ情報を排他ロックして更新する
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	user, breakdown, err := s.finishGame(tx, serviceRequest, sessionID, startedAt, rewardEvents, now)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			log.Println(fmt.Sprintf("Rollback Error in finishing game: %s", rollbackErr))
+		}
 		return nil, err
 	}
 
-	// ユーザのハイスコアとリクエストのスコアを比較
+	if commitErr := tx.Commit(); commitErr != nil {
+		return nil, commitErr
+	}
+
+	// ランキングへ反映する. 失敗してもデータベースの更新は確定しているため、ログのみ出力し再構築で復旧する
+	s.updateLeaderboards(user, serviceRequest.Score, now)
+
+	return &FinishGameResponse{
+		Coin:      breakdown.TotalCoin,
+		Breakdown: breakdown,
+	}, nil
+}
+
+// finishGame トランザクション内でセッションを終了済みにし、報酬コインとハイスコアを更新してゲームプレイを記録する
+// 戻り値は更新後のユーザ情報と報酬コインの内訳
+func (s *GameService) finishGame(tx *sql.Tx, serviceRequest *FinishGameRequest, sessionID string, startedAt time.Time, rewardEvents []*model.RewardEvent, now time.Time) (*model.User, *RewardBreakdown, error) {
+	// セッションを終了済みにする. 終了済みのセッションは再利用できない
+	ok, err := s.GameSessionRepository.UpdateGameSessionFinishedAt(tx, sessionID, serviceRequest.UserId, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		return nil, nil, myerror.ApplicationError{
+			Message: fmt.Sprintf("game session is already finished or not found. sessionID=%s", sessionID),
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	// ゲーム終了前のユーザ情報を排他ロックで取得
+	user, err := s.UserRepository.SelectUserByPrimaryKeyForUpdate(tx, serviceRequest.UserId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user == nil {
+		return nil, nil, errors.New(fmt.Sprintf("user not found. userID=%s", serviceRequest.UserId))
+	}
+
+	// 直近1時間のプレイ回数の上限. ユーザ情報のロック中に集計するため同時に終了しても上限を超えない
+	playCount, err := s.GamePlayRepository.SelectGamePlayCountByUserIDSince(tx, serviceRequest.UserId, now.Add(-time.Hour))
+	if err != nil {
+		return nil, nil, err
+	}
+	if playCount >= constant.GameMaxPlaysPerHour {
+		return nil, nil, myerror.ApplicationError{
+			Message: fmt.Sprintf("game play limit per hour exceeded. userID=%s, playCount=%d", serviceRequest.UserId, playCount),
+			Code:    http.StatusTooManyRequests,
+		}
+	}
+
+	// 本日獲得済みの報酬コインの上限. 今回の報酬で上限を超える場合は報酬の計算で超えた分を切り詰める
+	earnedToday, err := s.GamePlayRepository.SelectRewardCoinSumByUserIDSince(tx, serviceRequest.UserId, dailyResetTime(now))
+	if err != nil {
+		return nil, nil, err
+	}
+	if earnedToday >= constant.RewardCoinDailyCap {
+		return nil, nil, myerror.ApplicationError{
+			Message: fmt.Sprintf("daily reward coin limit reached. userID=%s, earnedToday=%d", serviceRequest.UserId, earnedToday),
+			Code:    http.StatusTooManyRequests,
+		}
+	}
+
+	// 報酬の計算
+	breakdown := s.RewardCalculator.Calculate(&RewardInput{
+		Score:        serviceRequest.Score,
+		HighScore:    user.HighScore,
+		EarnedToday:  earnedToday,
+		RewardEvents: rewardEvents,
+	})
+	rewardCoin := breakdown.TotalCoin
+
+	// ユーザのハイスコアとリクエストのスコアを比較. 更新した場合は達成日時も記録する
 	if user.HighScore < serviceRequest.Score {
 		user.HighScore = serviceRequest.Score
+		user.HighScoreUpdatedAt = now
 	}
 	user.Coin += rewardCoin // 所持コイン
 
 	// 所持コインとハイスコアを更新
-	if err = s.UserRepository.UpdateUserCoinAndHighScoreByPrimaryKey(user.ID, user.Coin, user.HighScore); err != nil {
+	if err = s.UserRepository.UpdateUserCoinAndHighScoreByPrimaryKey(tx, user.ID, user.Coin, user.HighScore, user.HighScoreUpdatedAt); err != nil {
+		return nil, nil, err
+	}
+
+	// ゲームプレイ記録の登録
+	if err = s.GamePlayRepository.InsertGamePlay(tx, &model.GamePlay{
+		UserID:     serviceRequest.UserId,
+		Score:      serviceRequest.Score,
+		RewardCoin: rewardCoin,
+		Duration:   int(now.Sub(startedAt) / time.Second),
+		CreatedAt:  now,
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	return user, breakdown, nil
+}
+
+// updateLeaderboards 全期間のランキングにハイスコアを、期間別のランキングに期間内の最高スコアを反映する
+func (s *GameService) updateLeaderboards(user *model.User, score int, now time.Time) {
+	// コミットとランキングへの反映の順序は同時に終了したゲーム間で前後するため、高い場合のみ反映してハイスコアが下がらないようにする
+	allTimeStore := newRankingPeriod(constant.RankingPeriodAllTime, now).store(s.Leaderboards)
+	if err := allTimeStore.SetIfHigher(newLeaderboardEntry(user)); err != nil {
+		log.Println(fmt.Sprintf("failed to set leaderboard entry. userID=%s: %s", user.ID, err))
+	}
+
+	for _, periodName := range []string{constant.RankingPeriodDaily, constant.RankingPeriodWeekly} {
+		periodStore := newRankingPeriod(periodName, now).store(s.Leaderboards)
+		if err := periodStore.SetIfHigher(&leaderboard.Entry{
+			UserID:     user.ID,
+			Score:      score,
+			AchievedAt: now,
+		}); err != nil {
+			log.Println(fmt.Sprintf("failed to set %s leaderboard entry. userID=%s: %s", periodName, user.ID, err))
+		}
+	}
+}
+
+// GetGameHistory ゲームプレイ履歴取得のロジック
+func (s *GameService) GetGameHistory(serviceRequest *GetGameHistoryRequest) (*GetGameHistoryResponse, error) {
+	// 新しい順に指定位置から指定件数の記録を取得
+	gamePlays, err := s.GamePlayRepository.SelectGamePlaysByUserID(serviceRequest.UserID, serviceRequest.Limit, serviceRequest.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	// 直近の一定期間のプレイを集計
+	since := time.Now().AddDate(0, 0, -constant.GameHistoryStatsDays)
+	gamePlayStats, err := s.GamePlayRepository.SelectGamePlayStatsByUserIDSince(serviceRequest.UserID, since)
+	if err != nil {
 		return nil, err
 	}
 
-	return &FinishGameResponse{Coin: rewardCoin}, err
+	gamePlayList := make([]*GamePlay, 0, len(gamePlays))
+	for _, gamePlay := range gamePlays {
+		gamePlayList = append(gamePlayList, &GamePlay{
+			Score:      gamePlay.Score,
+			RewardCoin: gamePlay.RewardCoin,
+			Duration:   gamePlay.Duration,
+			FinishedAt: gamePlay.CreatedAt,
+		})
+	}
+
+	return &GetGameHistoryResponse{
+		GamePlays: gamePlayList,
+		Stats: &GamePlayStats{
+			PlayCount:    gamePlayStats.PlayCount,
+			AverageScore: gamePlayStats.AverageScore,
+			BestScore:    gamePlayStats.BestScore,
+		},
+	}, nil
+}
+
+// validateGameScore セッションの有効期限とプレイ時間に対するスコアの妥当性を検証する
+func validateGameScore(score int, startedAt time.Time, now time.Time) error {
+	elapsed := now.Sub(startedAt)
+	if elapsed > constant.GameSessionExpiration {
+		return myerror.ApplicationError{
+			Message: fmt.Sprintf("game session is expired. startedAt=%s", startedAt.Format(time.RFC3339)),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	if maxScore := int(elapsed/time.Second) * constant.GameMaxScorePerSecond; score > maxScore {
+		return myerror.ApplicationError{
+			Message: fmt.Sprintf("game score is too high for play time. score=%d, maxScore=%d", score, maxScore),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	return nil
 }
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"20dojo-online/pkg/constant"
+	"20dojo-online/pkg/server/model"
+	"fmt"
+	"sort"
+)
+
+// RewardCalculator ゲーム終了時の報酬コインを計算する
+type RewardCalculator interface {
+	Calculate(input *RewardInput) *RewardBreakdown
+}
+
+// RewardInput 報酬コインの計算に利用する情報
+type RewardInput struct {
+	Score        int
+	HighScore    int                  // ゲーム終了前のハイスコア
+	EarnedToday  int                  // 本日獲得済みの報酬コイン
+	RewardEvents []*model.RewardEvent // 開催中の報酬イベント
+}
+
+// RewardBreakdown 報酬コインの内訳
+type RewardBreakdown struct {
+	BaseCoin   int            // スコアに応じた基本コイン
+	Bonuses    []*RewardBonus // ボーナスコイン
+	Multiplier float64        // イベントによる倍率
+	CappedCoin int            // 1日の上限により減らしたコイン
+	TotalCoin  int            // 獲得コインの合計
+}
+
+// RewardBonus ボーナスコインの内訳
+type RewardBonus struct {
+	Name string
+	Coin int
+}
+
+// StandardRewardCalculator 基本コインにボーナスを加え、開催中のイベントの倍率を掛けて1日の上限で切り詰める
+type StandardRewardCalculator struct {
+	CoinRate            float64
+	NewHighScoreBonus   int
+	ScoreThresholdBonus map[int]int
+	DailyCap            int
+}
+
+func NewStandardRewardCalculator() *StandardRewardCalculator {
+	return &StandardRewardCalculator{
+		CoinRate:            constant.RewardCoinRate,
+		NewHighScoreBonus:   constant.RewardNewHighScoreBonus,
+		ScoreThresholdBonus: constant.RewardScoreThresholdBonus,
+		DailyCap:            constant.RewardCoinDailyCap,
+	}
+}
+
+var _ RewardCalculator = (*StandardRewardCalculator)(nil)
+
+// Calculate 報酬コインを計算する
+func (c *StandardRewardCalculator) Calculate(input *RewardInput) *RewardBreakdown {
+	breakdown := &RewardBreakdown{
+		BaseCoin:   int(float64(input.Score) * c.CoinRate),
+		Bonuses:    []*RewardBonus{},
+		Multiplier: 1,
+	}
+	subtotal := breakdown.BaseCoin
+
+	// ハイスコア更新ボーナス
+	if input.Score > input.HighScore && c.NewHighScoreBonus > 0 {
+		breakdown.Bonuses = append(breakdown.Bonuses, &RewardBonus{Name: "new_high_score", Coin: c.NewHighScoreBonus})
+		subtotal += c.NewHighScoreBonus
+	}
+
+	// スコア閾値ボーナス. 到達した最も高い閾値のみ
+	thresholds := make([]int, 0, len(c.ScoreThresholdBonus))
+	for threshold := range c.ScoreThresholdBonus {
+		thresholds = append(thresholds, threshold)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(thresholds)))
+	for _, threshold := range thresholds {
+		if input.Score >= threshold {
+			bonus := c.ScoreThresholdBonus[threshold]
+			breakdown.Bonuses = append(breakdown.Bonuses, &RewardBonus{Name: fmt.Sprintf("score_%d", threshold), Coin: bonus})
+			subtotal += bonus
+			break
+		}
+	}
+
+	// 開催中のイベントの倍率. 複数開催中の場合は最も高い倍率を適用する
+	for _, rewardEvent := range input.RewardEvents {
+		if rewardEvent.Multiplier > breakdown.Multiplier {
+			breakdown.Multiplier = rewardEvent.Multiplier
+		}
+	}
+	total := int(float64(subtotal) * breakdown.Multiplier)
+
+	// 1日の上限
+	if c.DailyCap > 0 {
+		remaining := c.DailyCap - input.EarnedToday
+		if remaining < 0 {
+			remaining = 0
+		}
+		if total > remaining {
+			breakdown.CappedCoin = total - remaining
+			total = remaining
+		}
+	}
+
+	breakdown.TotalCoin = total
+	return breakdown
+}
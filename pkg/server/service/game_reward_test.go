@@ -0,0 +1,97 @@
+package service
+
+import (
+	"20dojo-online/pkg/server/model"
+	"reflect"
+	"testing"
+)
+
+func TestStandardRewardCalculator_Calculate(t *testing.T) {
+	calculator := &StandardRewardCalculator{
+		CoinRate:          0.1,
+		NewHighScoreBonus: 100,
+		ScoreThresholdBonus: map[int]int{
+			1000: 50,
+			5000: 300,
+		},
+		DailyCap: 10000,
+	}
+
+	tests := []struct {
+		name  string
+		input *RewardInput
+		want  *RewardBreakdown
+	}{
+		{
+			name:  "正常:ボーナスなし",
+			input: &RewardInput{Score: 500, HighScore: 1000},
+			want: &RewardBreakdown{
+				BaseCoin:   50,
+				Bonuses:    []*RewardBonus{},
+				Multiplier: 1,
+				TotalCoin:  50,
+			},
+		},
+		{
+			name:  "正常:ハイスコア更新と到達した最も高い閾値のボーナス",
+			input: &RewardInput{Score: 6000, HighScore: 1000},
+			want: &RewardBreakdown{
+				BaseCoin: 600,
+				Bonuses: []*RewardBonus{
+					{Name: "new_high_score", Coin: 100},
+					{Name: "score_5000", Coin: 300},
+				},
+				Multiplier: 1,
+				TotalCoin:  1000,
+			},
+		},
+		{
+			name: "正常:開催中のイベントのうち最も高い倍率を適用",
+			input: &RewardInput{
+				Score:     1000,
+				HighScore: 1000,
+				RewardEvents: []*model.RewardEvent{
+					{ID: "1", Multiplier: 1.5},
+					{ID: "2", Multiplier: 2},
+				},
+			},
+			want: &RewardBreakdown{
+				BaseCoin: 100,
+				Bonuses: []*RewardBonus{
+					{Name: "score_1000", Coin: 50},
+				},
+				Multiplier: 2,
+				TotalCoin:  300,
+			},
+		},
+		{
+			name:  "正常:1日の上限で切り詰め",
+			input: &RewardInput{Score: 500, HighScore: 1000, EarnedToday: 9980},
+			want: &RewardBreakdown{
+				BaseCoin:   50,
+				Bonuses:    []*RewardBonus{},
+				Multiplier: 1,
+				CappedCoin: 30,
+				TotalCoin:  20,
+			},
+		},
+		{
+			name:  "正常:上限に到達済み",
+			input: &RewardInput{Score: 500, HighScore: 1000, EarnedToday: 10000},
+			want: &RewardBreakdown{
+				BaseCoin:   50,
+				Bonuses:    []*RewardBonus{},
+				Multiplier: 1,
+				CappedCoin: 50,
+				TotalCoin:  0,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := calculator.Calculate(tt.input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Calculate() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
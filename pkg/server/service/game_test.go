@@ -0,0 +1,291 @@
+package service
+
+import (
+	"20dojo-online/pkg/constant"
+	"20dojo-online/pkg/leaderboard"
+	"20dojo-online/pkg/myerror"
+	"20dojo-online/pkg/server/model"
+	"20dojo-online/pkg/session"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+// TestGameService_FinishGame トランザクション開始前のセッション・スコアの検証
+// 報酬の付与とセッションの終了はTestGameService_finishGameで確認する
+func TestGameService_FinishGame(t *testing.T) {
+	signer := session.NewSigner([]byte("secret"))
+	startedAt := time.Now().Add(-60 * time.Second)
+
+	type args struct {
+		serviceRequest *FinishGameRequest
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		before  func(mock *mockRepository, args args)
+		want    *FinishGameResponse
+		wantErr bool
+	}{
+		{
+			name: "異常:他のユーザのセッション",
+			args: args{
+				serviceRequest: &FinishGameRequest{
+					UserId:    "UserId2",
+					SessionID: signer.Sign("SessionId1", "UserId1", startedAt),
+					Score:     1000,
+				},
+			},
+			before:  func(mock *mockRepository, args args) {},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "異常:改ざんされた開始日時",
+			args: args{
+				serviceRequest: &FinishGameRequest{
+					UserId:    "UserId1",
+					SessionID: "SessionId1.1.invalid",
+					Score:     1000,
+				},
+			},
+			before:  func(mock *mockRepository, args args) {},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "異常:有効期限切れのセッション",
+			args: args{
+				serviceRequest: &FinishGameRequest{
+					UserId:    "UserId1",
+					SessionID: signer.Sign("SessionId1", "UserId1", time.Now().Add(-constant.GameSessionExpiration-time.Minute)),
+					Score:     1000,
+				},
+			},
+			before:  func(mock *mockRepository, args args) {},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "異常:プレイ時間に対してスコアが高すぎる",
+			args: args{
+				serviceRequest: &FinishGameRequest{
+					UserId:    "UserId1",
+					SessionID: signer.Sign("SessionId1", "UserId1", startedAt),
+					Score:     2000000000,
+				},
+			},
+			before:  func(mock *mockRepository, args args) {},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMockRepository(ctrl)
+			tt.before(mock, tt.args)
+			s := NewGameService(mock.userRepository, mock.gameSessionRepository, mock.gamePlayRepository, mock.rewardEventRepository, leaderboard.NewMemoryFactory(), NewStandardRewardCalculator(), signer)
+			got, err := s.FinishGame(tt.args.serviceRequest)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FinishGame() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FinishGame() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// トランザクションはモックのリポジトリでは利用しないためnilを渡す
+func TestGameService_finishGame(t *testing.T) {
+	now := time.Date(2020, 8, 1, 12, 0, 0, 0, time.Local)
+	startedAt := now.Add(-60 * time.Second)
+	highScoreUpdatedAt := now.Add(-24 * time.Hour)
+	// 上限に達していない場合の獲得コイン
+	rewardCoin := NewStandardRewardCalculator().Calculate(&RewardInput{Score: 3000, HighScore: 1000}).TotalCoin
+
+	type args struct {
+		serviceRequest *FinishGameRequest
+	}
+
+	tests := []struct {
+		name          string
+		args          args
+		before        func(mock *mockRepository, args args)
+		wantUser      *model.User
+		wantTotalCoin int
+		wantErrCode   int // エラーの場合のHTTPステータスコード
+	}{
+		{
+			name: "正常:報酬コインを付与してハイスコアを更新",
+			args: args{
+				serviceRequest: &FinishGameRequest{UserId: "UserId1", SessionID: "signed", Score: 3000},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.gameSessionRepository.EXPECT().UpdateGameSessionFinishedAt(nil, "session1", "UserId1", now).Return(true, nil)
+				mock.userRepository.EXPECT().SelectUserByPrimaryKeyForUpdate(nil, "UserId1").Return(&model.User{
+					ID: "UserId1", Coin: 50, HighScore: 1000, HighScoreUpdatedAt: highScoreUpdatedAt,
+				}, nil)
+				mock.gamePlayRepository.EXPECT().SelectGamePlayCountByUserIDSince(nil, "UserId1", now.Add(-time.Hour)).Return(0, nil)
+				mock.gamePlayRepository.EXPECT().SelectRewardCoinSumByUserIDSince(nil, "UserId1", dailyResetTime(now)).Return(0, nil)
+				mock.userRepository.EXPECT().UpdateUserCoinAndHighScoreByPrimaryKey(nil, "UserId1", 50+rewardCoin, 3000, now).Return(nil)
+				mock.gamePlayRepository.EXPECT().InsertGamePlay(nil, &model.GamePlay{
+					UserID: "UserId1", Score: 3000, RewardCoin: rewardCoin, Duration: 60, CreatedAt: now,
+				}).Return(nil)
+			},
+			wantUser:      &model.User{ID: "UserId1", Coin: 50 + rewardCoin, HighScore: 3000, HighScoreUpdatedAt: now},
+			wantTotalCoin: rewardCoin,
+		},
+		{
+			name: "正常:今回の報酬で1日の上限を超える場合は超えた分を切り詰め",
+			args: args{
+				serviceRequest: &FinishGameRequest{UserId: "UserId1", SessionID: "signed", Score: 3000},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.gameSessionRepository.EXPECT().UpdateGameSessionFinishedAt(nil, "session1", "UserId1", now).Return(true, nil)
+				mock.userRepository.EXPECT().SelectUserByPrimaryKeyForUpdate(nil, "UserId1").Return(&model.User{
+					ID: "UserId1", Coin: 50, HighScore: 1000, HighScoreUpdatedAt: highScoreUpdatedAt,
+				}, nil)
+				mock.gamePlayRepository.EXPECT().SelectGamePlayCountByUserIDSince(nil, "UserId1", now.Add(-time.Hour)).Return(0, nil)
+				mock.gamePlayRepository.EXPECT().SelectRewardCoinSumByUserIDSince(nil, "UserId1", dailyResetTime(now)).Return(constant.RewardCoinDailyCap-100, nil)
+				mock.userRepository.EXPECT().UpdateUserCoinAndHighScoreByPrimaryKey(nil, "UserId1", 150, 3000, now).Return(nil)
+				mock.gamePlayRepository.EXPECT().InsertGamePlay(nil, &model.GamePlay{
+					UserID: "UserId1", Score: 3000, RewardCoin: 100, Duration: 60, CreatedAt: now,
+				}).Return(nil)
+			},
+			wantUser:      &model.User{ID: "UserId1", Coin: 150, HighScore: 3000, HighScoreUpdatedAt: now},
+			wantTotalCoin: 100,
+		},
+		{
+			name: "異常:1日の上限に達している",
+			args: args{
+				serviceRequest: &FinishGameRequest{UserId: "UserId1", SessionID: "signed", Score: 3000},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.gameSessionRepository.EXPECT().UpdateGameSessionFinishedAt(nil, "session1", "UserId1", now).Return(true, nil)
+				mock.userRepository.EXPECT().SelectUserByPrimaryKeyForUpdate(nil, "UserId1").Return(&model.User{
+					ID: "UserId1", Coin: 50, HighScore: 1000, HighScoreUpdatedAt: highScoreUpdatedAt,
+				}, nil)
+				mock.gamePlayRepository.EXPECT().SelectGamePlayCountByUserIDSince(nil, "UserId1", now.Add(-time.Hour)).Return(0, nil)
+				mock.gamePlayRepository.EXPECT().SelectRewardCoinSumByUserIDSince(nil, "UserId1", dailyResetTime(now)).Return(constant.RewardCoinDailyCap, nil)
+			},
+			wantErrCode: http.StatusTooManyRequests,
+		},
+		{
+			name: "異常:終了済みのセッション",
+			args: args{
+				serviceRequest: &FinishGameRequest{UserId: "UserId1", SessionID: "signed", Score: 3000},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.gameSessionRepository.EXPECT().UpdateGameSessionFinishedAt(nil, "session1", "UserId1", now).Return(false, nil)
+			},
+			wantErrCode: http.StatusBadRequest,
+		},
+		{
+			name: "異常:プレイ回数の上限",
+			args: args{
+				serviceRequest: &FinishGameRequest{UserId: "UserId1", SessionID: "signed", Score: 3000},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.gameSessionRepository.EXPECT().UpdateGameSessionFinishedAt(nil, "session1", "UserId1", now).Return(true, nil)
+				mock.userRepository.EXPECT().SelectUserByPrimaryKeyForUpdate(nil, "UserId1").Return(&model.User{ID: "UserId1"}, nil)
+				mock.gamePlayRepository.EXPECT().SelectGamePlayCountByUserIDSince(nil, "UserId1", now.Add(-time.Hour)).Return(constant.GameMaxPlaysPerHour, nil)
+			},
+			wantErrCode: http.StatusTooManyRequests,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMockRepository(ctrl)
+			tt.before(mock, tt.args)
+			s := NewGameService(mock.userRepository, mock.gameSessionRepository, mock.gamePlayRepository, mock.rewardEventRepository, leaderboard.NewMemoryFactory(), NewStandardRewardCalculator(), nil)
+			gotUser, gotBreakdown, err := s.finishGame(nil, tt.args.serviceRequest, "session1", startedAt, nil, now)
+			if tt.wantErrCode != 0 {
+				var appErr myerror.ApplicationError
+				if !errors.As(err, &appErr) || appErr.Code != tt.wantErrCode {
+					t.Errorf("finishGame() error = %v, wantErrCode %d", err, tt.wantErrCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("finishGame() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(gotUser, tt.wantUser) {
+				t.Errorf("finishGame() gotUser = %v, want %v", gotUser, tt.wantUser)
+			}
+			if gotBreakdown.TotalCoin != tt.wantTotalCoin {
+				t.Errorf("finishGame() gotBreakdown.TotalCoin = %d, want %d", gotBreakdown.TotalCoin, tt.wantTotalCoin)
+			}
+		})
+	}
+}
+
+// 同時に終了したゲームのランキングへの反映がコミットと逆順になっても、ハイスコアは下がらない
+func TestGameService_updateLeaderboards(t *testing.T) {
+	now := time.Now()
+	leaderboards := leaderboard.NewMemoryFactory()
+	s := NewGameService(nil, nil, nil, nil, leaderboards, NewStandardRewardCalculator(), nil)
+
+	// 後にコミットされたハイスコア3000を先に反映する
+	s.updateLeaderboards(&model.User{ID: "UserId1", HighScore: 3000, HighScoreUpdatedAt: now}, 3000, now)
+	s.updateLeaderboards(&model.User{ID: "UserId1", HighScore: 2000, HighScoreUpdatedAt: now.Add(-time.Second)}, 2000, now.Add(-time.Second))
+
+	for _, periodName := range []string{constant.RankingPeriodAllTime, constant.RankingPeriodDaily, constant.RankingPeriodWeekly} {
+		entries, err := newRankingPeriod(periodName, now).store(leaderboards).Range(1, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 1 || entries[0].Score != 3000 {
+			t.Errorf("%s leaderboard entries = %v, want score 3000", periodName, entries)
+		}
+	}
+}
+
+func TestGameService_GetGameHistory(t *testing.T) {
+	finishedAt := time.Unix(1598227200, 0)
+
+	ctrl := gomock.NewController(t)
+	mock := newMockRepository(ctrl)
+	mock.gamePlayRepository.EXPECT().SelectGamePlaysByUserID("UserId1", constant.GameHistoryListLimit, 1).Return([]*model.GamePlay{
+		{ID: 2, UserID: "UserId1", Score: 3000, RewardCoin: 300, Duration: 90, CreatedAt: finishedAt},
+		{ID: 1, UserID: "UserId1", Score: 1000, RewardCoin: 100, Duration: 60, CreatedAt: finishedAt.Add(-time.Hour)},
+	}, nil)
+	mock.gamePlayRepository.EXPECT().SelectGamePlayStatsByUserIDSince("UserId1", gomock.Any()).Return(&model.GamePlayStats{
+		PlayCount:    2,
+		AverageScore: 2000,
+		BestScore:    3000,
+	}, nil)
+
+	s := NewGameService(mock.userRepository, mock.gameSessionRepository, mock.gamePlayRepository, mock.rewardEventRepository, leaderboard.NewMemoryFactory(), NewStandardRewardCalculator(), nil)
+	got, err := s.GetGameHistory(&GetGameHistoryRequest{
+		UserID: "UserId1",
+		Limit:  constant.GameHistoryListLimit,
+		Offset: 1,
+	})
+	if err != nil {
+		t.Fatalf("GetGameHistory() error = %v", err)
+	}
+	want := &GetGameHistoryResponse{
+		GamePlays: []*GamePlay{
+			{Score: 3000, RewardCoin: 300, Duration: 90, FinishedAt: finishedAt},
+			{Score: 1000, RewardCoin: 100, Duration: 60, FinishedAt: finishedAt.Add(-time.Hour)},
+		},
+		Stats: &GamePlayStats{
+			PlayCount:    2,
+			AverageScore: 2000,
+			BestScore:    3000,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetGameHistory() got = %v, want %v", got, want)
+	}
+}
@@ -34,6 +34,21 @@ func (m *MockCollectionServiceInterface) EXPECT() *MockCollectionServiceInterfac
 	return m.recorder
 }
 
+// ClaimCollectionSetReward mocks base method.
+func (m *MockCollectionServiceInterface) ClaimCollectionSetReward(serviceRequest *service.ClaimCollectionSetRewardRequest) (*service.ClaimCollectionSetRewardResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimCollectionSetReward", serviceRequest)
+	ret0, _ := ret[0].(*service.ClaimCollectionSetRewardResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimCollectionSetReward indicates an expected call of ClaimCollectionSetReward.
+func (mr *MockCollectionServiceInterfaceMockRecorder) ClaimCollectionSetReward(serviceRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimCollectionSetReward", reflect.TypeOf((*MockCollectionServiceInterface)(nil).ClaimCollectionSetReward), serviceRequest)
+}
+
 // GetUserCollectionList mocks base method.
 func (m *MockCollectionServiceInterface) GetUserCollectionList(serviceRequest *service.GetUserCollectionListRequest) (*service.GetUserCollectionListResponse, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: friend.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	service "20dojo-online/pkg/server/service"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockFriendServiceInterface is a mock of FriendServiceInterface interface.
+type MockFriendServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockFriendServiceInterfaceMockRecorder
+}
+
+// MockFriendServiceInterfaceMockRecorder is the mock recorder for MockFriendServiceInterface.
+type MockFriendServiceInterfaceMockRecorder struct {
+	mock *MockFriendServiceInterface
+}
+
+// NewMockFriendServiceInterface creates a new mock instance.
+func NewMockFriendServiceInterface(ctrl *gomock.Controller) *MockFriendServiceInterface {
+	mock := &MockFriendServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockFriendServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFriendServiceInterface) EXPECT() *MockFriendServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AcceptFriend mocks base method.
+func (m *MockFriendServiceInterface) AcceptFriend(serviceRequest *service.AcceptFriendRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptFriend", serviceRequest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptFriend indicates an expected call of AcceptFriend.
+func (mr *MockFriendServiceInterfaceMockRecorder) AcceptFriend(serviceRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptFriend", reflect.TypeOf((*MockFriendServiceInterface)(nil).AcceptFriend), serviceRequest)
+}
+
+// GetFriendList mocks base method.
+func (m *MockFriendServiceInterface) GetFriendList(serviceRequest *service.GetFriendListRequest) (*service.GetFriendListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFriendList", serviceRequest)
+	ret0, _ := ret[0].(*service.GetFriendListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFriendList indicates an expected call of GetFriendList.
+func (mr *MockFriendServiceInterfaceMockRecorder) GetFriendList(serviceRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFriendList", reflect.TypeOf((*MockFriendServiceInterface)(nil).GetFriendList), serviceRequest)
+}
+
+// RemoveFriend mocks base method.
+func (m *MockFriendServiceInterface) RemoveFriend(serviceRequest *service.RemoveFriendRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFriend", serviceRequest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFriend indicates an expected call of RemoveFriend.
+func (mr *MockFriendServiceInterfaceMockRecorder) RemoveFriend(serviceRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFriend", reflect.TypeOf((*MockFriendServiceInterface)(nil).RemoveFriend), serviceRequest)
+}
+
+// RequestFriend mocks base method.
+func (m *MockFriendServiceInterface) RequestFriend(serviceRequest *service.RequestFriendRequest) (*service.RequestFriendResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestFriend", serviceRequest)
+	ret0, _ := ret[0].(*service.RequestFriendResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestFriend indicates an expected call of RequestFriend.
+func (mr *MockFriendServiceInterfaceMockRecorder) RequestFriend(serviceRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestFriend", reflect.TypeOf((*MockFriendServiceInterface)(nil).RequestFriend), serviceRequest)
+}
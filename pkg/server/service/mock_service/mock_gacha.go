@@ -48,3 +48,62 @@ func (mr *MockGachaServiceInterfaceMockRecorder) DrawGacha(serviceRequest interf
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DrawGacha", reflect.TypeOf((*MockGachaServiceInterface)(nil).DrawGacha), serviceRequest)
 }
+
+// GetGachaHistory mocks base method.
+func (m *MockGachaServiceInterface) GetGachaHistory(serviceRequest *service.GetGachaHistoryRequest) (*service.GetGachaHistoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGachaHistory", serviceRequest)
+	ret0, _ := ret[0].(*service.GetGachaHistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGachaHistory indicates an expected call of GetGachaHistory.
+func (mr *MockGachaServiceInterfaceMockRecorder) GetGachaHistory(serviceRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGachaHistory", reflect.TypeOf((*MockGachaServiceInterface)(nil).GetGachaHistory), serviceRequest)
+}
+
+// GetGachaList mocks base method.
+func (m *MockGachaServiceInterface) GetGachaList() (*service.GetGachaListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGachaList")
+	ret0, _ := ret[0].(*service.GetGachaListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGachaList indicates an expected call of GetGachaList.
+func (mr *MockGachaServiceInterfaceMockRecorder) GetGachaList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGachaList", reflect.TypeOf((*MockGachaServiceInterface)(nil).GetGachaList))
+}
+
+// GetGachaRates mocks base method.
+func (m *MockGachaServiceInterface) GetGachaRates(serviceRequest *service.GetGachaRatesRequest) (*service.GetGachaRatesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGachaRates", serviceRequest)
+	ret0, _ := ret[0].(*service.GetGachaRatesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGachaRates indicates an expected call of GetGachaRates.
+func (mr *MockGachaServiceInterfaceMockRecorder) GetGachaRates(serviceRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGachaRates", reflect.TypeOf((*MockGachaServiceInterface)(nil).GetGachaRates), serviceRequest)
+}
+
+// ResetGachaBox mocks base method.
+func (m *MockGachaServiceInterface) ResetGachaBox(serviceRequest *service.ResetGachaBoxRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetGachaBox", serviceRequest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetGachaBox indicates an expected call of ResetGachaBox.
+func (mr *MockGachaServiceInterfaceMockRecorder) ResetGachaBox(serviceRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetGachaBox", reflect.TypeOf((*MockGachaServiceInterface)(nil).ResetGachaBox), serviceRequest)
+}
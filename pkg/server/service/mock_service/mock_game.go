@@ -48,3 +48,33 @@ func (mr *MockGameServiceInterfaceMockRecorder) FinishGame(serviceRequest interf
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishGame", reflect.TypeOf((*MockGameServiceInterface)(nil).FinishGame), serviceRequest)
 }
+
+// GetGameHistory mocks base method.
+func (m *MockGameServiceInterface) GetGameHistory(serviceRequest *service.GetGameHistoryRequest) (*service.GetGameHistoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGameHistory", serviceRequest)
+	ret0, _ := ret[0].(*service.GetGameHistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGameHistory indicates an expected call of GetGameHistory.
+func (mr *MockGameServiceInterfaceMockRecorder) GetGameHistory(serviceRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGameHistory", reflect.TypeOf((*MockGameServiceInterface)(nil).GetGameHistory), serviceRequest)
+}
+
+// StartGame mocks base method.
+func (m *MockGameServiceInterface) StartGame(serviceRequest *service.StartGameRequest) (*service.StartGameResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartGame", serviceRequest)
+	ret0, _ := ret[0].(*service.StartGameResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartGame indicates an expected call of StartGame.
+func (mr *MockGameServiceInterfaceMockRecorder) StartGame(serviceRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartGame", reflect.TypeOf((*MockGameServiceInterface)(nil).StartGame), serviceRequest)
+}
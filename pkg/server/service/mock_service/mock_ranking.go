@@ -34,6 +34,51 @@ func (m *MockRankingServiceInterface) EXPECT() *MockRankingServiceInterfaceMockR
 	return m.recorder
 }
 
+// CloseRankingPeriod mocks base method.
+func (m *MockRankingServiceInterface) CloseRankingPeriod(serviceRequest *service.CloseRankingPeriodRequest) (*service.CloseRankingPeriodResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseRankingPeriod", serviceRequest)
+	ret0, _ := ret[0].(*service.CloseRankingPeriodResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseRankingPeriod indicates an expected call of CloseRankingPeriod.
+func (mr *MockRankingServiceInterfaceMockRecorder) CloseRankingPeriod(serviceRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseRankingPeriod", reflect.TypeOf((*MockRankingServiceInterface)(nil).CloseRankingPeriod), serviceRequest)
+}
+
+// GetFriendRankInfoList mocks base method.
+func (m *MockRankingServiceInterface) GetFriendRankInfoList(serviceRequest *service.GetFriendRankInfoListRequest) (*service.GetRankInfoListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFriendRankInfoList", serviceRequest)
+	ret0, _ := ret[0].(*service.GetRankInfoListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFriendRankInfoList indicates an expected call of GetFriendRankInfoList.
+func (mr *MockRankingServiceInterfaceMockRecorder) GetFriendRankInfoList(serviceRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFriendRankInfoList", reflect.TypeOf((*MockRankingServiceInterface)(nil).GetFriendRankInfoList), serviceRequest)
+}
+
+// GetMyRankInfo mocks base method.
+func (m *MockRankingServiceInterface) GetMyRankInfo(serviceRequest *service.GetMyRankInfoRequest) (*service.GetMyRankInfoResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMyRankInfo", serviceRequest)
+	ret0, _ := ret[0].(*service.GetMyRankInfoResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMyRankInfo indicates an expected call of GetMyRankInfo.
+func (mr *MockRankingServiceInterfaceMockRecorder) GetMyRankInfo(serviceRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMyRankInfo", reflect.TypeOf((*MockRankingServiceInterface)(nil).GetMyRankInfo), serviceRequest)
+}
+
 // GetRankInfoList mocks base method.
 func (m *MockRankingServiceInterface) GetRankInfoList(serviceRequest *service.GetRankInfoListRequest) (*service.GetRankInfoListResponse, error) {
 	m.ctrl.T.Helper()
@@ -48,3 +93,17 @@ func (mr *MockRankingServiceInterfaceMockRecorder) GetRankInfoList(serviceReques
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRankInfoList", reflect.TypeOf((*MockRankingServiceInterface)(nil).GetRankInfoList), serviceRequest)
 }
+
+// RebuildLeaderboard mocks base method.
+func (m *MockRankingServiceInterface) RebuildLeaderboard() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebuildLeaderboard")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RebuildLeaderboard indicates an expected call of RebuildLeaderboard.
+func (mr *MockRankingServiceInterfaceMockRecorder) RebuildLeaderboard() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebuildLeaderboard", reflect.TypeOf((*MockRankingServiceInterface)(nil).RebuildLeaderboard))
+}
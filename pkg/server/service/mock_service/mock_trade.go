@@ -0,0 +1,107 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: trade.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	service "20dojo-online/pkg/server/service"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTradeServiceInterface is a mock of TradeServiceInterface interface.
+type MockTradeServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTradeServiceInterfaceMockRecorder
+}
+
+// MockTradeServiceInterfaceMockRecorder is the mock recorder for MockTradeServiceInterface.
+type MockTradeServiceInterfaceMockRecorder struct {
+	mock *MockTradeServiceInterface
+}
+
+// NewMockTradeServiceInterface creates a new mock instance.
+func NewMockTradeServiceInterface(ctrl *gomock.Controller) *MockTradeServiceInterface {
+	mock := &MockTradeServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockTradeServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTradeServiceInterface) EXPECT() *MockTradeServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AcceptTrade mocks base method.
+func (m *MockTradeServiceInterface) AcceptTrade(serviceRequest *service.TradeActionRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptTrade", serviceRequest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptTrade indicates an expected call of AcceptTrade.
+func (mr *MockTradeServiceInterfaceMockRecorder) AcceptTrade(serviceRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptTrade", reflect.TypeOf((*MockTradeServiceInterface)(nil).AcceptTrade), serviceRequest)
+}
+
+// CancelTrade mocks base method.
+func (m *MockTradeServiceInterface) CancelTrade(serviceRequest *service.TradeActionRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelTrade", serviceRequest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelTrade indicates an expected call of CancelTrade.
+func (mr *MockTradeServiceInterfaceMockRecorder) CancelTrade(serviceRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelTrade", reflect.TypeOf((*MockTradeServiceInterface)(nil).CancelTrade), serviceRequest)
+}
+
+// DeclineTrade mocks base method.
+func (m *MockTradeServiceInterface) DeclineTrade(serviceRequest *service.TradeActionRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineTrade", serviceRequest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeclineTrade indicates an expected call of DeclineTrade.
+func (mr *MockTradeServiceInterfaceMockRecorder) DeclineTrade(serviceRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineTrade", reflect.TypeOf((*MockTradeServiceInterface)(nil).DeclineTrade), serviceRequest)
+}
+
+// GetTradeList mocks base method.
+func (m *MockTradeServiceInterface) GetTradeList(serviceRequest *service.GetTradeListRequest) (*service.GetTradeListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTradeList", serviceRequest)
+	ret0, _ := ret[0].(*service.GetTradeListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTradeList indicates an expected call of GetTradeList.
+func (mr *MockTradeServiceInterfaceMockRecorder) GetTradeList(serviceRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTradeList", reflect.TypeOf((*MockTradeServiceInterface)(nil).GetTradeList), serviceRequest)
+}
+
+// ProposeTrade mocks base method.
+func (m *MockTradeServiceInterface) ProposeTrade(serviceRequest *service.ProposeTradeRequest) (*service.ProposeTradeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProposeTrade", serviceRequest)
+	ret0, _ := ret[0].(*service.ProposeTradeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProposeTrade indicates an expected call of ProposeTrade.
+func (mr *MockTradeServiceInterfaceMockRecorder) ProposeTrade(serviceRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposeTrade", reflect.TypeOf((*MockTradeServiceInterface)(nil).ProposeTrade), serviceRequest)
+}
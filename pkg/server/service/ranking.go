@@ -2,15 +2,60 @@
 
 package service
 
-import "20dojo-online/pkg/server/model"
+import (
+	"20dojo-online/pkg/constant"
+	"20dojo-online/pkg/cursor"
+	"20dojo-online/pkg/db"
+	"20dojo-online/pkg/leaderboard"
+	"20dojo-online/pkg/myerror"
+	"20dojo-online/pkg/server/model"
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+)
 
 type GetRankInfoListRequest struct {
 	Limit  int
 	Offset int
+	Cursor string // 前のページのNextCursor. 指定した場合はOffsetより優先する
+	Mode   string // 順位の付け方. 空の場合はconstant.RankingModeCompetition
+	Period string // 集計期間. 空の場合はconstant.RankingPeriodAllTime
 }
 
 type GetRankInfoListResponse struct {
 	RankInfoList []*RankInfo
+	NextCursor   string // 次のページがない場合は空
+}
+
+type GetMyRankInfoRequest struct {
+	UserID    string
+	Neighbors int    // 前後に含めるユーザ数
+	Mode      string // 順位の付け方. 空の場合はconstant.RankingModeCompetition
+}
+
+type GetMyRankInfoResponse struct {
+	MyRankInfo   *RankInfo
+	RankInfoList []*RankInfo // 前後のユーザを含むランキング情報
+}
+
+type GetFriendRankInfoListRequest struct {
+	UserID string
+	Mode   string // 順位の付け方. 空の場合はconstant.RankingModeCompetition
+}
+
+type CloseRankingPeriodRequest struct {
+	Period string    // 集計期間(日別・週別)
+	Now    time.Time // この日時の直前に終了した期間を締める
+}
+
+type CloseRankingPeriodResponse struct {
+	StartAt       time.Time
+	EndAt         time.Time
+	AlreadyClosed bool // 締め処理済みの場合はtrue
+	Snapshots     []*model.RankingSnapshot
 }
 
 // RankInfo ランキング情報
@@ -21,20 +66,48 @@ type RankInfo struct {
 	Score    int
 }
 
+// rankingCursor ランキングのカーソル. 前のページの最後のユーザの並び順のキーを保持する
+type rankingCursor struct {
+	Score      int    `json:"s"`
+	AchievedAt int64  `json:"t"` // UNIX時間(ナノ秒)
+	UserID     string `json:"u"`
+}
+
 type RankingService struct {
-	UserRepository model.UserRepositoryInterface
+	UserRepository            model.UserRepositoryInterface
+	GamePlayRepository        model.GamePlayRepositoryInterface
+	RankingSeasonRepository   model.RankingSeasonRepositoryInterface
+	RankingSnapshotRepository model.RankingSnapshotRepositoryInterface
+	FriendRepository          model.FriendRepositoryInterface
+	Leaderboards              leaderboard.Factory
 }
 
 var _ RankingServiceInterface = (*RankingService)(nil)
 
-func NewRankingService(userRepository model.UserRepositoryInterface) *RankingService {
+func NewRankingService(
+	userRepository model.UserRepositoryInterface,
+	gamePlayRepository model.GamePlayRepositoryInterface,
+	rankingSeasonRepository model.RankingSeasonRepositoryInterface,
+	rankingSnapshotRepository model.RankingSnapshotRepositoryInterface,
+	friendRepository model.FriendRepositoryInterface,
+	leaderboards leaderboard.Factory,
+) *RankingService {
 	return &RankingService{
-		UserRepository: userRepository,
+		UserRepository:            userRepository,
+		GamePlayRepository:        gamePlayRepository,
+		RankingSeasonRepository:   rankingSeasonRepository,
+		RankingSnapshotRepository: rankingSnapshotRepository,
+		FriendRepository:          friendRepository,
+		Leaderboards:              leaderboards,
 	}
 }
 
 type RankingServiceInterface interface {
 	GetRankInfoList(serviceRequest *GetRankInfoListRequest) (*GetRankInfoListResponse, error)
+	GetMyRankInfo(serviceRequest *GetMyRankInfoRequest) (*GetMyRankInfoResponse, error)
+	GetFriendRankInfoList(serviceRequest *GetFriendRankInfoListRequest) (*GetRankInfoListResponse, error)
+	RebuildLeaderboard() error
+	CloseRankingPeriod(serviceRequest *CloseRankingPeriodRequest) (*CloseRankingPeriodResponse, error)
 }
 
 var _ RankingServiceInterface = (*RankingService)(nil)
@@ -42,24 +115,388 @@ var _ RankingServiceInterface = (*RankingService)(nil)
 // GetRankInfoList ランキング情報取得時のロジック
 func (s *RankingService) GetRankInfoList(serviceRequest *GetRankInfoListRequest) (*GetRankInfoListResponse, error) {
 
-	// ハイスコア順に指定順位から指定件数を取得
-	usersOrderByHighScoreDesc, err := s.UserRepository.SelectUsersOrderByHighScoreDesc(serviceRequest.Limit, serviceRequest.Offset)
+	store := newRankingPeriod(serviceRequest.Period, time.Now()).store(s.Leaderboards)
+
+	// カーソルの指定がある場合は、前のページの最後のユーザの次から取得する
+	// 前のページの取得後にスコアが変わっても、並び順のキーで位置を求めるため重複や抜けが起きない
+	offset := serviceRequest.Offset
+	if serviceRequest.Cursor != "" {
+		var rankingCursor rankingCursor
+		if err := cursor.Decode(serviceRequest.Cursor, &rankingCursor); err != nil {
+			return nil, myerror.ApplicationError{
+				Message:       "failed to decode ranking cursor",
+				OriginalError: err,
+				Code:          http.StatusBadRequest,
+			}
+		}
+		count, err := store.CountUntil(&leaderboard.Entry{
+			UserID:     rankingCursor.UserID,
+			Score:      rankingCursor.Score,
+			AchievedAt: time.Unix(0, rankingCursor.AchievedAt),
+		})
+		if err != nil {
+			return nil, err
+		}
+		offset = count + 1
+	}
+
+	// 集計期間のランキングからスコア順に指定順位から指定件数を取得. 次のページの有無を判定するため1件多く取得する
+	entries, err := store.Range(offset, serviceRequest.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return &GetRankInfoListResponse{}, nil
+	}
+	var nextCursor string
+	if len(entries) > serviceRequest.Limit {
+		entries = entries[:serviceRequest.Limit]
+		last := entries[len(entries)-1]
+		if nextCursor, err = cursor.Encode(&rankingCursor{
+			Score:      last.Score,
+			AchievedAt: last.AchievedAt.UnixNano(),
+			UserID:     last.UserID,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	// ユーザ名はユーザ情報から取得する
+	userIDs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		userIDs = append(userIDs, entry.UserID)
+	}
+	users, err := s.UserRepository.SelectUsersByPrimaryKeys(userIDs)
+	if err != nil {
+		return nil, err
+	}
+	userMap := make(map[string]*model.User, len(users))
+	for _, user := range users {
+		userMap[user.ID] = user
+	}
+
+	// 先頭のユーザの順位は自分より高いスコアの件数から求める. 同点のユーザが前のページにいる場合も同順位にするため
+	rank, err := selectRank(store, serviceRequest.Mode, entries[0].Score)
 	if err != nil {
 		return nil, err
 	}
 
 	var rankInfoList []*RankInfo
 
-	// ランク付け
-	for index, userRankedIn := range usersOrderByHighScoreDesc {
+	// ランク付け. 同点は同順位とする
+	for index, entry := range entries {
+		if index > 0 && entry.Score != entries[index-1].Score {
+			if serviceRequest.Mode == constant.RankingModeDense {
+				rank++
+			} else {
+				rank = offset + index
+			}
+		}
+		user, ok := userMap[entry.UserID]
+		if !ok {
+			return nil, fmt.Errorf("user in leaderboard not found. userID=%s", entry.UserID)
+		}
 		rankInfo := &RankInfo{
-			UserId:   userRankedIn.ID,
-			UserName: userRankedIn.Name,
-			Rank:     serviceRequest.Offset + index,
-			Score:    userRankedIn.HighScore,
+			UserId:   user.ID,
+			UserName: user.Name,
+			Rank:     rank,
+			Score:    entry.Score,
 		}
 		rankInfoList = append(rankInfoList, rankInfo)
 	}
 
+	return &GetRankInfoListResponse{
+		RankInfoList: rankInfoList,
+		NextCursor:   nextCursor,
+	}, nil
+}
+
+// GetMyRankInfo 自分の順位と前後のユーザのランキング情報取得時のロジック
+func (s *RankingService) GetMyRankInfo(serviceRequest *GetMyRankInfoRequest) (*GetMyRankInfoResponse, error) {
+	// 並び順での自分の位置
+	store := newRankingPeriod(constant.RankingPeriodAllTime, time.Now()).store(s.Leaderboards)
+	position, err := store.Position(serviceRequest.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if position == 0 {
+		// ランキングに未登録(ゲーム未プレイ)の場合はユーザ情報から登録する
+		// ロックせずに取得したユーザ情報のため、同時に終了したゲームのハイスコアを上書きしないよう高い場合のみ登録する
+		user, err := s.UserRepository.SelectUserByPrimaryKey(serviceRequest.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, fmt.Errorf("user not found. userID=%s", serviceRequest.UserID)
+		}
+		if err = store.SetIfHigher(newLeaderboardEntry(user)); err != nil {
+			return nil, err
+		}
+		if position, err = store.Position(serviceRequest.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	// 前後のユーザを含む範囲のランキング情報を取得
+	offset := position - serviceRequest.Neighbors
+	if offset < 1 {
+		offset = 1
+	}
+	res, err := s.GetRankInfoList(&GetRankInfoListRequest{
+		Limit:  position - offset + 1 + serviceRequest.Neighbors,
+		Offset: offset,
+		Mode:   serviceRequest.Mode,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rankInfo := range res.RankInfoList {
+		if rankInfo.UserId == serviceRequest.UserID {
+			return &GetMyRankInfoResponse{
+				MyRankInfo:   rankInfo,
+				RankInfoList: res.RankInfoList,
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("user is not in ranking. userID=%s, position=%d", serviceRequest.UserID, position)
+}
+
+// GetFriendRankInfoList 自分とフレンドのランキング情報取得時のロジック
+// フレンド数には上限があるため、ランキングを使わずユーザ情報のハイスコアから順位を付ける
+func (s *RankingService) GetFriendRankInfoList(serviceRequest *GetFriendRankInfoListRequest) (*GetRankInfoListResponse, error) {
+	friends, err := s.FriendRepository.SelectFriendsByUserID(serviceRequest.UserID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(friends)+1)
+	userIDs = append(userIDs, serviceRequest.UserID)
+	for _, friend := range friends {
+		userIDs = append(userIDs, friend.FriendUserID)
+	}
+	users, err := s.UserRepository.SelectUsersByPrimaryKeys(userIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) != len(userIDs) {
+		return nil, fmt.Errorf("user or friend not found. userID=%s", serviceRequest.UserID)
+	}
+
+	// 全体のランキングと同じ並び順にする
+	entries := make([]*leaderboard.Entry, 0, len(users))
+	userMap := make(map[string]*model.User, len(users))
+	for _, user := range users {
+		entries = append(entries, newLeaderboardEntry(user))
+		userMap[user.ID] = user
+	}
+	leaderboard.SortEntries(entries)
+
+	// ランク付け. 同点は同順位とする
+	rankInfoList := make([]*RankInfo, 0, len(entries))
+	rank := 1
+	for index, entry := range entries {
+		if index > 0 && entry.Score != entries[index-1].Score {
+			if serviceRequest.Mode == constant.RankingModeDense {
+				rank++
+			} else {
+				rank = index + 1
+			}
+		}
+		rankInfoList = append(rankInfoList, &RankInfo{
+			UserId:   entry.UserID,
+			UserName: userMap[entry.UserID].Name,
+			Rank:     rank,
+			Score:    entry.Score,
+		})
+	}
+
 	return &GetRankInfoListResponse{RankInfoList: rankInfoList}, nil
 }
+
+// RebuildLeaderboard 全ユーザのハイスコアと現在の期間のゲームプレイ記録からランキングを作り直す
+func (s *RankingService) RebuildLeaderboard() error {
+	users, err := s.UserRepository.SelectUsersAll()
+	if err != nil {
+		return err
+	}
+	entries := make([]*leaderboard.Entry, 0, len(users))
+	for _, user := range users {
+		entries = append(entries, newLeaderboardEntry(user))
+	}
+	now := time.Now()
+	if err = newRankingPeriod(constant.RankingPeriodAllTime, now).store(s.Leaderboards).Rebuild(entries); err != nil {
+		return err
+	}
+
+	// 期間別のランキングは期間内の最高スコアで作り直す
+	for _, periodName := range []string{constant.RankingPeriodDaily, constant.RankingPeriodWeekly} {
+		period := newRankingPeriod(periodName, now)
+		entries, err := s.selectPeriodEntries(period)
+		if err != nil {
+			return err
+		}
+		if err = period.store(s.Leaderboards).Rebuild(entries); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CloseRankingPeriod 直前に終了した期間別ランキングの締め処理のロジック
+// 期間内の最高スコアで順位を確定して上位を記録し、報酬コインを付与する. 締め処理済みの期間は何もしない
+func (s *RankingService) CloseRankingPeriod(serviceRequest *CloseRankingPeriodRequest) (*CloseRankingPeriodResponse, error) {
+	rewardCoins, ok := constant.RankingPeriodRewardCoins[serviceRequest.Period]
+	if !ok {
+		return nil, fmt.Errorf("ranking period cannot be closed. period=%s", serviceRequest.Period)
+	}
+	period := newRankingPeriod(serviceRequest.Period, serviceRequest.Now).previous()
+
+	// Redisのデータは期限切れの可能性があるため、ゲームプレイ記録から順位を確定する
+	entries, err := s.selectPeriodEntries(period)
+	if err != nil {
+		return nil, err
+	}
+	var snapshots []*model.RankingSnapshot
+	rank := 0
+	for index, entry := range entries {
+		if index >= constant.RankingSnapshotLimit {
+			break
+		}
+		if index == 0 || entry.Score != entries[index-1].Score {
+			rank = index + 1
+		}
+		rewardCoin := 0
+		if rank <= len(rewardCoins) {
+			rewardCoin = rewardCoins[rank-1]
+		}
+		snapshots = append(snapshots, &model.RankingSnapshot{
+			Period:     period.name,
+			StartAt:    period.startAt,
+			UserID:     entry.UserID,
+			Rank:       rank,
+			Score:      entry.Score,
+			RewardCoin: rewardCoin,
+		})
+	}
+
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.closeRankingPeriod(tx, period, snapshots, serviceRequest.Now)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			log.Println(fmt.Sprintf("Rollback Error in closing ranking period: %s", rollbackErr))
+		}
+		return nil, err
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return nil, commitErr
+	}
+	return res, nil
+}
+
+// closeRankingPeriod トランザクション内で締め処理済みの期間と上位ユーザを記録し、報酬コインを付与する
+// 締め処理済みの期間は何も更新せずに返す
+func (s *RankingService) closeRankingPeriod(tx *sql.Tx, period *rankingPeriod, snapshots []*model.RankingSnapshot, now time.Time) (*CloseRankingPeriodResponse, error) {
+	rankingSeason, err := s.RankingSeasonRepository.SelectRankingSeasonByPrimaryKey(tx, period.name, period.startAt)
+	if err != nil {
+		return nil, err
+	}
+	if rankingSeason != nil {
+		return &CloseRankingPeriodResponse{
+			StartAt:       period.startAt,
+			EndAt:         period.endAt,
+			AlreadyClosed: true,
+		}, nil
+	}
+
+	// 主キーの重複により、同時に締め処理を実行しても一方のみ成功する
+	if err = s.RankingSeasonRepository.InsertRankingSeason(tx, &model.RankingSeason{
+		Period:   period.name,
+		StartAt:  period.startAt,
+		EndAt:    period.endAt,
+		ClosedAt: now,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err = s.RankingSnapshotRepository.BulkInsertRankingSnapshot(tx, snapshots); err != nil {
+		return nil, err
+	}
+
+	// 報酬コインの付与. 他の期間の締め処理やトレードとのデッドロックを防ぐため、順位ではなくユーザIDの順にロックする
+	rewardSnapshots := make([]*model.RankingSnapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		if snapshot.RewardCoin > 0 {
+			rewardSnapshots = append(rewardSnapshots, snapshot)
+		}
+	}
+	sort.Slice(rewardSnapshots, func(i, j int) bool {
+		return rewardSnapshots[i].UserID < rewardSnapshots[j].UserID
+	})
+	for _, snapshot := range rewardSnapshots {
+		user, err := s.UserRepository.SelectUserByPrimaryKeyForUpdate(tx, snapshot.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, fmt.Errorf("user not found. userID=%s", snapshot.UserID)
+		}
+		if err = s.UserRepository.UpdateUserCoinByPrimaryKey(tx, user.ID, user.Coin+snapshot.RewardCoin); err != nil {
+			return nil, err
+		}
+	}
+
+	return &CloseRankingPeriodResponse{
+		StartAt:   period.startAt,
+		EndAt:     period.endAt,
+		Snapshots: snapshots,
+	}, nil
+}
+
+// selectPeriodEntries 期間内のゲームプレイ記録からユーザごとの最高スコアを並び順で取得する
+func (s *RankingService) selectPeriodEntries(period *rankingPeriod) ([]*leaderboard.Entry, error) {
+	bestScores, err := s.GamePlayRepository.SelectBestScoresBetween(period.startAt, period.endAt)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*leaderboard.Entry, 0, len(bestScores))
+	for _, bestScore := range bestScores {
+		entries = append(entries, &leaderboard.Entry{
+			UserID:     bestScore.UserID,
+			Score:      bestScore.Score,
+			AchievedAt: bestScore.AchievedAt,
+		})
+	}
+	leaderboard.SortEntries(entries)
+	return entries, nil
+}
+
+// selectRank 指定したスコアの順位を取得する
+func selectRank(store leaderboard.Store, mode string, highScore int) (int, error) {
+	var (
+		count int
+		err   error
+	)
+	if mode == constant.RankingModeDense {
+		count, err = store.CountDistinctGreater(highScore)
+	} else {
+		count, err = store.CountGreater(highScore)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return count + 1, nil
+}
+
+// newLeaderboardEntry ユーザ情報からランキングに登録するハイスコアを作成する
+func newLeaderboardEntry(user *model.User) *leaderboard.Entry {
+	return &leaderboard.Entry{
+		UserID:     user.ID,
+		Score:      user.HighScore,
+		AchievedAt: user.HighScoreUpdatedAt,
+	}
+}
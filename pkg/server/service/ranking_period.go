@@ -0,0 +1,51 @@
+package service
+
+import (
+	"20dojo-online/pkg/constant"
+	"20dojo-online/pkg/leaderboard"
+	"fmt"
+	"time"
+)
+
+// rankingPeriod ランキングの集計期間
+type rankingPeriod struct {
+	name    string
+	startAt time.Time // 全期間の場合はゼロ値
+	endAt   time.Time // 全期間の場合はゼロ値
+}
+
+// newRankingPeriod 指定日時を含む集計期間を返す. 日別・週別以外は全期間とする
+func newRankingPeriod(name string, now time.Time) *rankingPeriod {
+	switch name {
+	case constant.RankingPeriodDaily:
+		startAt := dailyResetTime(now)
+		return &rankingPeriod{name: name, startAt: startAt, endAt: startAt.AddDate(0, 0, 1)}
+	case constant.RankingPeriodWeekly:
+		startAt := dailyResetTime(now)
+		startAt = startAt.AddDate(0, 0, -((int(startAt.Weekday()) + 6) % 7)) // 月曜日まで戻す
+		return &rankingPeriod{name: name, startAt: startAt, endAt: startAt.AddDate(0, 0, 7)}
+	}
+	return &rankingPeriod{name: constant.RankingPeriodAllTime}
+}
+
+// isAllTime 全期間のランキングであるか
+func (p *rankingPeriod) isAllTime() bool {
+	return p.name == constant.RankingPeriodAllTime
+}
+
+// previous 直前の集計期間を返す
+func (p *rankingPeriod) previous() *rankingPeriod {
+	if p.isAllTime() {
+		return p
+	}
+	return newRankingPeriod(p.name, p.startAt.Add(-time.Second))
+}
+
+// store 集計期間のランキングを取得する. 期間ごとにキーが変わるため、期間が切り替わると空のランキングになる
+func (p *rankingPeriod) store(leaderboards leaderboard.Factory) leaderboard.Store {
+	if p.isAllTime() {
+		return leaderboards.Store(constant.LeaderboardKey, 0)
+	}
+	key := fmt.Sprintf("%s:%s:%s", constant.LeaderboardKey, p.name, p.startAt.Format("20060102"))
+	return leaderboards.Store(key, constant.RankingPeriodRetention)
+}
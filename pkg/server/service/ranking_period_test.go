@@ -0,0 +1,62 @@
+package service
+
+import (
+	"20dojo-online/pkg/constant"
+	"testing"
+	"time"
+)
+
+func TestNewRankingPeriod(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	tests := []struct {
+		name        string
+		period      string
+		now         time.Time
+		wantStartAt time.Time
+		wantEndAt   time.Time
+	}{
+		{
+			name:        "正常:日別はリセット時刻から1日",
+			period:      constant.RankingPeriodDaily,
+			now:         time.Date(2020, 8, 26, 3, 0, 0, 0, jst),
+			wantStartAt: time.Date(2020, 8, 25, 4, 0, 0, 0, jst),
+			wantEndAt:   time.Date(2020, 8, 26, 4, 0, 0, 0, jst),
+		},
+		{
+			name:        "正常:週別は月曜日のリセット時刻から1週間",
+			period:      constant.RankingPeriodWeekly,
+			now:         time.Date(2020, 8, 26, 12, 0, 0, 0, jst), // 水曜日
+			wantStartAt: time.Date(2020, 8, 24, 4, 0, 0, 0, jst),
+			wantEndAt:   time.Date(2020, 8, 31, 4, 0, 0, 0, jst),
+		},
+		{
+			name:        "正常:月曜日のリセット時刻より前は前の週",
+			period:      constant.RankingPeriodWeekly,
+			now:         time.Date(2020, 8, 24, 3, 0, 0, 0, jst),
+			wantStartAt: time.Date(2020, 8, 17, 4, 0, 0, 0, jst),
+			wantEndAt:   time.Date(2020, 8, 24, 4, 0, 0, 0, jst),
+		},
+		{
+			name:   "正常:全期間",
+			period: constant.RankingPeriodAllTime,
+			now:    time.Date(2020, 8, 26, 12, 0, 0, 0, jst),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newRankingPeriod(tt.period, tt.now)
+			if !got.startAt.Equal(tt.wantStartAt) || !got.endAt.Equal(tt.wantEndAt) {
+				t.Errorf("newRankingPeriod() = [%v, %v), want [%v, %v)", got.startAt, got.endAt, tt.wantStartAt, tt.wantEndAt)
+			}
+		})
+	}
+}
+
+func TestRankingPeriod_Previous(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	got := newRankingPeriod(constant.RankingPeriodWeekly, time.Date(2020, 8, 26, 12, 0, 0, 0, jst)).previous()
+	wantStartAt := time.Date(2020, 8, 17, 4, 0, 0, 0, jst)
+	if !got.startAt.Equal(wantStartAt) {
+		t.Errorf("previous() startAt = %v, want %v", got.startAt, wantStartAt)
+	}
+}
@@ -1,15 +1,63 @@
 package service
 
 import (
+	"20dojo-online/pkg/constant"
+	"20dojo-online/pkg/cursor"
+	"20dojo-online/pkg/leaderboard"
 	"20dojo-online/pkg/server/model"
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 )
 
+// newTestLeaderboards テスト用のランキング. 全期間はUserId1から順に並ぶ
+// UserId1: 10000, UserId2〜3: 500, UserId4〜5: 300, UserId6: 100
+// 日別はUserId6: 100のみ
+func newTestLeaderboards(t *testing.T) *leaderboard.MemoryFactory {
+	achievedAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	leaderboards := leaderboard.NewMemoryFactory()
+	if err := newRankingPeriod(constant.RankingPeriodDaily, time.Now()).store(leaderboards).Set(&leaderboard.Entry{
+		UserID: "UserId6", Score: 100, AchievedAt: achievedAt,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := leaderboards.Store(constant.LeaderboardKey, 0).Rebuild([]*leaderboard.Entry{
+		// 同点は先に達成したユーザが上位
+		{UserID: "UserId3", Score: 500, AchievedAt: achievedAt.Add(time.Hour)},
+		{UserID: "UserId2", Score: 500, AchievedAt: achievedAt},
+		{UserID: "UserId1", Score: 10000, AchievedAt: achievedAt},
+		{UserID: "UserId5", Score: 300, AchievedAt: achievedAt},
+		{UserID: "UserId4", Score: 300, AchievedAt: achievedAt},
+		{UserID: "UserId6", Score: 100, AchievedAt: achievedAt},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return leaderboards
+}
+
+// testRankingCursor テスト用のランキングのカーソルを作成する
+func testRankingCursor(t *testing.T, score int, achievedAt time.Time, userID string) string {
+	token, err := cursor.Encode(&rankingCursor{Score: score, AchievedAt: achievedAt.UnixNano(), UserID: userID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+// testUsers ユーザIDを指定してユーザ情報を作成する. ユーザ名はユーザIDのUserIdをUserに置き換えたもの
+func testUsers(userIDs ...string) []*model.User {
+	users := make([]*model.User, 0, len(userIDs))
+	for _, userID := range userIDs {
+		users = append(users, &model.User{ID: userID, Name: "User" + userID[len("UserId"):]})
+	}
+	return users
+}
+
 func TestRankingService_GetRankInfoList(t *testing.T) {
+	achievedAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
 
 	type args struct {
 		serviceRequest *GetRankInfoListRequest
@@ -25,61 +73,185 @@ func TestRankingService_GetRankInfoList(t *testing.T) {
 		{
 			name: "正常:ユーザ複数",
 			before: func(mock *mockRepository, args args) {
-				mock.userRepository.EXPECT().SelectUsersOrderByHighScoreDesc(
-					args.serviceRequest.Limit, args.serviceRequest.Offset).Return([]*model.User{
-					{
-						ID:        "UserId1",
-						AuthToken: "User1AuthToken",
-						Name:      "User1",
-						HighScore: 10000,
-						Coin:      1000,
-					},
-					{
-						ID:        "UserId2",
-						AuthToken: "User2AuthToken",
-						Name:      "User2",
-						HighScore: 10,
-						Coin:      1000,
-					},
-				}, nil)
+				mock.userRepository.EXPECT().SelectUsersByPrimaryKeys([]string{"UserId1", "UserId2"}).Return(testUsers("UserId1", "UserId2"), nil)
+			},
+			args: args{
+				serviceRequest: &GetRankInfoListRequest{
+					Limit:  2,
+					Offset: 1,
+				},
+			},
+			want: &GetRankInfoListResponse{
+				RankInfoList: []*RankInfo{
+					{UserId: "UserId1", UserName: "User1", Rank: 1, Score: 10000},
+					{UserId: "UserId2", UserName: "User2", Rank: 2, Score: 500},
+				},
+				NextCursor: testRankingCursor(t, 500, achievedAt, "UserId2"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常:カーソルの次から取得",
+			before: func(mock *mockRepository, args args) {
+				mock.userRepository.EXPECT().SelectUsersByPrimaryKeys([]string{"UserId3", "UserId4"}).Return(testUsers("UserId3", "UserId4"), nil)
+			},
+			args: args{
+				serviceRequest: &GetRankInfoListRequest{
+					Limit:  2,
+					Cursor: testRankingCursor(t, 500, achievedAt, "UserId2"),
+				},
+			},
+			want: &GetRankInfoListResponse{
+				RankInfoList: []*RankInfo{
+					{UserId: "UserId3", UserName: "User3", Rank: 2, Score: 500},
+					{UserId: "UserId4", UserName: "User4", Rank: 4, Score: 300},
+				},
+				NextCursor: testRankingCursor(t, 300, achievedAt, "UserId4"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常:カーソルのユーザのスコアが変わっても並び順の位置から取得",
+			before: func(mock *mockRepository, args args) {
+				mock.userRepository.EXPECT().SelectUsersByPrimaryKeys([]string{"UserId6"}).Return(testUsers("UserId6"), nil)
+			},
+			args: args{
+				serviceRequest: &GetRankInfoListRequest{
+					Limit:  2,
+					Cursor: testRankingCursor(t, 200, achievedAt, "UserId7"),
+				},
+			},
+			want: &GetRankInfoListResponse{
+				RankInfoList: []*RankInfo{
+					{UserId: "UserId6", UserName: "User6", Rank: 6, Score: 100},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常:同点は同順位で次の順位を飛ばす",
+			before: func(mock *mockRepository, args args) {
+				mock.userRepository.EXPECT().SelectUsersByPrimaryKeys([]string{"UserId3", "UserId4", "UserId5", "UserId6"}).Return(testUsers("UserId3", "UserId4", "UserId5", "UserId6"), nil)
+			},
+			args: args{
+				serviceRequest: &GetRankInfoListRequest{
+					Limit:  10,
+					Offset: 3,
+					Mode:   constant.RankingModeCompetition,
+				},
+			},
+			want: &GetRankInfoListResponse{
+				RankInfoList: []*RankInfo{
+					// 前のページのUserId2と同順位
+					{UserId: "UserId3", UserName: "User3", Rank: 2, Score: 500},
+					{UserId: "UserId4", UserName: "User4", Rank: 4, Score: 300},
+					{UserId: "UserId5", UserName: "User5", Rank: 4, Score: 300},
+					{UserId: "UserId6", UserName: "User6", Rank: 6, Score: 100},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常:同点は同順位で次の順位を飛ばさない",
+			before: func(mock *mockRepository, args args) {
+				mock.userRepository.EXPECT().SelectUsersByPrimaryKeys([]string{"UserId3", "UserId4", "UserId5", "UserId6"}).Return(testUsers("UserId3", "UserId4", "UserId5", "UserId6"), nil)
+			},
+			args: args{
+				serviceRequest: &GetRankInfoListRequest{
+					Limit:  10,
+					Offset: 3,
+					Mode:   constant.RankingModeDense,
+				},
+			},
+			want: &GetRankInfoListResponse{
+				RankInfoList: []*RankInfo{
+					{UserId: "UserId3", UserName: "User3", Rank: 2, Score: 500},
+					{UserId: "UserId4", UserName: "User4", Rank: 3, Score: 300},
+					{UserId: "UserId5", UserName: "User5", Rank: 3, Score: 300},
+					{UserId: "UserId6", UserName: "User6", Rank: 4, Score: 100},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常:日別ランキング",
+			before: func(mock *mockRepository, args args) {
+				mock.userRepository.EXPECT().SelectUsersByPrimaryKeys([]string{"UserId6"}).Return(testUsers("UserId6"), nil)
 			},
 			args: args{
 				serviceRequest: &GetRankInfoListRequest{
 					Limit:  10,
 					Offset: 1,
+					Period: constant.RankingPeriodDaily,
 				},
 			},
 			want: &GetRankInfoListResponse{
 				RankInfoList: []*RankInfo{
-					{
-						UserId:   "UserId1",
-						UserName: "User1",
-						Rank:     1,
-						Score:    10000,
-					},
-					{
-						UserId:   "UserId2",
-						UserName: "User2",
-						Rank:     2,
-						Score:    10,
-					},
+					{UserId: "UserId6", UserName: "User6", Rank: 1, Score: 100},
 				},
 			},
 			wantErr: false,
 		},
 		{
-			name: "異常:ユーザ取得エラー",
+			name:   "正常:最後のページのカーソル",
+			before: func(mock *mockRepository, args args) {},
+			args: args{
+				serviceRequest: &GetRankInfoListRequest{
+					Limit:  10,
+					Cursor: testRankingCursor(t, 100, achievedAt, "UserId6"),
+				},
+			},
+			want:    &GetRankInfoListResponse{},
+			wantErr: false,
+		},
+		{
+			name:   "異常:カーソルの形式が不正",
+			before: func(mock *mockRepository, args args) {},
 			args: args{
 				serviceRequest: &GetRankInfoListRequest{
 					Limit:  10,
+					Cursor: "invalid",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:   "正常:開始順位がユーザ数より大きい",
+			before: func(mock *mockRepository, args args) {},
+			args: args{
+				serviceRequest: &GetRankInfoListRequest{
+					Limit:  10,
+					Offset: 7,
+				},
+			},
+			want:    &GetRankInfoListResponse{},
+			wantErr: false,
+		},
+		{
+			name: "異常:ユーザ取得エラー",
+			args: args{
+				serviceRequest: &GetRankInfoListRequest{
+					Limit:  1,
+					Offset: 1,
+				},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.userRepository.EXPECT().SelectUsersByPrimaryKeys([]string{"UserId1"}).Return(nil, errors.New("SelectUsersByPrimaryKeys failed"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "異常:ランキングのユーザが存在しない",
+			args: args{
+				serviceRequest: &GetRankInfoListRequest{
+					Limit:  2,
 					Offset: 1,
 				},
 			},
 			before: func(mock *mockRepository, args args) {
-				mock.userRepository.EXPECT().SelectUsersOrderByHighScoreDesc(
-					args.serviceRequest.Limit, args.serviceRequest.Offset).Return([]*model.User{
-					nil,
-				}, errors.New("SelectUsersOrderByHighScoreDesc failed"))
+				mock.userRepository.EXPECT().SelectUsersByPrimaryKeys([]string{"UserId1", "UserId2"}).Return(testUsers("UserId1"), nil)
 			},
 			want:    nil,
 			wantErr: true,
@@ -90,7 +262,7 @@ func TestRankingService_GetRankInfoList(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mock := newMockRepository(ctrl)
 			tt.before(mock, tt.args)
-			s := NewRankingService(mock.userRepository)
+			s := NewRankingService(mock.userRepository, mock.gamePlayRepository, nil, nil, mock.friendRepository, newTestLeaderboards(t))
 			got, err := s.GetRankInfoList(tt.args.serviceRequest)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetRankInfoList() error = %v, wantErr %v", err, tt.wantErr)
@@ -102,3 +274,429 @@ func TestRankingService_GetRankInfoList(t *testing.T) {
 		})
 	}
 }
+
+func TestRankingService_GetMyRankInfo(t *testing.T) {
+	type args struct {
+		serviceRequest *GetMyRankInfoRequest
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		before  func(mock *mockRepository, args args)
+		want    *GetMyRankInfoResponse
+		wantErr bool
+	}{
+		{
+			name: "正常:前後のユーザを含む",
+			args: args{
+				serviceRequest: &GetMyRankInfoRequest{UserID: "UserId4", Neighbors: 1},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.userRepository.EXPECT().SelectUsersByPrimaryKeys([]string{"UserId3", "UserId4", "UserId5"}).Return(testUsers("UserId3", "UserId4", "UserId5"), nil)
+			},
+			want: &GetMyRankInfoResponse{
+				MyRankInfo: &RankInfo{UserId: "UserId4", UserName: "User4", Rank: 4, Score: 300},
+				RankInfoList: []*RankInfo{
+					{UserId: "UserId3", UserName: "User3", Rank: 2, Score: 500},
+					{UserId: "UserId4", UserName: "User4", Rank: 4, Score: 300},
+					{UserId: "UserId5", UserName: "User5", Rank: 4, Score: 300},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常:1位の場合は後ろのユーザのみ",
+			args: args{
+				serviceRequest: &GetMyRankInfoRequest{UserID: "UserId1", Neighbors: 2, Mode: constant.RankingModeDense},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.userRepository.EXPECT().SelectUsersByPrimaryKeys([]string{"UserId1", "UserId2", "UserId3"}).Return(testUsers("UserId1", "UserId2", "UserId3"), nil)
+			},
+			want: &GetMyRankInfoResponse{
+				MyRankInfo: &RankInfo{UserId: "UserId1", UserName: "User1", Rank: 1, Score: 10000},
+				RankInfoList: []*RankInfo{
+					{UserId: "UserId1", UserName: "User1", Rank: 1, Score: 10000},
+					{UserId: "UserId2", UserName: "User2", Rank: 2, Score: 500},
+					{UserId: "UserId3", UserName: "User3", Rank: 2, Score: 500},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常:ランキング未登録のユーザは登録してから取得",
+			args: args{
+				serviceRequest: &GetMyRankInfoRequest{UserID: "UserId7", Neighbors: 1},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.userRepository.EXPECT().SelectUserByPrimaryKey("UserId7").Return(&model.User{ID: "UserId7", Name: "User7", HighScore: 0}, nil)
+				mock.userRepository.EXPECT().SelectUsersByPrimaryKeys([]string{"UserId6", "UserId7"}).Return(testUsers("UserId6", "UserId7"), nil)
+			},
+			want: &GetMyRankInfoResponse{
+				MyRankInfo: &RankInfo{UserId: "UserId7", UserName: "User7", Rank: 7, Score: 0},
+				RankInfoList: []*RankInfo{
+					{UserId: "UserId6", UserName: "User6", Rank: 6, Score: 100},
+					{UserId: "UserId7", UserName: "User7", Rank: 7, Score: 0},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常:存在しないユーザ",
+			args: args{
+				serviceRequest: &GetMyRankInfoRequest{UserID: "UserId7", Neighbors: 1},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.userRepository.EXPECT().SelectUserByPrimaryKey("UserId7").Return(nil, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMockRepository(ctrl)
+			tt.before(mock, tt.args)
+			s := NewRankingService(mock.userRepository, mock.gamePlayRepository, nil, nil, mock.friendRepository, newTestLeaderboards(t))
+			got, err := s.GetMyRankInfo(tt.args.serviceRequest)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetMyRankInfo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetMyRankInfo() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRankingService_GetFriendRankInfoList(t *testing.T) {
+	achievedAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	friendUsers := []*model.User{
+		{ID: "UserId1", Name: "User1", HighScore: 300, HighScoreUpdatedAt: achievedAt},
+		{ID: "UserId2", Name: "User2", HighScore: 500, HighScoreUpdatedAt: achievedAt.Add(time.Hour)},
+		{ID: "UserId3", Name: "User3", HighScore: 500, HighScoreUpdatedAt: achievedAt},
+		{ID: "UserId4", Name: "User4", HighScore: 100, HighScoreUpdatedAt: achievedAt},
+	}
+
+	type args struct {
+		serviceRequest *GetFriendRankInfoListRequest
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		before  func(mock *mockRepository, args args)
+		want    *GetRankInfoListResponse
+		wantErr bool
+	}{
+		{
+			name: "正常:同点は同順位で次の順位を飛ばす",
+			args: args{
+				serviceRequest: &GetFriendRankInfoListRequest{UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.friendRepository.EXPECT().SelectFriendsByUserID("UserId1").Return([]*model.Friend{
+					{UserID: "UserId1", FriendUserID: "UserId2"},
+					{UserID: "UserId1", FriendUserID: "UserId3"},
+					{UserID: "UserId1", FriendUserID: "UserId4"},
+				}, nil)
+				mock.userRepository.EXPECT().SelectUsersByPrimaryKeys([]string{"UserId1", "UserId2", "UserId3", "UserId4"}).Return(friendUsers, nil)
+			},
+			want: &GetRankInfoListResponse{
+				RankInfoList: []*RankInfo{
+					// 同点は先に達成したユーザが上位
+					{UserId: "UserId3", UserName: "User3", Rank: 1, Score: 500},
+					{UserId: "UserId2", UserName: "User2", Rank: 1, Score: 500},
+					{UserId: "UserId1", UserName: "User1", Rank: 3, Score: 300},
+					{UserId: "UserId4", UserName: "User4", Rank: 4, Score: 100},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常:同点は同順位で次の順位を飛ばさない",
+			args: args{
+				serviceRequest: &GetFriendRankInfoListRequest{UserID: "UserId1", Mode: constant.RankingModeDense},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.friendRepository.EXPECT().SelectFriendsByUserID("UserId1").Return([]*model.Friend{
+					{UserID: "UserId1", FriendUserID: "UserId2"},
+					{UserID: "UserId1", FriendUserID: "UserId3"},
+					{UserID: "UserId1", FriendUserID: "UserId4"},
+				}, nil)
+				mock.userRepository.EXPECT().SelectUsersByPrimaryKeys([]string{"UserId1", "UserId2", "UserId3", "UserId4"}).Return(friendUsers, nil)
+			},
+			want: &GetRankInfoListResponse{
+				RankInfoList: []*RankInfo{
+					{UserId: "UserId3", UserName: "User3", Rank: 1, Score: 500},
+					{UserId: "UserId2", UserName: "User2", Rank: 1, Score: 500},
+					{UserId: "UserId1", UserName: "User1", Rank: 2, Score: 300},
+					{UserId: "UserId4", UserName: "User4", Rank: 3, Score: 100},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常:フレンドなし",
+			args: args{
+				serviceRequest: &GetFriendRankInfoListRequest{UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.friendRepository.EXPECT().SelectFriendsByUserID("UserId1").Return(nil, nil)
+				mock.userRepository.EXPECT().SelectUsersByPrimaryKeys([]string{"UserId1"}).Return(friendUsers[:1], nil)
+			},
+			want: &GetRankInfoListResponse{
+				RankInfoList: []*RankInfo{
+					{UserId: "UserId1", UserName: "User1", Rank: 1, Score: 300},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常:フレンド取得エラー",
+			args: args{
+				serviceRequest: &GetFriendRankInfoListRequest{UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.friendRepository.EXPECT().SelectFriendsByUserID("UserId1").Return(nil, errors.New("SelectFriendsByUserID failed"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "異常:フレンドのユーザが存在しない",
+			args: args{
+				serviceRequest: &GetFriendRankInfoListRequest{UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.friendRepository.EXPECT().SelectFriendsByUserID("UserId1").Return([]*model.Friend{
+					{UserID: "UserId1", FriendUserID: "UserId2"},
+				}, nil)
+				mock.userRepository.EXPECT().SelectUsersByPrimaryKeys([]string{"UserId1", "UserId2"}).Return(friendUsers[:1], nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMockRepository(ctrl)
+			tt.before(mock, tt.args)
+			s := NewRankingService(mock.userRepository, mock.gamePlayRepository, nil, nil, mock.friendRepository, newTestLeaderboards(t))
+			got, err := s.GetFriendRankInfoList(tt.args.serviceRequest)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetFriendRankInfoList() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetFriendRankInfoList() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRankingService_RebuildLeaderboard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mock := newMockRepository(ctrl)
+	achievedAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock.userRepository.EXPECT().SelectUsersAll().Return([]*model.User{
+		{ID: "UserId1", HighScore: 100, HighScoreUpdatedAt: achievedAt},
+		{ID: "UserId2", HighScore: 200, HighScoreUpdatedAt: achievedAt},
+	}, nil)
+	// 日別・週別は期間内の最高スコアから作り直す
+	mock.gamePlayRepository.EXPECT().SelectBestScoresBetween(gomock.Any(), gomock.Any()).Return([]*model.GamePlayBestScore{
+		{UserID: "UserId1", Score: 50, AchievedAt: achievedAt},
+	}, nil).Times(2)
+
+	leaderboards := newTestLeaderboards(t)
+	s := NewRankingService(mock.userRepository, mock.gamePlayRepository, nil, nil, mock.friendRepository, leaderboards)
+	if err := s.RebuildLeaderboard(); err != nil {
+		t.Fatalf("RebuildLeaderboard() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		period string
+		want   []*leaderboard.Entry
+	}{
+		{
+			name:   "全期間は既存の登録を置き換える",
+			period: constant.RankingPeriodAllTime,
+			want: []*leaderboard.Entry{
+				{UserID: "UserId2", Score: 200, AchievedAt: achievedAt},
+				{UserID: "UserId1", Score: 100, AchievedAt: achievedAt},
+			},
+		},
+		{
+			name:   "日別",
+			period: constant.RankingPeriodDaily,
+			want: []*leaderboard.Entry{
+				{UserID: "UserId1", Score: 50, AchievedAt: achievedAt},
+			},
+		},
+		{
+			name:   "週別",
+			period: constant.RankingPeriodWeekly,
+			want: []*leaderboard.Entry{
+				{UserID: "UserId1", Score: 50, AchievedAt: achievedAt},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newRankingPeriod(tt.period, time.Now()).store(leaderboards).Range(1, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Range() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRankingService_CloseRankingPeriod トランザクション開始前の検証
+// 締め処理の記録と報酬の付与はTestRankingService_closeRankingPeriodで確認する
+func TestRankingService_CloseRankingPeriod(t *testing.T) {
+	type args struct {
+		serviceRequest *CloseRankingPeriodRequest
+	}
+
+	tests := []struct {
+		name   string
+		args   args
+		before func(mock *mockRepository, args args)
+	}{
+		{
+			name: "異常:締め処理のない集計期間",
+			args: args{
+				serviceRequest: &CloseRankingPeriodRequest{Period: constant.RankingPeriodAllTime, Now: time.Now()},
+			},
+			before: func(mock *mockRepository, args args) {},
+		},
+		{
+			name: "異常:最高スコアの取得エラー",
+			args: args{
+				serviceRequest: &CloseRankingPeriodRequest{
+					Period: constant.RankingPeriodDaily,
+					Now:    time.Date(2020, 8, 26, 12, 0, 0, 0, time.Local),
+				},
+			},
+			before: func(mock *mockRepository, args args) {
+				// 直前の日の期間を集計する
+				mock.gamePlayRepository.EXPECT().SelectBestScoresBetween(
+					time.Date(2020, 8, 25, 4, 0, 0, 0, time.Local),
+					time.Date(2020, 8, 26, 4, 0, 0, 0, time.Local),
+				).Return(nil, errors.New("SelectBestScoresBetween failed"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMockRepository(ctrl)
+			tt.before(mock, tt.args)
+			s := NewRankingService(mock.userRepository, mock.gamePlayRepository, nil, nil, mock.friendRepository, newTestLeaderboards(t))
+			if _, err := s.CloseRankingPeriod(tt.args.serviceRequest); err == nil {
+				t.Errorf("CloseRankingPeriod() error = nil, want error")
+			}
+		})
+	}
+}
+
+// トランザクションはモックのリポジトリでは利用しないためnilを渡す
+func TestRankingService_closeRankingPeriod(t *testing.T) {
+	now := time.Date(2020, 8, 26, 12, 0, 0, 0, time.Local)
+	period := &rankingPeriod{
+		name:    constant.RankingPeriodDaily,
+		startAt: time.Date(2020, 8, 25, 4, 0, 0, 0, time.Local),
+		endAt:   time.Date(2020, 8, 26, 4, 0, 0, 0, time.Local),
+	}
+	// 順位順に並べたスナップショット. 報酬の付与は順位ではなくユーザIDの順に行う
+	snapshots := []*model.RankingSnapshot{
+		{Period: period.name, StartAt: period.startAt, UserID: "UserId3", Rank: 1, Score: 300, RewardCoin: 1000},
+		{Period: period.name, StartAt: period.startAt, UserID: "UserId1", Rank: 2, Score: 200, RewardCoin: 500},
+		{Period: period.name, StartAt: period.startAt, UserID: "UserId2", Rank: 3, Score: 100, RewardCoin: 300},
+		{Period: period.name, StartAt: period.startAt, UserID: "UserId4", Rank: 4, Score: 50, RewardCoin: 0},
+	}
+	rankingSeason := &model.RankingSeason{Period: period.name, StartAt: period.startAt, EndAt: period.endAt, ClosedAt: now}
+
+	tests := []struct {
+		name    string
+		before  func(mock *mockRepository)
+		want    *CloseRankingPeriodResponse
+		wantErr bool
+	}{
+		{
+			name: "正常:締め処理を記録してユーザIDの順に報酬コインを付与",
+			before: func(mock *mockRepository) {
+				gomock.InOrder(
+					mock.rankingSeasonRepository.EXPECT().SelectRankingSeasonByPrimaryKey(nil, period.name, period.startAt).Return(nil, nil),
+					mock.rankingSeasonRepository.EXPECT().InsertRankingSeason(nil, rankingSeason).Return(nil),
+					mock.rankingSnapshotRepository.EXPECT().BulkInsertRankingSnapshot(nil, snapshots).Return(nil),
+					mock.userRepository.EXPECT().SelectUserByPrimaryKeyForUpdate(nil, "UserId1").Return(&model.User{ID: "UserId1", Coin: 10}, nil),
+					mock.userRepository.EXPECT().UpdateUserCoinByPrimaryKey(nil, "UserId1", 510).Return(nil),
+					mock.userRepository.EXPECT().SelectUserByPrimaryKeyForUpdate(nil, "UserId2").Return(&model.User{ID: "UserId2", Coin: 20}, nil),
+					mock.userRepository.EXPECT().UpdateUserCoinByPrimaryKey(nil, "UserId2", 320).Return(nil),
+					mock.userRepository.EXPECT().SelectUserByPrimaryKeyForUpdate(nil, "UserId3").Return(&model.User{ID: "UserId3", Coin: 30}, nil),
+					mock.userRepository.EXPECT().UpdateUserCoinByPrimaryKey(nil, "UserId3", 1030).Return(nil),
+				)
+			},
+			want: &CloseRankingPeriodResponse{
+				StartAt:   period.startAt,
+				EndAt:     period.endAt,
+				Snapshots: snapshots,
+			},
+		},
+		{
+			name: "正常:締め処理済みの期間は更新しない",
+			before: func(mock *mockRepository) {
+				mock.rankingSeasonRepository.EXPECT().SelectRankingSeasonByPrimaryKey(nil, period.name, period.startAt).Return(rankingSeason, nil)
+			},
+			want: &CloseRankingPeriodResponse{
+				StartAt:       period.startAt,
+				EndAt:         period.endAt,
+				AlreadyClosed: true,
+			},
+		},
+		{
+			name: "異常:締め処理の記録エラー",
+			before: func(mock *mockRepository) {
+				mock.rankingSeasonRepository.EXPECT().SelectRankingSeasonByPrimaryKey(nil, period.name, period.startAt).Return(nil, nil)
+				mock.rankingSeasonRepository.EXPECT().InsertRankingSeason(nil, rankingSeason).Return(errors.New("InsertRankingSeason failed"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "異常:報酬対象のユーザが存在しない",
+			before: func(mock *mockRepository) {
+				mock.rankingSeasonRepository.EXPECT().SelectRankingSeasonByPrimaryKey(nil, period.name, period.startAt).Return(nil, nil)
+				mock.rankingSeasonRepository.EXPECT().InsertRankingSeason(nil, rankingSeason).Return(nil)
+				mock.rankingSnapshotRepository.EXPECT().BulkInsertRankingSnapshot(nil, snapshots).Return(nil)
+				mock.userRepository.EXPECT().SelectUserByPrimaryKeyForUpdate(nil, "UserId1").Return(nil, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMockRepository(ctrl)
+			tt.before(mock)
+			s := NewRankingService(mock.userRepository, mock.gamePlayRepository, mock.rankingSeasonRepository, mock.rankingSnapshotRepository, mock.friendRepository, newTestLeaderboards(t))
+			got, err := s.closeRankingPeriod(nil, period, snapshots, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("closeRankingPeriod() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("closeRankingPeriod() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,11 +7,51 @@ import (
 )
 
 type mockRepository struct {
-	userRepository *mock_model.MockUserRepositoryInterface
+	userRepository                    *mock_model.MockUserRepositoryInterface
+	gachaRepository                   *mock_model.MockGachaRepositoryInterface
+	gachaProbabilityRepository        *mock_model.MockGachaProbabilityRepositoryInterface
+	collectionItemRepository          *mock_model.MockCollectionItemRepositoryInterface
+	gameSessionRepository             *mock_model.MockGameSessionRepositoryInterface
+	gamePlayRepository                *mock_model.MockGamePlayRepositoryInterface
+	rewardEventRepository             *mock_model.MockRewardEventRepositoryInterface
+	friendRepository                  *mock_model.MockFriendRepositoryInterface
+	userCollectionItemRepository      *mock_model.MockUserCollectionItemRepositoryInterface
+	collectionSetRepository           *mock_model.MockCollectionSetRepositoryInterface
+	userCollectionSetRewardRepository *mock_model.MockUserCollectionSetRewardRepositoryInterface
+	tradeRepository                   *mock_model.MockTradeRepositoryInterface
+	userGachaPityRepository           *mock_model.MockUserGachaPityRepositoryInterface
+	gachaDrawHistoryRepository        *mock_model.MockGachaDrawHistoryRepositoryInterface
+	gachaStepRepository               *mock_model.MockGachaStepRepositoryInterface
+	userGachaStepRepository           *mock_model.MockUserGachaStepRepositoryInterface
+	userGachaBoxItemRepository        *mock_model.MockUserGachaBoxItemRepositoryInterface
+	userGachaTicketRepository         *mock_model.MockUserGachaTicketRepositoryInterface
+	userGachaFreeDrawRepository       *mock_model.MockUserGachaFreeDrawRepositoryInterface
+	rankingSeasonRepository           *mock_model.MockRankingSeasonRepositoryInterface
+	rankingSnapshotRepository         *mock_model.MockRankingSnapshotRepositoryInterface
 }
 
 func newMockRepository(ctrl *gomock.Controller) *mockRepository {
 	return &mockRepository{
-		userRepository: mock_model.NewMockUserRepositoryInterface(ctrl),
+		userRepository:                    mock_model.NewMockUserRepositoryInterface(ctrl),
+		gachaRepository:                   mock_model.NewMockGachaRepositoryInterface(ctrl),
+		gachaProbabilityRepository:        mock_model.NewMockGachaProbabilityRepositoryInterface(ctrl),
+		collectionItemRepository:          mock_model.NewMockCollectionItemRepositoryInterface(ctrl),
+		gameSessionRepository:             mock_model.NewMockGameSessionRepositoryInterface(ctrl),
+		gamePlayRepository:                mock_model.NewMockGamePlayRepositoryInterface(ctrl),
+		rewardEventRepository:             mock_model.NewMockRewardEventRepositoryInterface(ctrl),
+		friendRepository:                  mock_model.NewMockFriendRepositoryInterface(ctrl),
+		userCollectionItemRepository:      mock_model.NewMockUserCollectionItemRepositoryInterface(ctrl),
+		collectionSetRepository:           mock_model.NewMockCollectionSetRepositoryInterface(ctrl),
+		userCollectionSetRewardRepository: mock_model.NewMockUserCollectionSetRewardRepositoryInterface(ctrl),
+		tradeRepository:                   mock_model.NewMockTradeRepositoryInterface(ctrl),
+		userGachaPityRepository:           mock_model.NewMockUserGachaPityRepositoryInterface(ctrl),
+		gachaDrawHistoryRepository:        mock_model.NewMockGachaDrawHistoryRepositoryInterface(ctrl),
+		gachaStepRepository:               mock_model.NewMockGachaStepRepositoryInterface(ctrl),
+		userGachaStepRepository:           mock_model.NewMockUserGachaStepRepositoryInterface(ctrl),
+		userGachaBoxItemRepository:        mock_model.NewMockUserGachaBoxItemRepositoryInterface(ctrl),
+		userGachaTicketRepository:         mock_model.NewMockUserGachaTicketRepositoryInterface(ctrl),
+		userGachaFreeDrawRepository:       mock_model.NewMockUserGachaFreeDrawRepositoryInterface(ctrl),
+		rankingSeasonRepository:           mock_model.NewMockRankingSeasonRepositoryInterface(ctrl),
+		rankingSnapshotRepository:         mock_model.NewMockRankingSnapshotRepositoryInterface(ctrl),
 	}
 }
@@ -0,0 +1,669 @@
+//go:generate mockgen -source=$GOFILE -package=mock_$GOPACKAGE -destination=./mock_$GOPACKAGE/mock_$GOFILE
+
+package service
+
+import (
+	"20dojo-online/pkg/constant"
+	"20dojo-online/pkg/db"
+	"20dojo-online/pkg/myerror"
+	"20dojo-online/pkg/server/model"
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TradeItemRequest トレードで受け渡すアイテムと個数
+type TradeItemRequest struct {
+	CollectionID string
+	Count        int
+}
+
+type ProposeTradeRequest struct {
+	UserID       string
+	TargetUserID string
+	OfferItems   []*TradeItemRequest // 提案したユーザが渡すアイテム
+	RequestItems []*TradeItemRequest // 提案されたユーザが渡すアイテム
+	OfferCoin    int                 // 提案したユーザが渡すコイン
+	RequestCoin  int                 // 提案されたユーザが渡すコイン
+}
+
+type ProposeTradeResponse struct {
+	TradeID   string
+	ExpiresAt time.Time
+}
+
+// TradeActionRequest トレードの承認・拒否・取り消しのリクエスト
+type TradeActionRequest struct {
+	UserID  string
+	TradeID string
+}
+
+type GetTradeListRequest struct {
+	UserID string
+}
+
+type GetTradeListResponse struct {
+	Sent     []*TradeInfo // 自分が提案した承認待ちのトレード
+	Received []*TradeInfo // 自分宛ての承認待ちのトレード
+}
+
+// TradeInfo 承認待ちのトレード情報
+type TradeInfo struct {
+	TradeID        string
+	ProposerUserID string
+	TargetUserID   string
+	OfferItems     []*TradeItemInfo
+	RequestItems   []*TradeItemInfo
+	OfferCoin      int
+	RequestCoin    int
+	ExpiresAt      time.Time
+	CreatedAt      time.Time
+}
+
+// TradeItemInfo トレードで受け渡すアイテム情報
+type TradeItemInfo struct {
+	CollectionID string
+	Name         string
+	Rarity       int
+	Count        int
+}
+
+type TradeService struct {
+	TradeRepository                   model.TradeRepositoryInterface
+	UserRepository                    model.UserRepositoryInterface
+	UserCollectionItemRepository      model.UserCollectionItemRepositoryInterface
+	CollectionItemRepository          model.CollectionItemRepositoryInterface
+	CollectionSetRepository           model.CollectionSetRepositoryInterface
+	UserCollectionSetRewardRepository model.UserCollectionSetRewardRepositoryInterface
+}
+
+func NewTradeService(
+	tradeRepository model.TradeRepositoryInterface,
+	userRepository model.UserRepositoryInterface,
+	userCollectionItemRepository model.UserCollectionItemRepositoryInterface,
+	collectionItemRepository model.CollectionItemRepositoryInterface,
+	collectionSetRepository model.CollectionSetRepositoryInterface,
+	userCollectionSetRewardRepository model.UserCollectionSetRewardRepositoryInterface,
+) *TradeService {
+	return &TradeService{
+		TradeRepository:                   tradeRepository,
+		UserRepository:                    userRepository,
+		UserCollectionItemRepository:      userCollectionItemRepository,
+		CollectionItemRepository:          collectionItemRepository,
+		CollectionSetRepository:           collectionSetRepository,
+		UserCollectionSetRewardRepository: userCollectionSetRewardRepository,
+	}
+}
+
+type TradeServiceInterface interface {
+	ProposeTrade(serviceRequest *ProposeTradeRequest) (*ProposeTradeResponse, error)
+	AcceptTrade(serviceRequest *TradeActionRequest) error
+	DeclineTrade(serviceRequest *TradeActionRequest) error
+	CancelTrade(serviceRequest *TradeActionRequest) error
+	GetTradeList(serviceRequest *GetTradeListRequest) (*GetTradeListResponse, error)
+}
+
+var _ TradeServiceInterface = (*TradeService)(nil)
+
+// ProposeTrade トレード提案のロジック
+func (s *TradeService) ProposeTrade(serviceRequest *ProposeTradeRequest) (*ProposeTradeResponse, error) {
+	if serviceRequest.UserID == serviceRequest.TargetUserID {
+		return nil, myerror.ApplicationError{
+			Message: fmt.Sprintf("cannot propose trade to yourself. userID=%s", serviceRequest.UserID),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	if len(serviceRequest.OfferItems) == 0 && len(serviceRequest.RequestItems) == 0 {
+		return nil, myerror.ApplicationError{
+			Message: "trade must include at least one item",
+			Code:    http.StatusBadRequest,
+		}
+	}
+	if serviceRequest.OfferCoin < 0 || serviceRequest.RequestCoin < 0 {
+		return nil, myerror.ApplicationError{
+			Message: fmt.Sprintf("trade coin must not be negative. offerCoin=%d, requestCoin=%d", serviceRequest.OfferCoin, serviceRequest.RequestCoin),
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	// 受け渡すアイテムがマスタに存在するかを確認
+	collectionItems, err := s.CollectionItemRepository.SelectCollectionItemAll()
+	if err != nil {
+		return nil, err
+	}
+	collectionItemMap := make(map[string]struct{}, len(collectionItems))
+	for _, collectionItem := range collectionItems {
+		collectionItemMap[collectionItem.ID] = struct{}{}
+	}
+	tradeID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	var tradeItems []*model.TradeItem
+	for _, side := range []struct {
+		userID string
+		items  []*TradeItemRequest
+	}{
+		{userID: serviceRequest.UserID, items: serviceRequest.OfferItems},
+		{userID: serviceRequest.TargetUserID, items: serviceRequest.RequestItems},
+	} {
+		seen := make(map[string]struct{}, len(side.items))
+		for _, item := range side.items {
+			if _, ok := collectionItemMap[item.CollectionID]; !ok {
+				return nil, myerror.ApplicationError{
+					Message: fmt.Sprintf("collection item not found. collectionID=%s", item.CollectionID),
+					Code:    http.StatusBadRequest,
+				}
+			}
+			if item.Count <= 0 {
+				return nil, myerror.ApplicationError{
+					Message: fmt.Sprintf("trade item count must be positive. collectionID=%s, count=%d", item.CollectionID, item.Count),
+					Code:    http.StatusBadRequest,
+				}
+			}
+			if _, ok := seen[item.CollectionID]; ok {
+				return nil, myerror.ApplicationError{
+					Message: fmt.Sprintf("collection item is duplicated in trade. collectionID=%s", item.CollectionID),
+					Code:    http.StatusBadRequest,
+				}
+			}
+			seen[item.CollectionID] = struct{}{}
+			tradeItems = append(tradeItems, &model.TradeItem{
+				TradeID:          tradeID.String(),
+				UserID:           side.userID,
+				CollectionItemID: item.CollectionID,
+				Count:            item.Count,
+			})
+		}
+	}
+
+	// DATETIME型に合わせて秒単位で記録する
+	now := time.Now().Truncate(time.Second)
+	trade := &model.Trade{
+		ID:             tradeID.String(),
+		ProposerUserID: serviceRequest.UserID,
+		TargetUserID:   serviceRequest.TargetUserID,
+		OfferCoin:      serviceRequest.OfferCoin,
+		RequestCoin:    serviceRequest.RequestCoin,
+		Status:         constant.TradeStatusPending,
+		ExpiresAt:      now.Add(constant.TradeExpiration),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.proposeTrade(tx, trade, tradeItems); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			log.Println(fmt.Sprintf("Rollback Error in proposing trade: %s", rollbackErr))
+		}
+		return nil, err
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return nil, commitErr
+	}
+
+	return &ProposeTradeResponse{
+		TradeID:   trade.ID,
+		ExpiresAt: trade.ExpiresAt,
+	}, nil
+}
+
+// proposeTrade トランザクション内で両ユーザの所持アイテム・コインを確認してトレードを登録する
+func (s *TradeService) proposeTrade(tx *sql.Tx, trade *model.Trade, tradeItems []*model.TradeItem) error {
+	users, err := s.lockUsers(tx, trade.ProposerUserID, trade.TargetUserID)
+	if err != nil {
+		return err
+	}
+
+	count, err := s.TradeRepository.SelectPendingTradeCountByProposerUserID(tx, trade.ProposerUserID, trade.CreatedAt)
+	if err != nil {
+		return err
+	}
+	if count >= constant.TradePendingLimit {
+		return myerror.ApplicationError{
+			Message: fmt.Sprintf("pending trade limit exceeded. limit=%d", constant.TradePendingLimit),
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	// 承認時にも確認するが、受け渡しできないトレードは提案の時点で弾く
+	if _, err = s.selectTradeAssets(tx, trade, tradeItems, users); err != nil {
+		return err
+	}
+
+	if err = s.TradeRepository.InsertTrade(tx, trade); err != nil {
+		return err
+	}
+	if len(tradeItems) >= 1 {
+		if err = s.TradeRepository.BulkInsertTradeItem(tx, tradeItems); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AcceptTrade トレード承認のロジック. 両ユーザのアイテムとコインを1つのトランザクションで受け渡す
+func (s *TradeService) AcceptTrade(serviceRequest *TradeActionRequest) error {
+	// ロックするユーザを決めるため、ロックせずにトレードを取得する
+	trade, err := s.TradeRepository.SelectTradeByPrimaryKey(serviceRequest.TradeID)
+	if err != nil {
+		return err
+	}
+	if trade == nil || trade.TargetUserID != serviceRequest.UserID {
+		return tradeNotFoundError(serviceRequest.TradeID)
+	}
+	tradeItems, err := s.TradeRepository.SelectTradeItemsByTradeIDs([]string{trade.ID})
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	if err = s.acceptTrade(tx, trade, tradeItems, time.Now()); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			log.Println(fmt.Sprintf("Rollback Error in accepting trade: %s", rollbackErr))
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// acceptTrade トランザクション内で両ユーザをロックし、アイテムとコインを受け渡してトレードを承認済みにする
+func (s *TradeService) acceptTrade(tx *sql.Tx, trade *model.Trade, tradeItems []*model.TradeItem, now time.Time) error {
+	// デッドロックを防ぐため、ゲーム終了やガチャと同じくユーザ情報を先にロックしてからトレードをロックする
+	users, err := s.lockUsers(tx, trade.ProposerUserID, trade.TargetUserID)
+	if err != nil {
+		return err
+	}
+	if trade, err = s.TradeRepository.SelectTradeByPrimaryKeyForUpdate(tx, trade.ID); err != nil {
+		return err
+	}
+	if err = validatePendingTrade(trade, now); err != nil {
+		return err
+	}
+
+	userCollectionItemMaps, err := s.selectTradeAssets(tx, trade, tradeItems, users)
+	if err != nil {
+		return err
+	}
+
+	// アイテムの受け渡し. 獲得数が0になったアイテムは削除する
+	changed := transferTradeItems(trade, tradeItems, userCollectionItemMaps, now)
+	for _, userID := range []string{trade.ProposerUserID, trade.TargetUserID} {
+		var (
+			upsertUserCollectionItemSlice []*model.UserCollectionItem
+			deleteCollectionItemIDs       []string
+		)
+		for _, userCollectionItem := range changed[userID] {
+			if userCollectionItem.Count == 0 {
+				deleteCollectionItemIDs = append(deleteCollectionItemIDs, userCollectionItem.CollectionItemID)
+				continue
+			}
+			upsertUserCollectionItemSlice = append(upsertUserCollectionItemSlice, userCollectionItem)
+		}
+		if len(upsertUserCollectionItemSlice) >= 1 {
+			if err = s.UserCollectionItemRepository.BulkUpsertUserCollectionItem(tx, upsertUserCollectionItemSlice); err != nil {
+				return err
+			}
+		}
+		if len(deleteCollectionItemIDs) >= 1 {
+			if err = s.UserCollectionItemRepository.DeleteUserCollectionItemsByPrimaryKeys(tx, userID, deleteCollectionItemIDs); err != nil {
+				return err
+			}
+		}
+	}
+
+	// コインの受け渡し
+	proposer, target := users[trade.ProposerUserID], users[trade.TargetUserID]
+	if trade.OfferCoin != 0 || trade.RequestCoin != 0 {
+		if err = s.UserRepository.UpdateUserCoinByPrimaryKey(tx, proposer.ID, proposer.Coin-trade.OfferCoin+trade.RequestCoin); err != nil {
+			return err
+		}
+		if err = s.UserRepository.UpdateUserCoinByPrimaryKey(tx, target.ID, target.Coin-trade.RequestCoin+trade.OfferCoin); err != nil {
+			return err
+		}
+	}
+
+	return s.TradeRepository.UpdateTradeStatusByPrimaryKey(tx, trade.ID, constant.TradeStatusAccepted, now)
+}
+
+// DeclineTrade トレード拒否のロジック. 提案されたユーザのみ拒否できる
+func (s *TradeService) DeclineTrade(serviceRequest *TradeActionRequest) error {
+	return s.closeTrade(serviceRequest, constant.TradeStatusDeclined, func(trade *model.Trade) bool {
+		return trade.TargetUserID == serviceRequest.UserID
+	})
+}
+
+// CancelTrade トレード取り消しのロジック. 提案したユーザのみ取り消せる
+func (s *TradeService) CancelTrade(serviceRequest *TradeActionRequest) error {
+	return s.closeTrade(serviceRequest, constant.TradeStatusCanceled, func(trade *model.Trade) bool {
+		return trade.ProposerUserID == serviceRequest.UserID
+	})
+}
+
+// closeTrade 承認待ちのトレードを受け渡しせずに終了する. 有効期限を過ぎたトレードも終了できる
+func (s *TradeService) closeTrade(serviceRequest *TradeActionRequest, status string, isAllowed func(trade *model.Trade) bool) error {
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	if err = s.updatePendingTradeStatus(tx, serviceRequest.TradeID, status, isAllowed); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			log.Println(fmt.Sprintf("Rollback Error in closing trade: %s", rollbackErr))
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// updatePendingTradeStatus トランザクション内で承認待ちのトレードをロックして状態を更新する
+func (s *TradeService) updatePendingTradeStatus(tx *sql.Tx, tradeID string, status string, isAllowed func(trade *model.Trade) bool) error {
+	trade, err := s.TradeRepository.SelectTradeByPrimaryKeyForUpdate(tx, tradeID)
+	if err != nil {
+		return err
+	}
+	if trade == nil || !isAllowed(trade) {
+		return tradeNotFoundError(tradeID)
+	}
+	if trade.Status != constant.TradeStatusPending {
+		return myerror.ApplicationError{
+			Message: fmt.Sprintf("trade is not pending. tradeID=%s, status=%s", trade.ID, trade.Status),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	return s.TradeRepository.UpdateTradeStatusByPrimaryKey(tx, trade.ID, status, time.Now())
+}
+
+// GetTradeList 承認待ちのトレード一覧取得のロジック
+func (s *TradeService) GetTradeList(serviceRequest *GetTradeListRequest) (*GetTradeListResponse, error) {
+	trades, err := s.TradeRepository.SelectPendingTradesByUserID(serviceRequest.UserID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	res := &GetTradeListResponse{
+		Sent:     []*TradeInfo{},
+		Received: []*TradeInfo{},
+	}
+	if len(trades) == 0 {
+		return res, nil
+	}
+
+	tradeIDs := make([]string, 0, len(trades))
+	for _, trade := range trades {
+		tradeIDs = append(tradeIDs, trade.ID)
+	}
+	tradeItems, err := s.TradeRepository.SelectTradeItemsByTradeIDs(tradeIDs)
+	if err != nil {
+		return nil, err
+	}
+	collectionItems, err := s.CollectionItemRepository.SelectCollectionItemAll()
+	if err != nil {
+		return nil, err
+	}
+	collectionItemMap := make(map[string]*model.CollectionItem, len(collectionItems))
+	for _, collectionItem := range collectionItems {
+		collectionItemMap[collectionItem.ID] = collectionItem
+	}
+
+	tradeInfoMap := make(map[string]*TradeInfo, len(trades))
+	for _, trade := range trades {
+		tradeInfo := &TradeInfo{
+			TradeID:        trade.ID,
+			ProposerUserID: trade.ProposerUserID,
+			TargetUserID:   trade.TargetUserID,
+			OfferItems:     []*TradeItemInfo{},
+			RequestItems:   []*TradeItemInfo{},
+			OfferCoin:      trade.OfferCoin,
+			RequestCoin:    trade.RequestCoin,
+			ExpiresAt:      trade.ExpiresAt,
+			CreatedAt:      trade.CreatedAt,
+		}
+		tradeInfoMap[trade.ID] = tradeInfo
+		if trade.ProposerUserID == serviceRequest.UserID {
+			res.Sent = append(res.Sent, tradeInfo)
+		} else {
+			res.Received = append(res.Received, tradeInfo)
+		}
+	}
+	for _, tradeItem := range tradeItems {
+		tradeInfo, ok := tradeInfoMap[tradeItem.TradeID]
+		if !ok {
+			continue
+		}
+		tradeItemInfo := &TradeItemInfo{
+			CollectionID: tradeItem.CollectionItemID,
+			Count:        tradeItem.Count,
+		}
+		if collectionItem, ok := collectionItemMap[tradeItem.CollectionItemID]; ok {
+			tradeItemInfo.Name = collectionItem.Name
+			tradeItemInfo.Rarity = collectionItem.Rarity
+		}
+		if tradeItem.UserID == tradeInfo.ProposerUserID {
+			tradeInfo.OfferItems = append(tradeInfo.OfferItems, tradeItemInfo)
+		} else {
+			tradeInfo.RequestItems = append(tradeInfo.RequestItems, tradeItemInfo)
+		}
+	}
+	return res, nil
+}
+
+// lockUsers トレードする2人のユーザ情報を排他ロックで取得する. デッドロックを防ぐためユーザIDの順にロックする
+func (s *TradeService) lockUsers(tx *sql.Tx, userIDs ...string) (map[string]*model.User, error) {
+	sortedUserIDs := append([]string{}, userIDs...)
+	sort.Strings(sortedUserIDs)
+	users := make(map[string]*model.User, len(sortedUserIDs))
+	for _, userID := range sortedUserIDs {
+		user, err := s.UserRepository.SelectUserByPrimaryKeyForUpdate(tx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, myerror.ApplicationError{
+				Message: fmt.Sprintf("user not found. userID=%s", userID),
+				Code:    http.StatusBadRequest,
+			}
+		}
+		users[userID] = user
+	}
+	return users, nil
+}
+
+// selectTradeAssets 両ユーザの所持アイテムを排他ロックで取得し、トレードで渡すアイテムとコインが足りているかを確認する
+// 戻り値はユーザIDごとの所持アイテム(コレクションアイテムIDをキーとする)
+func (s *TradeService) selectTradeAssets(tx *sql.Tx, trade *model.Trade, tradeItems []*model.TradeItem, users map[string]*model.User) (map[string]map[string]*model.UserCollectionItem, error) {
+	for _, coin := range []struct {
+		user *model.User
+		coin int
+	}{
+		{user: users[trade.ProposerUserID], coin: trade.OfferCoin},
+		{user: users[trade.TargetUserID], coin: trade.RequestCoin},
+	} {
+		if coin.user.Coin < coin.coin {
+			return nil, myerror.ApplicationError{
+				Message: fmt.Sprintf("coin is not enough for trade. userID=%s, coin=%d, required=%d", coin.user.ID, coin.user.Coin, coin.coin),
+				Code:    http.StatusBadRequest,
+			}
+		}
+	}
+
+	userCollectionItemMaps := make(map[string]map[string]*model.UserCollectionItem, 2)
+	for _, userID := range []string{trade.ProposerUserID, trade.TargetUserID} {
+		userCollectionItems, err := s.UserCollectionItemRepository.SelectUserCollectionItemsByUserIDForUpdate(tx, userID)
+		if err != nil {
+			return nil, err
+		}
+		userCollectionItemMap := make(map[string]*model.UserCollectionItem, len(userCollectionItems))
+		for _, userCollectionItem := range userCollectionItems {
+			userCollectionItemMap[userCollectionItem.CollectionItemID] = userCollectionItem
+		}
+		userCollectionItemMaps[userID] = userCollectionItemMap
+	}
+
+	for _, tradeItem := range tradeItems {
+		possessed := 0
+		if userCollectionItem, ok := userCollectionItemMaps[tradeItem.UserID][tradeItem.CollectionItemID]; ok {
+			possessed = userCollectionItem.Count
+		}
+		if possessed < tradeItem.Count {
+			return nil, myerror.ApplicationError{
+				Message: fmt.Sprintf("collection item is not enough for trade. userID=%s, collectionID=%s, count=%d, required=%d",
+					tradeItem.UserID, tradeItem.CollectionItemID, possessed, tradeItem.Count),
+				Code: http.StatusBadRequest,
+			}
+		}
+	}
+	if err := s.checkTradableItems(trade, tradeItems); err != nil {
+		return nil, err
+	}
+	return userCollectionItemMaps, nil
+}
+
+// checkTradableItems 渡すユーザが報酬を受け取ったコレクションセットの対象アイテムが含まれていないかを確認する
+// 受け取り後にアイテムを渡すと、別のユーザが同じアイテムで再び報酬を受け取れてしまうため
+// 報酬の受け取りはユーザ情報をロックして記録するため、両ユーザのロック後に呼び出す
+func (s *TradeService) checkTradableItems(trade *model.Trade, tradeItems []*model.TradeItem) error {
+	if len(tradeItems) == 0 {
+		return nil
+	}
+
+	claimedSetIDs := make(map[string][]string, 2)
+	for _, userID := range []string{trade.ProposerUserID, trade.TargetUserID} {
+		userCollectionSetRewards, err := s.UserCollectionSetRewardRepository.SelectUserCollectionSetRewardsByUserID(userID)
+		if err != nil {
+			return err
+		}
+		for _, userCollectionSetReward := range userCollectionSetRewards {
+			claimedSetIDs[userID] = append(claimedSetIDs[userID], userCollectionSetReward.CollectionSetID)
+		}
+	}
+	if len(claimedSetIDs) == 0 {
+		return nil
+	}
+
+	collectionSets, err := s.CollectionSetRepository.SelectCollectionSetAll()
+	if err != nil {
+		return err
+	}
+	collectionItems, err := s.CollectionItemRepository.SelectCollectionItemAll()
+	if err != nil {
+		return err
+	}
+	collectionSetItems, err := s.CollectionSetRepository.SelectCollectionSetItemAll()
+	if err != nil {
+		return err
+	}
+	setItemIDs := collectionSetItemIDsMap(collectionSets, collectionItems, collectionSetItems)
+
+	// ユーザIDごとにトレードできないアイテムと、そのアイテムを含むセットをまとめる
+	untradable := make(map[string]map[string]string, len(claimedSetIDs))
+	for userID, collectionSetIDs := range claimedSetIDs {
+		untradable[userID] = make(map[string]string)
+		for _, collectionSetID := range collectionSetIDs {
+			for _, itemID := range setItemIDs[collectionSetID] {
+				untradable[userID][itemID] = collectionSetID
+			}
+		}
+	}
+	for _, tradeItem := range tradeItems {
+		if collectionSetID, ok := untradable[tradeItem.UserID][tradeItem.CollectionItemID]; ok {
+			return myerror.ApplicationError{
+				Message: fmt.Sprintf("collection item in claimed collection set cannot be traded. userID=%s, collectionID=%s, collectionSetID=%s",
+					tradeItem.UserID, tradeItem.CollectionItemID, collectionSetID),
+				Code: http.StatusBadRequest,
+			}
+		}
+	}
+	return nil
+}
+
+// validatePendingTrade トレードが承認待ちかつ有効期限内であることを確認する
+func validatePendingTrade(trade *model.Trade, now time.Time) error {
+	if trade.Status != constant.TradeStatusPending {
+		return myerror.ApplicationError{
+			Message: fmt.Sprintf("trade is not pending. tradeID=%s, status=%s", trade.ID, trade.Status),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	if !now.Before(trade.ExpiresAt) {
+		return myerror.ApplicationError{
+			Message: fmt.Sprintf("trade has expired. tradeID=%s, expiresAt=%s", trade.ID, trade.ExpiresAt.Format(time.RFC3339)),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	return nil
+}
+
+// tradeNotFoundError トレードが存在しない、または操作できないユーザの場合のエラー
+func tradeNotFoundError(tradeID string) error {
+	return myerror.ApplicationError{
+		Message: fmt.Sprintf("trade not found. tradeID=%s", tradeID),
+		Code:    http.StatusBadRequest,
+	}
+}
+
+// transferTradeItems 所持アイテムにトレードのアイテムの受け渡しを反映し、更新したアイテムをユーザIDごとにコレクションアイテムID順で返す
+// 渡す側の個数を全て減らしてから受け取る側に加える. 個数が足りていることは確認済みとする
+func transferTradeItems(trade *model.Trade, tradeItems []*model.TradeItem, userCollectionItemMaps map[string]map[string]*model.UserCollectionItem, now time.Time) map[string][]*model.UserCollectionItem {
+	changedMaps := make(map[string]map[string]*model.UserCollectionItem, 2)
+	markChanged := func(userCollectionItem *model.UserCollectionItem) {
+		if _, ok := changedMaps[userCollectionItem.UserID]; !ok {
+			changedMaps[userCollectionItem.UserID] = make(map[string]*model.UserCollectionItem)
+		}
+		changedMaps[userCollectionItem.UserID][userCollectionItem.CollectionItemID] = userCollectionItem
+	}
+
+	for _, tradeItem := range tradeItems {
+		userCollectionItem := userCollectionItemMaps[tradeItem.UserID][tradeItem.CollectionItemID]
+		userCollectionItem.Count -= tradeItem.Count
+		userCollectionItem.Level = collectionItemLevel(userCollectionItem.Count)
+		markChanged(userCollectionItem)
+	}
+	for _, tradeItem := range tradeItems {
+		receiverID := trade.TargetUserID
+		if tradeItem.UserID == trade.TargetUserID {
+			receiverID = trade.ProposerUserID
+		}
+		userCollectionItem, ok := userCollectionItemMaps[receiverID][tradeItem.CollectionItemID]
+		if !ok {
+			userCollectionItem = &model.UserCollectionItem{
+				UserID:           receiverID,
+				CollectionItemID: tradeItem.CollectionItemID,
+				FirstAcquiredAt:  now,
+			}
+			userCollectionItemMaps[receiverID][tradeItem.CollectionItemID] = userCollectionItem
+		}
+		userCollectionItem.Count += tradeItem.Count
+		userCollectionItem.Level = collectionItemLevel(userCollectionItem.Count)
+		userCollectionItem.LastAcquiredAt = now
+		markChanged(userCollectionItem)
+	}
+
+	changed := make(map[string][]*model.UserCollectionItem, len(changedMaps))
+	for userID, changedMap := range changedMaps {
+		userCollectionItems := make([]*model.UserCollectionItem, 0, len(changedMap))
+		for _, userCollectionItem := range changedMap {
+			userCollectionItems = append(userCollectionItems, userCollectionItem)
+		}
+		sort.Slice(userCollectionItems, func(i, j int) bool {
+			return userCollectionItems[i].CollectionItemID < userCollectionItems[j].CollectionItemID
+		})
+		changed[userID] = userCollectionItems
+	}
+	return changed
+}
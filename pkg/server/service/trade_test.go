@@ -0,0 +1,459 @@
+package service
+
+import (
+	"20dojo-online/pkg/constant"
+	"20dojo-online/pkg/server/model"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+// ProposeTradeのトランザクション内の処理はDBを利用するため、トランザクション開始前の検証のみテストする
+func TestTradeService_ProposeTrade(t *testing.T) {
+	collectionItems := []*model.CollectionItem{
+		{ID: "1001", Name: "ゴリラ01", Rarity: 1},
+		{ID: "2001", Name: "スゴリラ01", Rarity: 2},
+	}
+
+	type args struct {
+		serviceRequest *ProposeTradeRequest
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		before  func(mock *mockRepository, args args)
+		wantErr string
+	}{
+		{
+			name: "異常:自分への提案",
+			args: args{
+				serviceRequest: &ProposeTradeRequest{
+					UserID:       "UserId1",
+					TargetUserID: "UserId1",
+					OfferItems:   []*TradeItemRequest{{CollectionID: "1001", Count: 1}},
+				},
+			},
+			before:  func(mock *mockRepository, args args) {},
+			wantErr: "cannot propose trade to yourself. userID=UserId1",
+		},
+		{
+			name: "異常:アイテムなし",
+			args: args{
+				serviceRequest: &ProposeTradeRequest{UserID: "UserId1", TargetUserID: "UserId2", OfferCoin: 100},
+			},
+			before:  func(mock *mockRepository, args args) {},
+			wantErr: "trade must include at least one item",
+		},
+		{
+			name: "異常:コインが負",
+			args: args{
+				serviceRequest: &ProposeTradeRequest{
+					UserID:       "UserId1",
+					TargetUserID: "UserId2",
+					OfferItems:   []*TradeItemRequest{{CollectionID: "1001", Count: 1}},
+					RequestCoin:  -1,
+				},
+			},
+			before:  func(mock *mockRepository, args args) {},
+			wantErr: "trade coin must not be negative. offerCoin=0, requestCoin=-1",
+		},
+		{
+			name: "異常:存在しないアイテム",
+			args: args{
+				serviceRequest: &ProposeTradeRequest{
+					UserID:       "UserId1",
+					TargetUserID: "UserId2",
+					OfferItems:   []*TradeItemRequest{{CollectionID: "1001", Count: 1}},
+					RequestItems: []*TradeItemRequest{{CollectionID: "9999", Count: 1}},
+				},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.collectionItemRepository.EXPECT().SelectCollectionItemAll().Return(collectionItems, nil)
+			},
+			wantErr: "collection item not found. collectionID=9999",
+		},
+		{
+			name: "異常:個数が0",
+			args: args{
+				serviceRequest: &ProposeTradeRequest{
+					UserID:       "UserId1",
+					TargetUserID: "UserId2",
+					OfferItems:   []*TradeItemRequest{{CollectionID: "1001", Count: 0}},
+				},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.collectionItemRepository.EXPECT().SelectCollectionItemAll().Return(collectionItems, nil)
+			},
+			wantErr: "trade item count must be positive. collectionID=1001, count=0",
+		},
+		{
+			name: "異常:同じアイテムの重複",
+			args: args{
+				serviceRequest: &ProposeTradeRequest{
+					UserID:       "UserId1",
+					TargetUserID: "UserId2",
+					OfferItems:   []*TradeItemRequest{{CollectionID: "1001", Count: 1}, {CollectionID: "1001", Count: 2}},
+				},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.collectionItemRepository.EXPECT().SelectCollectionItemAll().Return(collectionItems, nil)
+			},
+			wantErr: "collection item is duplicated in trade. collectionID=1001",
+		},
+		{
+			name: "異常:コレクションアイテム取得エラー",
+			args: args{
+				serviceRequest: &ProposeTradeRequest{
+					UserID:       "UserId1",
+					TargetUserID: "UserId2",
+					OfferItems:   []*TradeItemRequest{{CollectionID: "1001", Count: 1}},
+				},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.collectionItemRepository.EXPECT().SelectCollectionItemAll().Return(nil, errors.New("SelectCollectionItemAll failed"))
+			},
+			wantErr: "SelectCollectionItemAll failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMockRepository(ctrl)
+			tt.before(mock, tt.args)
+			s := NewTradeService(mock.tradeRepository, mock.userRepository, mock.userCollectionItemRepository, mock.collectionItemRepository, mock.collectionSetRepository, mock.userCollectionSetRewardRepository)
+			got, err := s.ProposeTrade(tt.args.serviceRequest)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("ProposeTrade() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != nil {
+				t.Errorf("ProposeTrade() got = %v, want nil", got)
+			}
+		})
+	}
+}
+
+// AcceptTradeのトランザクション内の処理はDBを利用するため、トランザクション開始前の検証のみテストする
+func TestTradeService_AcceptTrade(t *testing.T) {
+	type args struct {
+		serviceRequest *TradeActionRequest
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		before  func(mock *mockRepository, args args)
+		wantErr string
+	}{
+		{
+			name: "異常:存在しないトレード",
+			args: args{
+				serviceRequest: &TradeActionRequest{UserID: "UserId2", TradeID: "TradeId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.tradeRepository.EXPECT().SelectTradeByPrimaryKey("TradeId1").Return(nil, nil)
+			},
+			wantErr: "trade not found. tradeID=TradeId1",
+		},
+		{
+			name: "異常:提案したユーザは承認できない",
+			args: args{
+				serviceRequest: &TradeActionRequest{UserID: "UserId1", TradeID: "TradeId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.tradeRepository.EXPECT().SelectTradeByPrimaryKey("TradeId1").Return(&model.Trade{
+					ID:             "TradeId1",
+					ProposerUserID: "UserId1",
+					TargetUserID:   "UserId2",
+				}, nil)
+			},
+			wantErr: "trade not found. tradeID=TradeId1",
+		},
+		{
+			name: "異常:トレード取得エラー",
+			args: args{
+				serviceRequest: &TradeActionRequest{UserID: "UserId2", TradeID: "TradeId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.tradeRepository.EXPECT().SelectTradeByPrimaryKey("TradeId1").Return(nil, errors.New("SelectTradeByPrimaryKey failed"))
+			},
+			wantErr: "SelectTradeByPrimaryKey failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMockRepository(ctrl)
+			tt.before(mock, tt.args)
+			s := NewTradeService(mock.tradeRepository, mock.userRepository, mock.userCollectionItemRepository, mock.collectionItemRepository, mock.collectionSetRepository, mock.userCollectionSetRewardRepository)
+			err := s.AcceptTrade(tt.args.serviceRequest)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("AcceptTrade() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// トランザクションはモックのリポジトリでは利用しないためnilを渡す
+func TestTradeService_acceptTrade(t *testing.T) {
+	now := time.Date(2020, 8, 1, 12, 0, 0, 0, time.Local)
+	pendingTrade := &model.Trade{
+		ID:             "TradeId1",
+		ProposerUserID: "UserId2",
+		TargetUserID:   "UserId1",
+		OfferCoin:      100,
+		Status:         constant.TradeStatusPending,
+		ExpiresAt:      now.Add(time.Hour),
+	}
+	tradeItems := []*model.TradeItem{
+		{TradeID: "TradeId1", UserID: "UserId1", CollectionItemID: "1001", Count: 1},
+	}
+
+	// lockUsers ユーザIDの順にユーザ情報をロックすることを期待する
+	lockUsers := func(mock *mockRepository) {
+		gomock.InOrder(
+			mock.userRepository.EXPECT().SelectUserByPrimaryKeyForUpdate(nil, "UserId1").Return(&model.User{ID: "UserId1", Coin: 0}, nil),
+			mock.userRepository.EXPECT().SelectUserByPrimaryKeyForUpdate(nil, "UserId2").Return(&model.User{ID: "UserId2", Coin: 500}, nil),
+		)
+	}
+	// selectUserCollectionItems 両ユーザの所持アイテムの取得を期待する
+	selectUserCollectionItems := func(mock *mockRepository) {
+		mock.userCollectionItemRepository.EXPECT().SelectUserCollectionItemsByUserIDForUpdate(nil, "UserId2").Return(nil, nil)
+		mock.userCollectionItemRepository.EXPECT().SelectUserCollectionItemsByUserIDForUpdate(nil, "UserId1").Return([]*model.UserCollectionItem{
+			{UserID: "UserId1", CollectionItemID: "1001", Count: 1, Level: 1},
+		}, nil)
+	}
+
+	tests := []struct {
+		name    string
+		before  func(mock *mockRepository)
+		wantErr string
+	}{
+		{
+			name: "正常:アイテムとコインの受け渡し",
+			before: func(mock *mockRepository) {
+				lockUsers(mock)
+				mock.tradeRepository.EXPECT().SelectTradeByPrimaryKeyForUpdate(nil, "TradeId1").Return(pendingTrade, nil)
+				selectUserCollectionItems(mock)
+				mock.userCollectionSetRewardRepository.EXPECT().SelectUserCollectionSetRewardsByUserID("UserId2").Return(nil, nil)
+				mock.userCollectionSetRewardRepository.EXPECT().SelectUserCollectionSetRewardsByUserID("UserId1").Return(nil, nil)
+				mock.userCollectionItemRepository.EXPECT().BulkUpsertUserCollectionItem(nil, []*model.UserCollectionItem{
+					{UserID: "UserId2", CollectionItemID: "1001", Count: 1, Level: 1, FirstAcquiredAt: now, LastAcquiredAt: now},
+				}).Return(nil)
+				mock.userCollectionItemRepository.EXPECT().DeleteUserCollectionItemsByPrimaryKeys(nil, "UserId1", []string{"1001"}).Return(nil)
+				mock.userRepository.EXPECT().UpdateUserCoinByPrimaryKey(nil, "UserId2", 400).Return(nil)
+				mock.userRepository.EXPECT().UpdateUserCoinByPrimaryKey(nil, "UserId1", 100).Return(nil)
+				mock.tradeRepository.EXPECT().UpdateTradeStatusByPrimaryKey(nil, "TradeId1", constant.TradeStatusAccepted, now).Return(nil)
+			},
+		},
+		{
+			name: "異常:有効期限切れ",
+			before: func(mock *mockRepository) {
+				lockUsers(mock)
+				expiredTrade := *pendingTrade
+				expiredTrade.ExpiresAt = now
+				mock.tradeRepository.EXPECT().SelectTradeByPrimaryKeyForUpdate(nil, "TradeId1").Return(&expiredTrade, nil)
+			},
+			wantErr: "trade has expired. tradeID=TradeId1, expiresAt=" + now.Format(time.RFC3339),
+		},
+		{
+			name: "異常:報酬を受け取ったセットのアイテム",
+			before: func(mock *mockRepository) {
+				lockUsers(mock)
+				mock.tradeRepository.EXPECT().SelectTradeByPrimaryKeyForUpdate(nil, "TradeId1").Return(pendingTrade, nil)
+				selectUserCollectionItems(mock)
+				mock.userCollectionSetRewardRepository.EXPECT().SelectUserCollectionSetRewardsByUserID("UserId2").Return(nil, nil)
+				mock.userCollectionSetRewardRepository.EXPECT().SelectUserCollectionSetRewardsByUserID("UserId1").Return([]*model.UserCollectionSetReward{
+					{UserID: "UserId1", CollectionSetID: "1"},
+				}, nil)
+				mock.collectionSetRepository.EXPECT().SelectCollectionSetAll().Return([]*model.CollectionSet{
+					{ID: "1", Rarity: 1},
+					{ID: "4"},
+				}, nil)
+				mock.collectionItemRepository.EXPECT().SelectCollectionItemAll().Return([]*model.CollectionItem{
+					{ID: "1001", Rarity: 1},
+					{ID: "2001", Rarity: 2},
+				}, nil)
+				mock.collectionSetRepository.EXPECT().SelectCollectionSetItemAll().Return([]*model.CollectionSetItem{
+					{CollectionSetID: "4", CollectionItemID: "2001"},
+				}, nil)
+			},
+			wantErr: "collection item in claimed collection set cannot be traded. userID=UserId1, collectionID=1001, collectionSetID=1",
+		},
+		{
+			name: "異常:アイテム不足",
+			before: func(mock *mockRepository) {
+				lockUsers(mock)
+				mock.tradeRepository.EXPECT().SelectTradeByPrimaryKeyForUpdate(nil, "TradeId1").Return(pendingTrade, nil)
+				mock.userCollectionItemRepository.EXPECT().SelectUserCollectionItemsByUserIDForUpdate(nil, "UserId2").Return(nil, nil)
+				mock.userCollectionItemRepository.EXPECT().SelectUserCollectionItemsByUserIDForUpdate(nil, "UserId1").Return(nil, nil)
+			},
+			wantErr: "collection item is not enough for trade. userID=UserId1, collectionID=1001, count=0, required=1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMockRepository(ctrl)
+			tt.before(mock)
+			s := NewTradeService(mock.tradeRepository, mock.userRepository, mock.userCollectionItemRepository, mock.collectionItemRepository, mock.collectionSetRepository, mock.userCollectionSetRewardRepository)
+			err := s.acceptTrade(nil, pendingTrade, tradeItems, now)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("acceptTrade() error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("acceptTrade() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTradeService_GetTradeList(t *testing.T) {
+	createdAt := time.Date(2020, 8, 1, 12, 0, 0, 0, time.Local)
+	expiresAt := createdAt.Add(72 * time.Hour)
+
+	type args struct {
+		serviceRequest *GetTradeListRequest
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		before  func(mock *mockRepository, args args)
+		want    *GetTradeListResponse
+		wantErr bool
+	}{
+		{
+			name: "正常:提案したトレードと自分宛てのトレード",
+			args: args{
+				serviceRequest: &GetTradeListRequest{UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.tradeRepository.EXPECT().SelectPendingTradesByUserID("UserId1", gomock.Any()).Return([]*model.Trade{
+					{ID: "TradeId2", ProposerUserID: "UserId2", TargetUserID: "UserId1", RequestCoin: 300, ExpiresAt: expiresAt, CreatedAt: createdAt},
+					{ID: "TradeId1", ProposerUserID: "UserId1", TargetUserID: "UserId3", OfferCoin: 100, ExpiresAt: expiresAt, CreatedAt: createdAt},
+				}, nil)
+				mock.tradeRepository.EXPECT().SelectTradeItemsByTradeIDs([]string{"TradeId2", "TradeId1"}).Return([]*model.TradeItem{
+					{TradeID: "TradeId1", UserID: "UserId1", CollectionItemID: "1001", Count: 2},
+					{TradeID: "TradeId1", UserID: "UserId3", CollectionItemID: "2001", Count: 1},
+					{TradeID: "TradeId2", UserID: "UserId1", CollectionItemID: "2001", Count: 1},
+				}, nil)
+				mock.collectionItemRepository.EXPECT().SelectCollectionItemAll().Return([]*model.CollectionItem{
+					{ID: "1001", Name: "ゴリラ01", Rarity: 1},
+					{ID: "2001", Name: "スゴリラ01", Rarity: 2},
+				}, nil)
+			},
+			want: &GetTradeListResponse{
+				Sent: []*TradeInfo{
+					{
+						TradeID:        "TradeId1",
+						ProposerUserID: "UserId1",
+						TargetUserID:   "UserId3",
+						OfferItems:     []*TradeItemInfo{{CollectionID: "1001", Name: "ゴリラ01", Rarity: 1, Count: 2}},
+						RequestItems:   []*TradeItemInfo{{CollectionID: "2001", Name: "スゴリラ01", Rarity: 2, Count: 1}},
+						OfferCoin:      100,
+						ExpiresAt:      expiresAt,
+						CreatedAt:      createdAt,
+					},
+				},
+				Received: []*TradeInfo{
+					{
+						TradeID:        "TradeId2",
+						ProposerUserID: "UserId2",
+						TargetUserID:   "UserId1",
+						OfferItems:     []*TradeItemInfo{},
+						RequestItems:   []*TradeItemInfo{{CollectionID: "2001", Name: "スゴリラ01", Rarity: 2, Count: 1}},
+						RequestCoin:    300,
+						ExpiresAt:      expiresAt,
+						CreatedAt:      createdAt,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常:トレードなし",
+			args: args{
+				serviceRequest: &GetTradeListRequest{UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.tradeRepository.EXPECT().SelectPendingTradesByUserID("UserId1", gomock.Any()).Return(nil, nil)
+			},
+			want: &GetTradeListResponse{
+				Sent:     []*TradeInfo{},
+				Received: []*TradeInfo{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常:トレード取得エラー",
+			args: args{
+				serviceRequest: &GetTradeListRequest{UserID: "UserId1"},
+			},
+			before: func(mock *mockRepository, args args) {
+				mock.tradeRepository.EXPECT().SelectPendingTradesByUserID("UserId1", gomock.Any()).Return(nil, errors.New("SelectPendingTradesByUserID failed"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := newMockRepository(ctrl)
+			tt.before(mock, tt.args)
+			s := NewTradeService(mock.tradeRepository, mock.userRepository, mock.userCollectionItemRepository, mock.collectionItemRepository, mock.collectionSetRepository, mock.userCollectionSetRewardRepository)
+			got, err := s.GetTradeList(tt.args.serviceRequest)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetTradeList() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetTradeList() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_transferTradeItems(t *testing.T) {
+	acquiredAt := time.Date(2020, 8, 1, 12, 0, 0, 0, time.Local)
+	now := time.Date(2020, 8, 3, 12, 0, 0, 0, time.Local)
+	trade := &model.Trade{ID: "TradeId1", ProposerUserID: "UserId1", TargetUserID: "UserId2"}
+	tradeItems := []*model.TradeItem{
+		{TradeID: "TradeId1", UserID: "UserId1", CollectionItemID: "1001", Count: 2},
+		{TradeID: "TradeId1", UserID: "UserId1", CollectionItemID: "1002", Count: 1},
+		{TradeID: "TradeId1", UserID: "UserId2", CollectionItemID: "2001", Count: 1},
+	}
+	userCollectionItemMaps := map[string]map[string]*model.UserCollectionItem{
+		"UserId1": {
+			"1001": {UserID: "UserId1", CollectionItemID: "1001", Count: 2, Level: 2, FirstAcquiredAt: acquiredAt, LastAcquiredAt: acquiredAt},
+			"1002": {UserID: "UserId1", CollectionItemID: "1002", Count: 4, Level: 3, FirstAcquiredAt: acquiredAt, LastAcquiredAt: acquiredAt},
+		},
+		"UserId2": {
+			"1002": {UserID: "UserId2", CollectionItemID: "1002", Count: 1, Level: 1, FirstAcquiredAt: acquiredAt, LastAcquiredAt: acquiredAt},
+			"2001": {UserID: "UserId2", CollectionItemID: "2001", Count: 1, Level: 1, FirstAcquiredAt: acquiredAt, LastAcquiredAt: acquiredAt},
+		},
+	}
+
+	want := map[string][]*model.UserCollectionItem{
+		"UserId1": {
+			{UserID: "UserId1", CollectionItemID: "1001", Count: 0, Level: 0, FirstAcquiredAt: acquiredAt, LastAcquiredAt: acquiredAt},
+			{UserID: "UserId1", CollectionItemID: "1002", Count: 3, Level: 2, FirstAcquiredAt: acquiredAt, LastAcquiredAt: acquiredAt},
+			{UserID: "UserId1", CollectionItemID: "2001", Count: 1, Level: 1, FirstAcquiredAt: now, LastAcquiredAt: now},
+		},
+		"UserId2": {
+			{UserID: "UserId2", CollectionItemID: "1001", Count: 2, Level: 2, FirstAcquiredAt: now, LastAcquiredAt: now},
+			{UserID: "UserId2", CollectionItemID: "1002", Count: 2, Level: 2, FirstAcquiredAt: acquiredAt, LastAcquiredAt: now},
+			{UserID: "UserId2", CollectionItemID: "2001", Count: 0, Level: 0, FirstAcquiredAt: acquiredAt, LastAcquiredAt: acquiredAt},
+		},
+	}
+	if got := transferTradeItems(trade, tradeItems, userCollectionItemMaps, now); !reflect.DeepEqual(got, want) {
+		t.Errorf("transferTradeItems() got = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,74 @@
+package session
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidToken 改ざん・形式不正・他ユーザのセッショントークン
+var ErrInvalidToken = errors.New("invalid session token")
+
+// Signer ゲームセッションIDと開始日時にHMAC-SHA256で署名し、トークンとして発行・検証する
+// トークンは「セッションID.開始日時(UNIX時間).署名」の形式となる
+type Signer struct {
+	secret []byte
+}
+
+func NewSigner(secret []byte) *Signer {
+	return &Signer{
+		secret: secret,
+	}
+}
+
+// SecretFromEnv 環境変数GAME_SESSION_SECRETから署名鍵を取得する
+// 未設定の場合はランダムな鍵を生成する(サーバを再起動すると発行済みのトークンは無効になる)
+func SecretFromEnv() []byte {
+	if secret := os.Getenv("GAME_SESSION_SECRET"); secret != "" {
+		return []byte(secret)
+	}
+	log.Println("GAME_SESSION_SECRET is not set. generate a random secret")
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		log.Fatal(err)
+	}
+	return secret
+}
+
+// Sign セッションIDと開始日時に署名したトークンを返す. 署名にはユーザIDも含める
+func (s *Signer) Sign(sessionID string, userID string, startedAt time.Time) string {
+	payload := fmt.Sprintf("%s.%d", sessionID, startedAt.Unix())
+	return payload + "." + s.signature(payload, userID)
+}
+
+// Verify トークンを検証し、セッションIDと開始日時を返す
+func (s *Signer) Verify(token string, userID string) (string, time.Time, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] == "" {
+		return "", time.Time{}, ErrInvalidToken
+	}
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(s.signature(payload, userID))) {
+		return "", time.Time{}, ErrInvalidToken
+	}
+	startedAtUnix, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", time.Time{}, ErrInvalidToken
+	}
+	return parts[0], time.Unix(startedAtUnix, 0), nil
+}
+
+// signature ペイロードとユーザIDの署名を返す
+func (s *Signer) signature(payload string, userID string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(userID + ":" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
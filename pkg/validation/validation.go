@@ -0,0 +1,161 @@
+package validation
+
+import (
+	"20dojo-online/pkg/myerror"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// unknownFieldErrorPrefix DisallowUnknownFieldsで未定義のフィールドがあった場合のエラーメッセージの接頭辞
+const unknownFieldErrorPrefix = "json: unknown field "
+
+// Validatable バリデーションルールを持つリクエスト
+type Validatable interface {
+	Validate(v *Validator)
+}
+
+// DecodeJSON リクエストbodyをデコードし、Validatableであればバリデーションを行う
+// 未定義のフィールドや複数のJSONを含むbodyは受け付けない
+// エラーの場合は不正なフィールドを含むHTTPコード400のApplicationErrorを返す
+func DecodeJSON(body io.Reader, dst interface{}) error {
+	decoder := json.NewDecoder(body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil {
+		return decodeError(err)
+	}
+	// 1つ目のJSONの後に続くデータがある場合
+	if _, err := decoder.Token(); err != io.EOF {
+		return myerror.ApplicationError{
+			Message: "request body must contain a single JSON value",
+			Code:    http.StatusBadRequest,
+		}
+	}
+
+	if validatable, ok := dst.(Validatable); ok {
+		v := New()
+		validatable.Validate(v)
+		return v.Err()
+	}
+	return nil
+}
+
+// decodeError デコードのエラーをHTTPコード400のApplicationErrorに変換する. フィールドを特定できる場合はFieldErrorsに含める
+func decodeError(err error) error {
+	if err == io.EOF {
+		return myerror.ApplicationError{
+			Message: "request body is empty",
+			Code:    http.StatusBadRequest,
+		}
+	}
+	appErr := myerror.ApplicationError{
+		Message:       "failed to decode request body",
+		OriginalError: err,
+		Code:          http.StatusBadRequest,
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		appErr.FieldErrors = []myerror.FieldError{{
+			Field:   typeErr.Field,
+			Message: fmt.Sprintf("must be %s", typeErr.Type.String()),
+		}}
+	}
+	// encoding/jsonは未定義のフィールドのエラーを型で区別できないため、メッセージからフィールド名を取得する
+	if strings.HasPrefix(err.Error(), unknownFieldErrorPrefix) {
+		if field, unquoteErr := strconv.Unquote(strings.TrimPrefix(err.Error(), unknownFieldErrorPrefix)); unquoteErr == nil {
+			appErr.FieldErrors = []myerror.FieldError{{
+				Field:   field,
+				Message: "is not allowed",
+			}}
+		}
+	}
+	return appErr
+}
+
+// Validator フィールド単位のバリデーションエラーを収集する
+type Validator struct {
+	fieldErrors []myerror.FieldError
+}
+
+func New() *Validator {
+	return &Validator{}
+}
+
+// Check 条件を満たさない場合にフィールドのエラーを追加する. 1つのフィールドにつき最初のエラーのみを保持する
+func (v *Validator) Check(ok bool, field string, message string) {
+	if ok {
+		return
+	}
+	for _, fieldError := range v.fieldErrors {
+		if fieldError.Field == field {
+			return
+		}
+	}
+	v.fieldErrors = append(v.fieldErrors, myerror.FieldError{
+		Field:   field,
+		Message: message,
+	})
+}
+
+// Required 文字列が空でないこと
+func (v *Validator) Required(field string, value string) {
+	v.Check(value != "", field, "is required")
+}
+
+// MaxLength 文字列の文字数が上限以下であること
+func (v *Validator) MaxLength(field string, value string, max int) {
+	v.Check(utf8.RuneCountInString(value) <= max, field, fmt.Sprintf("must be at most %d characters", max))
+}
+
+// Min 数値が下限以上であること
+func (v *Validator) Min(field string, value int, min int) {
+	v.Check(value >= min, field, fmt.Sprintf("must be %d or more", min))
+}
+
+// Max 数値が上限以下であること
+func (v *Validator) Max(field string, value int, max int) {
+	v.Check(value <= max, field, fmt.Sprintf("must be %d or less", max))
+}
+
+// OneOf 文字列が許可された値のいずれかであること
+func (v *Validator) OneOf(field string, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.Check(false, field, fmt.Sprintf("must be one of [%s]", strings.Join(allowed, ", ")))
+}
+
+// OneOfInt 数値が許可された値のいずれかであること
+func (v *Validator) OneOfInt(field string, value int, allowed ...int) {
+	allowedStrings := make([]string, 0, len(allowed))
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+		allowedStrings = append(allowedStrings, fmt.Sprintf("%d", a))
+	}
+	v.Check(false, field, fmt.Sprintf("must be one of [%s]", strings.Join(allowedStrings, ", ")))
+}
+
+// Err 収集したエラーをHTTPコード400のApplicationErrorとして返す. エラーがない場合はnilを返す
+func (v *Validator) Err() error {
+	if len(v.fieldErrors) == 0 {
+		return nil
+	}
+	messages := make([]string, 0, len(v.fieldErrors))
+	for _, fieldError := range v.fieldErrors {
+		messages = append(messages, fieldError.Field+" "+fieldError.Message)
+	}
+	return myerror.ApplicationError{
+		Message:     fmt.Sprintf("invalid request. %s", strings.Join(messages, ", ")),
+		Code:        http.StatusBadRequest,
+		FieldErrors: v.fieldErrors,
+	}
+}
@@ -0,0 +1,233 @@
+package validation
+
+import (
+	"20dojo-online/pkg/myerror"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidator(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate func(v *Validator)
+		want     []myerror.FieldError
+	}{
+		{
+			name:     "正常:Required 空でない文字列",
+			validate: func(v *Validator) { v.Required("name", "a") },
+			want:     nil,
+		},
+		{
+			name:     "異常:Required 空文字列",
+			validate: func(v *Validator) { v.Required("name", "") },
+			want:     []myerror.FieldError{{Field: "name", Message: "is required"}},
+		},
+		{
+			name:     "正常:MaxLength 上限と同じ文字数(マルチバイトは1文字として数える)",
+			validate: func(v *Validator) { v.MaxLength("name", "あいう", 3) },
+			want:     nil,
+		},
+		{
+			name:     "異常:MaxLength 上限を超える文字数",
+			validate: func(v *Validator) { v.MaxLength("name", "あいうえ", 3) },
+			want:     []myerror.FieldError{{Field: "name", Message: "must be at most 3 characters"}},
+		},
+		{
+			name:     "正常:Min 下限と同じ値",
+			validate: func(v *Validator) { v.Min("times", 1, 1) },
+			want:     nil,
+		},
+		{
+			name:     "異常:Min 下限未満",
+			validate: func(v *Validator) { v.Min("times", 0, 1) },
+			want:     []myerror.FieldError{{Field: "times", Message: "must be 1 or more"}},
+		},
+		{
+			name:     "正常:Max 上限と同じ値",
+			validate: func(v *Validator) { v.Max("times", 100, 100) },
+			want:     nil,
+		},
+		{
+			name:     "異常:Max 上限を超える値",
+			validate: func(v *Validator) { v.Max("times", 101, 100) },
+			want:     []myerror.FieldError{{Field: "times", Message: "must be 100 or less"}},
+		},
+		{
+			name:     "正常:OneOf 許可された値",
+			validate: func(v *Validator) { v.OneOf("payment", "ticket", "coin", "ticket") },
+			want:     nil,
+		},
+		{
+			name:     "異常:OneOf 許可されていない値",
+			validate: func(v *Validator) { v.OneOf("payment", "card", "coin", "ticket") },
+			want:     []myerror.FieldError{{Field: "payment", Message: "must be one of [coin, ticket]"}},
+		},
+		{
+			name:     "正常:OneOfInt 許可された値",
+			validate: func(v *Validator) { v.OneOfInt("times", 10, 1, 10) },
+			want:     nil,
+		},
+		{
+			name:     "異常:OneOfInt 許可されていない値",
+			validate: func(v *Validator) { v.OneOfInt("times", 5, 1, 10) },
+			want:     []myerror.FieldError{{Field: "times", Message: "must be one of [1, 10]"}},
+		},
+		{
+			name: "異常:1つのフィールドは最初のエラーのみ、フィールドはチェックした順に並ぶ",
+			validate: func(v *Validator) {
+				v.Required("name", "")
+				v.Min("times", 0, 1)
+				v.MaxLength("name", "", -1)
+				v.Max("times", 0, -1)
+				v.OneOf("payment", "card", "coin")
+			},
+			want: []myerror.FieldError{
+				{Field: "name", Message: "is required"},
+				{Field: "times", Message: "must be 1 or more"},
+				{Field: "payment", Message: "must be one of [coin]"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			tt.validate(v)
+			err := v.Err()
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("Err() error = %v, want nil", err)
+				}
+				return
+			}
+			var appErr myerror.ApplicationError
+			if !errors.As(err, &appErr) {
+				t.Fatalf("Err() error = %v, want ApplicationError", err)
+			}
+			if appErr.Code != http.StatusBadRequest {
+				t.Errorf("Err() code = %d, want %d", appErr.Code, http.StatusBadRequest)
+			}
+			if !reflect.DeepEqual(appErr.FieldErrors, tt.want) {
+				t.Errorf("Err() FieldErrors = %v, want %v", appErr.FieldErrors, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidator_ErrMessage(t *testing.T) {
+	v := New()
+	v.Required("name", "")
+	v.Min("times", 0, 1)
+	want := "invalid request. name is required, times must be 1 or more"
+	if err := v.Err(); err == nil || err.Error() != want {
+		t.Errorf("Err() error = %v, want %s", err, want)
+	}
+}
+
+type testRequest struct {
+	Name  string `json:"name"`
+	Times int    `json:"times"`
+}
+
+// Validate 名前は必須、回数は1以上
+func (r *testRequest) Validate(v *Validator) {
+	v.Required("name", r.Name)
+	v.Min("times", r.Times, 1)
+}
+
+func TestDecodeJSON(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            string
+		want            *testRequest
+		wantErrMessage  string // 空の場合はエラーなし
+		wantFieldErrors []myerror.FieldError
+	}{
+		{
+			name: "正常:デコードとバリデーション",
+			body: `{"name": "a", "times": 1}`,
+			want: &testRequest{Name: "a", Times: 1},
+		},
+		{
+			name: "正常:末尾の空白",
+			body: "{\"name\": \"a\", \"times\": 1}\n",
+			want: &testRequest{Name: "a", Times: 1},
+		},
+		{
+			name:           "異常:バリデーションエラー",
+			body:           `{"name": "", "times": 0}`,
+			wantErrMessage: "invalid request. name is required, times must be 1 or more",
+			wantFieldErrors: []myerror.FieldError{
+				{Field: "name", Message: "is required"},
+				{Field: "times", Message: "must be 1 or more"},
+			},
+		},
+		{
+			name:            "異常:型が異なるフィールド",
+			body:            `{"name": "a", "times": "1"}`,
+			wantErrMessage:  "failed to decode request body",
+			wantFieldErrors: []myerror.FieldError{{Field: "times", Message: "must be int"}},
+		},
+		{
+			name:            "異常:未定義のフィールド",
+			body:            `{"name": "a", "times": 1, "count": 1}`,
+			wantErrMessage:  "failed to decode request body",
+			wantFieldErrors: []myerror.FieldError{{Field: "count", Message: "is not allowed"}},
+		},
+		{
+			name:            "異常:不正なJSON",
+			body:            `{"name": "a",`,
+			wantErrMessage:  "failed to decode request body",
+			wantFieldErrors: nil,
+		},
+		{
+			name:            "異常:JSONの後に続くデータ",
+			body:            `{"name": "a", "times": 1} {"name": "b", "times": 2}`,
+			wantErrMessage:  "request body must contain a single JSON value",
+			wantFieldErrors: nil,
+		},
+		{
+			name:            "異常:JSONの後に続く不正なデータ",
+			body:            `{"name": "a", "times": 1}}`,
+			wantErrMessage:  "request body must contain a single JSON value",
+			wantFieldErrors: nil,
+		},
+		{
+			name:            "異常:空のbody",
+			body:            ``,
+			wantErrMessage:  "request body is empty",
+			wantFieldErrors: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got testRequest
+			err := DecodeJSON(strings.NewReader(tt.body), &got)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("DecodeJSON() error = %v, want nil", err)
+					return
+				}
+				if !reflect.DeepEqual(&got, tt.want) {
+					t.Errorf("DecodeJSON() got = %+v, want %+v", &got, tt.want)
+				}
+				return
+			}
+			var appErr myerror.ApplicationError
+			if !errors.As(err, &appErr) {
+				t.Fatalf("DecodeJSON() error = %v, want ApplicationError", err)
+			}
+			if appErr.Code != http.StatusBadRequest {
+				t.Errorf("DecodeJSON() code = %d, want %d", appErr.Code, http.StatusBadRequest)
+			}
+			if appErr.Message != tt.wantErrMessage {
+				t.Errorf("DecodeJSON() message = %s, want %s", appErr.Message, tt.wantErrMessage)
+			}
+			if !reflect.DeepEqual(appErr.FieldErrors, tt.wantFieldErrors) {
+				t.Errorf("DecodeJSON() FieldErrors = %v, want %v", appErr.FieldErrors, tt.wantFieldErrors)
+			}
+		})
+	}
+}